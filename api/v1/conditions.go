@@ -0,0 +1,134 @@
+package v1
+
+// Condition types used in the status of the operator resources
+const (
+	// ConditionReady indicates that the resource is fully applied
+	ConditionReady = "Ready"
+	// ConditionProgressing indicates that the resource is being applied
+	ConditionProgressing = "Progressing"
+	// ConditionDegraded indicates that the resource failed to apply
+	ConditionDegraded = "Degraded"
+)
+
+// Condition types used in the status of the SriovNetworkNodeState
+const (
+	// NodeStateConditionSynced indicates that the node configuration matches the spec
+	NodeStateConditionSynced = "Synced"
+	// NodeStateConditionDrainRequired indicates that the config-daemon requested to drain the node
+	NodeStateConditionDrainRequired = "DrainRequired"
+	// NodeStateConditionRebootRequired indicates that the config-daemon requested to reboot the node
+	NodeStateConditionRebootRequired = "RebootRequired"
+	// NodeStateConditionPluginError indicates that one of the config-daemon plugins failed
+	NodeStateConditionPluginError = "PluginError"
+)
+
+// Condition reasons used in the status of the SriovNetworkNodeState
+const (
+	// NodeStateReasonSucceeded the configuration was applied
+	NodeStateReasonSucceeded = "Succeeded"
+	// NodeStateReasonInProgress the configuration is being applied
+	NodeStateReasonInProgress = "InProgress"
+	// NodeStateReasonFailed the configuration failed to apply
+	NodeStateReasonFailed = "Failed"
+	// NodeStateReasonNoFailures the last configuration attempt didn't fail
+	NodeStateReasonNoFailures = "NoFailures"
+	// NodeStateReasonDrainRequired the pending configuration requires to drain the node
+	NodeStateReasonDrainRequired = "DrainRequired"
+	// NodeStateReasonRebootRequired the pending configuration requires to reboot the node
+	NodeStateReasonRebootRequired = "RebootRequired"
+	// NodeStateReasonNotRequired the pending configuration can be applied without drain or reboot
+	NodeStateReasonNotRequired = "NotRequired"
+	// NodeStateReasonPluginFailed a plugin returned an error
+	NodeStateReasonPluginFailed = "PluginFailed"
+	// NodeStateReasonNoPluginError none of the plugins returned an error
+	NodeStateReasonNoPluginError = "NoPluginError"
+)
+
+// Condition reasons used in the status of the SriovNetworkNodePolicy
+const (
+	// PolicyReasonNoMatchingNodes the policy doesn't select any node
+	PolicyReasonNoMatchingNodes = "NoMatchingNodes"
+	// PolicyReasonAllNodesApplied the policy was applied on all the selected nodes
+	PolicyReasonAllNodesApplied = "AllNodesApplied"
+	// PolicyReasonNodesPending some of the selected nodes didn't apply the policy yet
+	PolicyReasonNodesPending = "NodesPending"
+	// PolicyReasonNodesFailed some of the selected nodes failed to apply the policy
+	PolicyReasonNodesFailed = "NodesFailed"
+	// PolicyReasonNoFailures none of the selected nodes failed to apply the policy
+	PolicyReasonNoFailures = "NoFailures"
+	// PolicyReasonNoPendingNodes all the selected nodes finished to apply the policy
+	PolicyReasonNoPendingNodes = "NoPendingNodes"
+	// PolicyReasonDryRun the policy is in dry-run mode and is not applied on the nodes
+	PolicyReasonDryRun = "DryRun"
+	// PolicyReasonPreviewFailed the changes caused by the policy can't be computed on some nodes
+	PolicyReasonPreviewFailed = "PreviewFailed"
+	// PolicyReasonMacAllocationFailed the MAC address of some VFs can't be assigned because of a collision or an exhausted pool
+	PolicyReasonMacAllocationFailed = "MacAllocationFailed"
+)
+
+// Condition reasons used in the status of the SriovNetworkPoolConfig
+const (
+	// PoolReasonNodesDraining some of the nodes in the pool are being drained
+	PoolReasonNodesDraining = "NodesDraining"
+	// PoolReasonNoDrainInProgress none of the nodes in the pool is being drained
+	PoolReasonNoDrainInProgress = "NoDrainInProgress"
+	// PoolReasonDrainPending some of the nodes in the pool wait for the next maintenance window or for an approval to be drained
+	PoolReasonDrainPending = "DrainPending"
+	// PoolReasonConflictingNodes some of the nodes in the pool are selected by other pools
+	PoolReasonConflictingNodes = "ConflictingNodes"
+	// PoolReasonInvalidMaxUnavailable the maxUnavailable value of the pool can't be resolved
+	PoolReasonInvalidMaxUnavailable = "InvalidMaxUnavailable"
+	// PoolReasonInvalidMaintenanceWindows the maintenance windows of the pool can't be evaluated
+	PoolReasonInvalidMaintenanceWindows = "InvalidMaintenanceWindows"
+	// PoolReasonInvalidNodeSelector the node selector of the pool can't be parsed, the pool doesn't select any node
+	PoolReasonInvalidNodeSelector = "InvalidNodeSelector"
+	// PoolReasonDrainFailed the drain of some of the nodes in the pool failed with the Fail pdbPolicy
+	PoolReasonDrainFailed = "DrainFailed"
+	// PoolReasonValid the pool configuration is valid
+	PoolReasonValid = "Valid"
+)
+
+// Condition reasons used in the status of the network CRs
+const (
+	// NetworkReasonNetAttDefApplied the NetworkAttachmentDefinition was created or updated
+	NetworkReasonNetAttDefApplied = "NetAttDefApplied"
+	// NetworkReasonRenderFailed the NetworkAttachmentDefinition can't be rendered from the network
+	NetworkReasonRenderFailed = "RenderFailed"
+	// NetworkReasonNamespaceNotFound the target namespace of the network doesn't exist
+	NetworkReasonNamespaceNotFound = "NamespaceNotFound"
+	// NetworkReasonApplyFailed the NetworkAttachmentDefinition can't be created or updated
+	NetworkReasonApplyFailed = "ApplyFailed"
+)
+
+// Components reported in the status of the SriovOperatorConfig
+const (
+	ComponentConfigDaemon    = "ConfigDaemon"
+	ComponentDevicePlugin    = "DevicePlugin"
+	ComponentMetricsExporter = "MetricsExporter"
+	ComponentInjector        = "NetworkResourcesInjector"
+	ComponentOperatorWebhook = "OperatorWebhook"
+)
+
+// Condition reasons used in the status of the SriovOperatorConfig
+const (
+	// ComponentReasonDisabled the component is disabled in the config
+	ComponentReasonDisabled = "Disabled"
+	// ComponentReasonNotFound the DaemonSet of the component doesn't exist yet
+	ComponentReasonNotFound = "DaemonSetNotFound"
+	// ComponentReasonRolloutComplete all the pods of the component run the latest version and are ready
+	ComponentReasonRolloutComplete = "RolloutComplete"
+	// ComponentReasonRollingOut the pods of the component are being updated
+	ComponentReasonRollingOut = "RollingOut"
+	// ComponentReasonPodsNotReady some pods of the component are not ready after the rollout
+	ComponentReasonPodsNotReady = "PodsNotReady"
+	// ComponentReasonAllReady all the enabled components are ready
+	ComponentReasonAllReady = "AllComponentsReady"
+	// ComponentReasonNotReady some of the enabled components are not ready
+	ComponentReasonNotReady = "ComponentsNotReady"
+	// ComponentReasonProgressing some of the enabled components are being updated
+	ComponentReasonProgressing = "ComponentsProgressing"
+	// ComponentReasonDegraded some of the enabled components are degraded
+	ComponentReasonDegraded = "ComponentsDegraded"
+	// ComponentReasonNoFailures none of the enabled components is degraded
+	ComponentReasonNoFailures = "NoFailures"
+)
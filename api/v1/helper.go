@@ -2,8 +2,10 @@ package v1
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -12,10 +14,13 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/robfig/cron"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	intstrutil "k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -241,6 +246,16 @@ func FindInterface(interfaces Interfaces, name string) (iface Interface, err err
 	return Interface{}, fmt.Errorf("unable to find interface: %v", name)
 }
 
+// FindInterfaceByPciAddress returns the interface with the PCI address from the list
+func FindInterfaceByPciAddress(interfaces Interfaces, pciAddress string) (iface Interface, err error) {
+	for _, i := range interfaces {
+		if i.PciAddress == pciAddress {
+			return i, nil
+		}
+	}
+	return Interface{}, fmt.Errorf("unable to find interface with PCI address: %v", pciAddress)
+}
+
 // GetEswitchModeFromSpec returns ESwitchMode from the interface spec, returns legacy if not set
 func GetEswitchModeFromSpec(ifaceSpec *Interface) string {
 	if ifaceSpec.EswitchMode == "" {
@@ -276,6 +291,11 @@ func NeedToUpdateSriov(ifaceSpec *Interface, ifaceStatus *InterfaceExt) bool {
 		return true
 	}
 
+	if ifaceSpec.PfSettings != nil && !ifaceSpec.ExternallyManaged && !PfSettingsSatisfied(ifaceSpec.PfSettings, ifaceStatus.PfSettings) {
+		log.V(0).Info("NeedToUpdateSriov(): PF settings need update", "desired", ifaceSpec.PfSettings, "current", ifaceStatus.PfSettings)
+		return true
+	}
+
 	if ifaceStatus.LinkAdminState == consts.LinkAdminStateDown {
 		log.V(0).Info("NeedToUpdateSriov(): PF link status needs update", "desired to include", "up", "current", ifaceStatus.LinkAdminState)
 		return true
@@ -285,6 +305,14 @@ func NeedToUpdateSriov(ifaceSpec *Interface, ifaceStatus *InterfaceExt) bool {
 		for _, vfStatus := range ifaceStatus.VFs {
 			for _, groupSpec := range ifaceSpec.VfGroups {
 				if IndexInRange(vfStatus.VfID, groupSpec.VfRange) {
+					if vfStatus.Name != "" || StringInArray(vfStatus.Driver, vars.DpdkDrivers) {
+						if mac := groupSpec.MacForVf(vfStatus.VfID); mac != "" && vfStatus.AdminMac != "" &&
+							!strings.EqualFold(mac, vfStatus.AdminMac) {
+							log.V(0).Info("NeedToUpdateSriov(): VF admin MAC needs update",
+								"vf", vfStatus.VfID, "desired", mac, "current", vfStatus.AdminMac)
+							return true
+						}
+					}
 					if vfStatus.Driver == "" {
 						log.V(0).Info("NeedToUpdateSriov(): Driver needs update - has no driver",
 							"desired", groupSpec.DeviceType)
@@ -338,6 +366,132 @@ func NeedToUpdateSriov(ifaceSpec *Interface, ifaceStatus *InterfaceExt) bool {
 	return false
 }
 
+// NeedToUpdateVFs returns true if the VFs of one of the PFs reported in the status must be reconfigured
+// to reach the desired spec, in which case the node has to be drained.
+// lastApplied returns the configuration last applied on a PF, or nil if the VFs of the PF were not
+// created by the operator. It is used to reset the VFs of the PFs which are not part of the desired
+// spec anymore, and to detect the changes of the VF defaults and of the PF ring sizes and channels
+// which are not compared with the status.
+func NeedToUpdateVFs(desired *SriovNetworkNodeStateSpec, current *SriovNetworkNodeStateStatus, lastApplied func(pciAddress string) *Interface) bool {
+	return len(PfsToUpdateVFs(desired, current, lastApplied)) > 0
+}
+
+// PfsToUpdateVFs returns the PCI addresses of the PFs reported in the status whose VFs must be reconfigured
+// or removed to reach the desired spec, see NeedToUpdateVFs.
+func PfsToUpdateVFs(desired *SriovNetworkNodeStateSpec, current *SriovNetworkNodeStateStatus, lastApplied func(pciAddress string) *Interface) []string {
+	pfs := []string{}
+	for _, ifaceStatus := range current.Interfaces {
+		configured := false
+		for _, iface := range desired.Interfaces {
+			if iface.PciAddress == ifaceStatus.PciAddress {
+				configured = true
+				if ifaceStatus.NumVfs == 0 {
+					log.V(2).Info("NeedToUpdateVFs(): no need drain, for PCI address, current NumVfs is 0",
+						"address", iface.PciAddress)
+					break
+				}
+				if NeedToUpdateSriov(&iface, &ifaceStatus) {
+					log.V(2).Info("NeedToUpdateVFs(): need drain, for PCI address request update",
+						"address", iface.PciAddress)
+					pfs = append(pfs, ifaceStatus.PciAddress)
+					break
+				}
+				if applied := lastApplied(ifaceStatus.PciAddress); applied != nil &&
+					(VfDefaultsChanged(&iface, applied) || PfSettingsChanged(&iface, applied)) {
+					log.V(2).Info("NeedToUpdateVFs(): need drain, for PCI address VF default attributes or PF settings update",
+						"address", iface.PciAddress)
+					pfs = append(pfs, ifaceStatus.PciAddress)
+					break
+				}
+				log.V(2).Info("NeedToUpdateVFs(): no need drain,for PCI address",
+					"address", iface.PciAddress, "expected-vfs", iface.NumVfs, "current-vfs", ifaceStatus.NumVfs)
+			}
+		}
+		if !configured && ifaceStatus.NumVfs > 0 && needReset(lastApplied(ifaceStatus.PciAddress)) {
+			log.V(2).Info("NeedToUpdateVFs(): need drain since interface needs to be reset",
+				"interface", ifaceStatus)
+			pfs = append(pfs, ifaceStatus.PciAddress)
+		}
+	}
+	return pfs
+}
+
+// PfSettingsSatisfied returns true if all the features and pause settings defined in the desired PF
+// settings match the current PF settings, settings which are not defined in desired are ignored.
+// The ring sizes and channels are not compared as drivers can round or clamp them, see PfSettingsChanged.
+func PfSettingsSatisfied(desired, current *PfSettings) bool {
+	if desired == nil {
+		return true
+	}
+	if current == nil {
+		current = &PfSettings{}
+	}
+	boolSatisfied := func(d, c *bool) bool {
+		return d == nil || (c != nil && *d == *c)
+	}
+	for feature, enabled := range desired.Features {
+		if currentState, ok := current.Features[feature]; !ok || currentState != enabled {
+			return false
+		}
+	}
+	if desired.Pause != nil {
+		currentPause := current.Pause
+		if currentPause == nil {
+			currentPause = &PauseSettings{}
+		}
+		if !boolSatisfied(desired.Pause.Autoneg, currentPause.Autoneg) ||
+			!boolSatisfied(desired.Pause.Rx, currentPause.Rx) ||
+			!boolSatisfied(desired.Pause.Tx, currentPause.Tx) {
+			return false
+		}
+	}
+	return true
+}
+
+// PfSettingsChanged returns true if the ring sizes or channels of the desired interface differ from the ones
+// last applied on the PF. They are not compared with the PF status as the driver can adjust the values it
+// accepts, settings which are not defined in desired are left unchanged on the PF and are ignored.
+func PfSettingsChanged(desired, applied *Interface) bool {
+	if desired.PfSettings == nil {
+		return false
+	}
+	appliedSettings := applied.PfSettings
+	if appliedSettings == nil {
+		appliedSettings = &PfSettings{}
+	}
+	intChanged := func(d, a *int) bool {
+		return d != nil && (a == nil || *d != *a)
+	}
+	return intChanged(desired.PfSettings.RxRingSize, appliedSettings.RxRingSize) ||
+		intChanged(desired.PfSettings.TxRingSize, appliedSettings.TxRingSize) ||
+		intChanged(desired.PfSettings.CombinedChannels, appliedSettings.CombinedChannels)
+}
+
+// needReset returns true if the VFs of a PF which is not part of the desired spec anymore were created by the operator
+func needReset(applied *Interface) bool {
+	return applied != nil && !applied.ExternallyManaged
+}
+
+// VfDefaultsChanged returns true if the default attributes of the VFs of the desired interface differ
+// from the ones last applied on the PF. The defaults are not compared with the VF status as the VFs
+// bound to a userspace driver or attached to a workload don't report them.
+func VfDefaultsChanged(desired, applied *Interface) bool {
+	vfDefaults := func(iface *Interface, vfID int) VfDefaults {
+		for _, group := range iface.VfGroups {
+			if IndexInRange(vfID, group.VfRange) && group.VfDefaults != nil {
+				return *group.VfDefaults
+			}
+		}
+		return VfDefaults{}
+	}
+	for vfID := 0; vfID < desired.NumVfs; vfID++ {
+		if !reflect.DeepEqual(vfDefaults(desired, vfID), vfDefaults(applied, vfID)) {
+			return true
+		}
+	}
+	return false
+}
+
 type ByPriority []SriovNetworkNodePolicy
 
 func (a ByPriority) Len() int {
@@ -355,15 +509,22 @@ func (a ByPriority) Swap(i, j int) {
 	a[i], a[j] = a[j], a[i]
 }
 
-// Match check if node is selected by NodeSelector
+// NodeLabelSelector returns the label selector built from NodeSelector and NodeSelectorExpressions
+func (p *SriovNetworkNodePolicy) NodeLabelSelector() (labels.Selector, error) {
+	return metav1.LabelSelectorAsSelector(&metav1.LabelSelector{
+		MatchLabels:      p.Spec.NodeSelector,
+		MatchExpressions: p.Spec.NodeSelectorExpressions,
+	})
+}
+
+// Match check if node is selected by NodeSelector and NodeSelectorExpressions
 func (p *SriovNetworkNodePolicy) Selected(node *corev1.Node) bool {
-	for k, v := range p.Spec.NodeSelector {
-		if nv, ok := node.Labels[k]; ok && nv == v {
-			continue
-		}
+	selector, err := p.NodeLabelSelector()
+	if err != nil {
+		log.Error(err, "invalid node selector", "policy", p.GetName())
 		return false
 	}
-	return true
+	return selector.Matches(labels.Set(node.Labels))
 }
 
 func StringInArray(val string, array []string) bool {
@@ -397,6 +558,12 @@ func UniqueAppend(inSlice []string, strings ...string) []string {
 	return inSlice
 }
 
+// IsDryRun returns true if the policy has the dry-run annotation, a policy in dry-run mode
+// is not applied on the nodes
+func (p *SriovNetworkNodePolicy) IsDryRun() bool {
+	return p.GetAnnotations()[consts.PolicyDryRunAnnotation] == "true"
+}
+
 // Apply policy to SriovNetworkNodeState CR
 func (p *SriovNetworkNodePolicy) Apply(state *SriovNetworkNodeState, equalPriority bool) error {
 	s := p.Spec.NicSelector
@@ -415,6 +582,7 @@ func (p *SriovNetworkNodePolicy) Apply(state *SriovNetworkNodeState, equalPriori
 				EswitchMode:       p.Spec.EswitchMode,
 				NumVfs:            p.Spec.NumVfs,
 				ExternallyManaged: p.Spec.ExternallyManaged,
+				PfSettings:        p.Spec.PfSettings.DeepCopy(),
 			}
 			if p.Spec.NumVfs > 0 {
 				group, err := p.generatePfNameVfGroup(&iface)
@@ -440,6 +608,41 @@ func (p *SriovNetworkNodePolicy) Apply(state *SriovNetworkNodeState, equalPriori
 	return nil
 }
 
+// ApplyPolicies merges the configuration of the policies selecting the node into the spec of the node state,
+// the policies must be sorted by priority. The bridge configuration is applied only if manageSoftwareBridges is true.
+func (s *SriovNetworkNodeState) ApplyPolicies(policies []SriovNetworkNodePolicy, node *corev1.Node, manageSoftwareBridges bool) error {
+	// Previous Policy Priority(ppp) records the priority of previous evaluated policy in node policy list.
+	// Since node policy list is already sorted with priority number, comparing current priority with ppp shall
+	// be sufficient.
+	// ppp is set to 100 as initial value to avoid matching with the first policy in policy list, although
+	// it should not matter since the flag used in p.Apply() will only be applied when VF partition is detected.
+	ppp := 100
+	for i := range policies {
+		p := &policies[i]
+		// Note(adrianc): default policy is deprecated and ignored.
+		if p.Name == consts.DefaultPolicyName {
+			continue
+		}
+		if p.Selected(node) {
+			log.Info("apply", "policy", p.Name, "node", node.Name)
+			// Merging only for policies with the same priority (ppp == p.Spec.Priority)
+			// This boolean flag controls merging of PF configuration (e.g. mtu, numvfs etc)
+			// when VF partition is configured.
+			if err := p.Apply(s, ppp == p.Spec.Priority); err != nil {
+				return err
+			}
+			if manageSoftwareBridges {
+				if err := p.ApplyBridgeConfig(s); err != nil {
+					return err
+				}
+			}
+			// record the evaluated policy priority for next loop
+			ppp = p.Spec.Priority
+		}
+	}
+	return nil
+}
+
 // ApplyBridgeConfig applies bridge configuration from the policy to the provided state
 func (p *SriovNetworkNodePolicy) ApplyBridgeConfig(state *SriovNetworkNodeState) error {
 	if p.Spec.NicSelector.IsEmpty() {
@@ -528,6 +731,10 @@ func (iface Interface) mergeConfigs(input *Interface, equalPriority bool) {
 	if input.NumVfs < iface.NumVfs {
 		input.NumVfs = iface.NumVfs
 	}
+	// PF settings are taken from the highest priority policy which defines them
+	if input.PfSettings == nil {
+		input.PfSettings = iface.PfSettings
+	}
 }
 
 func (gr VfGroup) isVFRangeOverlapping(group VfGroup) bool {
@@ -578,6 +785,7 @@ func (p *SriovNetworkNodePolicy) generatePfNameVfGroup(iface *InterfaceExt) (*Vf
 		Mtu:          p.Spec.Mtu,
 		IsRdma:       p.Spec.IsRdma,
 		VdpaType:     p.Spec.VdpaType,
+		VfDefaults:   p.Spec.VfDefaults.DeepCopy(),
 	}, nil
 }
 
@@ -592,6 +800,132 @@ func IndexInRange(i int, r string) bool {
 	return false
 }
 
+// VfIDs returns the indexes of the VFs in the range of the group
+func (gr *VfGroup) VfIDs() []int {
+	rngSt, rngEnd, err := parseRange(gr.VfRange)
+	if err != nil {
+		return nil
+	}
+	ids := make([]int, 0, rngEnd-rngSt+1)
+	for i := rngSt; i <= rngEnd; i++ {
+		ids = append(ids, i)
+	}
+	return ids
+}
+
+// MacForVf returns the admin MAC address assigned to the VF in the group, empty if there is none
+func (gr *VfGroup) MacForVf(vfID int) string {
+	for _, vfMac := range gr.VfMacs {
+		if vfMac.VfID == vfID {
+			return vfMac.Mac
+		}
+	}
+	return ""
+}
+
+// MacRange is a range of MAC addresses stored as integers
+type MacRange struct {
+	Start uint64
+	End   uint64
+}
+
+// MacToUint64 converts a MAC address to an integer
+func MacToUint64(mac net.HardwareAddr) uint64 {
+	var v uint64
+	for _, b := range mac {
+		v = v<<8 | uint64(b)
+	}
+	return v
+}
+
+// Uint64ToMac converts an integer to a MAC address
+func Uint64ToMac(v uint64) net.HardwareAddr {
+	mac := make(net.HardwareAddr, 6)
+	for i := 5; i >= 0; i-- {
+		mac[i] = byte(v)
+		v >>= 8
+	}
+	return mac
+}
+
+func parseUnicastMac(s string) (net.HardwareAddr, error) {
+	mac, err := net.ParseMAC(s)
+	if err != nil || len(mac) != 6 {
+		return nil, fmt.Errorf("invalid MAC address %q", s)
+	}
+	if mac[0]&0x01 != 0 {
+		return nil, fmt.Errorf("MAC address %q is a multicast address", s)
+	}
+	return mac, nil
+}
+
+// ParseMacPool parses the MAC address ranges of the pool, each range can be a single
+// MAC address or a "<first>-<last>" range
+func ParseMacPool(pool []string) ([]MacRange, error) {
+	ranges := make([]MacRange, 0, len(pool))
+	for _, r := range pool {
+		start, end, found := strings.Cut(r, "-")
+		if !found {
+			end = start
+		}
+		startMac, err := parseUnicastMac(strings.TrimSpace(start))
+		if err != nil {
+			return nil, err
+		}
+		endMac, err := parseUnicastMac(strings.TrimSpace(end))
+		if err != nil {
+			return nil, err
+		}
+		rng := MacRange{Start: MacToUint64(startMac), End: MacToUint64(endMac)}
+		if rng.End < rng.Start {
+			return nil, fmt.Errorf("invalid MAC address range %q, the last address is lower than the first one", r)
+		}
+		if rng.Start>>40 != rng.End>>40 {
+			return nil, fmt.Errorf("invalid MAC address range %q, all the addresses must have the same first byte", r)
+		}
+		ranges = append(ranges, rng)
+	}
+	return ranges, nil
+}
+
+// HashVfMac derives the admin MAC address of a VF from the prefix and a hash of
+// the node name, the PF PCI address and the VF index
+func HashVfMac(prefix, nodeName, pciAddress string, vfID int) (string, error) {
+	mac := make(net.HardwareAddr, 0, 6)
+	for _, part := range strings.Split(prefix, ":") {
+		b, err := strconv.ParseUint(part, 16, 8)
+		if err != nil || len(part) != 2 {
+			return "", fmt.Errorf("invalid MAC address prefix %q", prefix)
+		}
+		mac = append(mac, byte(b))
+	}
+	if len(mac) == 0 || len(mac) > 3 {
+		return "", fmt.Errorf("invalid MAC address prefix %q, it must contain from 1 to 3 bytes", prefix)
+	}
+	if mac[0]&0x01 != 0 {
+		return "", fmt.Errorf("invalid MAC address prefix %q, it must not be a multicast address", prefix)
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%d", nodeName, pciAddress, vfID)))
+	mac = append(mac, sum[:6-len(mac)]...)
+	return mac.String(), nil
+}
+
+// ValidateVfMacAllocation checks that exactly one of prefix and pool is set and that it is valid
+func ValidateVfMacAllocation(a *VfMacAllocation) error {
+	if a == nil {
+		return nil
+	}
+	if (a.Prefix == "") == (len(a.Pool) == 0) {
+		return fmt.Errorf("exactly one of prefix and pool must be set in vfMacAllocation")
+	}
+	if a.Prefix != "" {
+		_, err := HashVfMac(a.Prefix, "", "", 0)
+		return err
+	}
+	_, err := ParseMacPool(a.Pool)
+	return err
+}
+
 func parseRange(r string) (rngSt, rngEnd int, err error) {
 	rng := strings.Split(r, "-")
 	rngSt, err = strconv.Atoi(rng[0])
@@ -640,14 +974,20 @@ func (selector *SriovNetworkNicSelector) IsEmpty() bool {
 }
 
 func (selector *SriovNetworkNicSelector) Selected(iface *InterfaceExt) bool {
+	return selector.NotSelectedReason(iface) == ""
+}
+
+// NotSelectedReason returns why the interface is not selected by the nicSelector,
+// an empty string is returned if the interface is selected
+func (selector *SriovNetworkNicSelector) NotSelectedReason(iface *InterfaceExt) string {
 	if selector.Vendor != "" && selector.Vendor != iface.Vendor {
-		return false
+		return fmt.Sprintf("vendor %q doesn't match %q", iface.Vendor, selector.Vendor)
 	}
 	if selector.DeviceID != "" && selector.DeviceID != iface.DeviceID {
-		return false
+		return fmt.Sprintf("device ID %q doesn't match %q", iface.DeviceID, selector.DeviceID)
 	}
-	if len(selector.RootDevices) > 0 && !StringInArray(iface.PciAddress, selector.RootDevices) {
-		return false
+	if len(selector.RootDevices) > 0 && !PciAddressInList(iface.PciAddress, selector.RootDevices) {
+		return fmt.Sprintf("PCI address %s is not in the root devices", iface.PciAddress)
 	}
 	if len(selector.PfNames) > 0 {
 		var pfNames []string
@@ -660,16 +1000,159 @@ func (selector *SriovNetworkNicSelector) Selected(iface *InterfaceExt) bool {
 			}
 		}
 		if !StringInArray(iface.Name, pfNames) {
-			return false
+			return fmt.Sprintf("PF name %q is not in the PF names", iface.Name)
 		}
 	}
 	if selector.NetFilter != "" && !NetFilterMatch(selector.NetFilter, iface.NetFilter) {
-		return false
+		return fmt.Sprintf("net filter %q doesn't match %q", iface.NetFilter, selector.NetFilter)
+	}
+	if StringInArray(iface.Name, selector.ExcludePfNames) {
+		return fmt.Sprintf("PF name %q is excluded", iface.Name)
+	}
+	if PciAddressInList(iface.PciAddress, selector.ExcludeRootDevices) {
+		return fmt.Sprintf("PCI address %s is excluded", iface.PciAddress)
+	}
+	if len(selector.NumaNodes) > 0 {
+		if iface.NumaNode == nil {
+			return "NUMA node is unknown"
+		}
+		if !slices.Contains(selector.NumaNodes, *iface.NumaNode) {
+			return fmt.Sprintf("NUMA node %d is not in the NUMA nodes", *iface.NumaNode)
+		}
+	}
+	// the speed of a PF is unknown while its link is down, it is not filtered out so that a link
+	// flap doesn't reset the VFs of a configured PF
+	if speed := ParseLinkSpeed(iface.LinkSpeed); len(selector.LinkSpeeds) > 0 && speed != -1 &&
+		!slices.Contains(selector.LinkSpeeds, speed) {
+		return fmt.Sprintf("link speed %q is not in the link speeds", iface.LinkSpeed)
 	}
+	if len(selector.PfDrivers) > 0 && !StringInArray(iface.Driver, selector.PfDrivers) {
+		return fmt.Sprintf("driver %q is not in the PF drivers", iface.Driver)
+	}
+
+	return ""
+}
 
+// HasNodeSpecificFilters returns true if the selector uses filters that the device plugin can't evaluate,
+// in this case the selected PFs must be resolved from the node state.
+func (selector *SriovNetworkNicSelector) HasNodeSpecificFilters() bool {
+	if len(selector.ExcludePfNames) > 0 || len(selector.ExcludeRootDevices) > 0 ||
+		len(selector.NumaNodes) > 0 || len(selector.LinkSpeeds) > 0 || len(selector.PfDrivers) > 0 {
+		return true
+	}
+	for _, rootDevice := range selector.RootDevices {
+		if IsPciAddressPattern(rootDevice) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseLinkSpeed returns the link speed in Mb/s from the "<speed> Mb/s" format reported in the node state,
+// -1 is returned when the speed is unknown
+func ParseLinkSpeed(linkSpeed string) int {
+	speed, err := strconv.Atoi(strings.TrimSpace(strings.TrimSuffix(linkSpeed, "Mb/s")))
+	if err != nil {
+		return -1
+	}
+	return speed
+}
+
+// IsPciAddressPattern returns true if the PCI address contains wildcards or ranges
+func IsPciAddressPattern(pattern string) bool {
+	return strings.ContainsAny(pattern, "*-")
+}
+
+// ValidatePciAddressPattern checks the format of a PCI address pattern
+func ValidatePciAddressPattern(pattern string) error {
+	fields := splitPciAddress(pattern)
+	if len(fields) != 4 {
+		return fmt.Errorf("invalid PCI address %q: expected format is <domain>:<bus>:<device>.<function>", pattern)
+	}
+	for _, field := range fields {
+		if field == "*" {
+			continue
+		}
+		bounds := strings.Split(field, "-")
+		if len(bounds) > 2 {
+			return fmt.Errorf("invalid PCI address %q: invalid range %q", pattern, field)
+		}
+		values := []uint64{}
+		for _, b := range bounds {
+			v, err := strconv.ParseUint(b, 16, 32)
+			if err != nil {
+				return fmt.Errorf("invalid PCI address %q: %q is not an hexadecimal value", pattern, b)
+			}
+			values = append(values, v)
+		}
+		if len(values) == 2 && values[0] > values[1] {
+			return fmt.Errorf("invalid PCI address %q: invalid range %q", pattern, field)
+		}
+	}
+	return nil
+}
+
+// PciAddressMatch checks if the PCI address matches the pattern, each field of the pattern can be
+// an exact value, a wildcard "*" or an inclusive hexadecimal range "<start>-<end>"
+func PciAddressMatch(pattern, address string) bool {
+	if !IsPciAddressPattern(pattern) {
+		return strings.EqualFold(pattern, address)
+	}
+	patternFields := splitPciAddress(pattern)
+	addressFields := splitPciAddress(address)
+	if len(patternFields) != 4 || len(addressFields) != 4 {
+		return false
+	}
+	for i, field := range patternFields {
+		if field == "*" {
+			continue
+		}
+		value, err := strconv.ParseUint(addressFields[i], 16, 32)
+		if err != nil {
+			return false
+		}
+		bounds := strings.Split(field, "-")
+		start, err := strconv.ParseUint(bounds[0], 16, 32)
+		if err != nil {
+			return false
+		}
+		end := start
+		if len(bounds) == 2 {
+			end, err = strconv.ParseUint(bounds[1], 16, 32)
+			if err != nil {
+				return false
+			}
+		}
+		if value < start || value > end {
+			return false
+		}
+	}
 	return true
 }
 
+// PciAddressInList checks if the PCI address matches one of the patterns
+func PciAddressInList(address string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if PciAddressMatch(pattern, address) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitPciAddress splits a PCI address to its domain, bus, device and function fields
+func splitPciAddress(address string) []string {
+	fields := strings.Split(address, ":")
+	if len(fields) != 3 {
+		return nil
+	}
+	devFunc := strings.Split(fields[2], ".")
+	if len(devFunc) != 2 {
+		return nil
+	}
+	return []string{fields[0], fields[1], devFunc[0], devFunc[1]}
+}
+
 func (s *SriovNetworkNodeState) GetInterfaceStateByPciAddress(addr string) *InterfaceExt {
 	for _, iface := range s.Status.Interfaces {
 		if addr == iface.PciAddress {
@@ -756,6 +1239,11 @@ func (cr *SriovIBNetwork) NetworkNamespace() string {
 	return cr.Spec.NetworkNamespace
 }
 
+// GetNetworkStatus returns the status of the network
+func (cr *SriovIBNetwork) GetNetworkStatus() *NetworkStatus {
+	return &cr.Status.NetworkStatus
+}
+
 // RenderNetAttDef renders a net-att-def for sriov CNI
 func (cr *SriovNetwork) RenderNetAttDef() (*uns.Unstructured, error) {
 	logger := log.WithName("RenderNetAttDef")
@@ -874,6 +1362,11 @@ func (cr *SriovNetwork) NetworkNamespace() string {
 	return cr.Spec.NetworkNamespace
 }
 
+// GetNetworkStatus returns the status of the network
+func (cr *SriovNetwork) GetNetworkStatus() *NetworkStatus {
+	return &cr.Status.NetworkStatus
+}
+
 // RenderNetAttDef renders a net-att-def for sriov CNI
 func (cr *OVSNetwork) RenderNetAttDef() (*uns.Unstructured, error) {
 	logger := log.WithName("RenderNetAttDef")
@@ -936,6 +1429,11 @@ func (cr *OVSNetwork) NetworkNamespace() string {
 	return cr.Spec.NetworkNamespace
 }
 
+// GetNetworkStatus returns the status of the network
+func (cr *OVSNetwork) GetNetworkStatus() *NetworkStatus {
+	return &cr.Status.NetworkStatus
+}
+
 // NetFilterMatch -- parse netFilter and check for a match
 func NetFilterMatch(netFilter string, netValue string) (isMatch bool) {
 	logger := log.WithName("NetFilterMatch")
@@ -995,6 +1493,159 @@ func (s *SriovNetworkPoolConfig) MaxUnavailable(numOfNodes int) (int, error) {
 	return maxunavail, nil
 }
 
+// MaintenanceWindowOpen returns true if the nodes of the pool can be drained at the given time,
+// otherwise it also returns the time the next maintenance window opens
+func (s *SriovNetworkPoolConfig) MaintenanceWindowOpen(now time.Time) (bool, time.Time, error) {
+	if len(s.Spec.MaintenanceWindows) == 0 {
+		return true, time.Time{}, nil
+	}
+	// an empty time zone is UTC
+	loc, err := time.LoadLocation(s.Spec.TimeZone)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("invalid timeZone %q: %v", s.Spec.TimeZone, err)
+	}
+	now = now.In(loc)
+
+	// evaluate all the windows to report the invalid ones
+	open := false
+	var next time.Time
+	for i := range s.Spec.MaintenanceWindows {
+		windowOpen, windowNext, err := s.Spec.MaintenanceWindows[i].open(now)
+		if err != nil {
+			return false, time.Time{}, fmt.Errorf("invalid maintenance window %d: %v", i, err)
+		}
+		open = open || windowOpen
+		if !windowNext.IsZero() && (next.IsZero() || windowNext.Before(next)) {
+			next = windowNext
+		}
+	}
+	if open {
+		return true, time.Time{}, nil
+	}
+	return false, next, nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"Sunday":    time.Sunday,
+	"Monday":    time.Monday,
+	"Tuesday":   time.Tuesday,
+	"Wednesday": time.Wednesday,
+	"Thursday":  time.Thursday,
+	"Friday":    time.Friday,
+	"Saturday":  time.Saturday,
+}
+
+// open returns true if the window is open at the given time, otherwise it also returns
+// the time the window opens next
+func (w *MaintenanceWindow) open(now time.Time) (bool, time.Time, error) {
+	if w.Schedule != "" {
+		if len(w.Days) > 0 || w.StartTime != "" || w.EndTime != "" {
+			return false, time.Time{}, fmt.Errorf("schedule can't be combined with days, startTime and endTime")
+		}
+		if w.Duration == nil || w.Duration.Duration <= 0 {
+			return false, time.Time{}, fmt.Errorf("a positive duration is required with schedule")
+		}
+		schedule, err := cron.ParseStandard(w.Schedule)
+		if err != nil {
+			return false, time.Time{}, fmt.Errorf("invalid schedule %q: %v", w.Schedule, err)
+		}
+		// the window is open if it opened during the last duration
+		if start := schedule.Next(now.Add(-w.Duration.Duration)); !start.After(now) {
+			return true, time.Time{}, nil
+		}
+		return false, schedule.Next(now), nil
+	}
+
+	if w.Duration != nil {
+		return false, time.Time{}, fmt.Errorf("duration can only be used with schedule")
+	}
+	if w.StartTime == "" || w.EndTime == "" {
+		return false, time.Time{}, fmt.Errorf("either schedule or startTime and endTime must be set")
+	}
+	start, err := time.Parse("15:04", w.StartTime)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("invalid startTime %q: %v", w.StartTime, err)
+	}
+	end, err := time.Parse("15:04", w.EndTime)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("invalid endTime %q: %v", w.EndTime, err)
+	}
+	days := map[time.Weekday]bool{}
+	for _, day := range w.Days {
+		weekday, ok := weekdays[day]
+		if !ok {
+			return false, time.Time{}, fmt.Errorf("invalid day %q", day)
+		}
+		days[weekday] = true
+	}
+
+	// start the day before as a window can close the day after it opened
+	for offset := -1; offset <= 7; offset++ {
+		windowStart := time.Date(now.Year(), now.Month(), now.Day()+offset, start.Hour(), start.Minute(), 0, 0, now.Location())
+		if len(days) > 0 && !days[windowStart.Weekday()] {
+			continue
+		}
+		windowEnd := time.Date(now.Year(), now.Month(), now.Day()+offset, end.Hour(), end.Minute(), 0, 0, now.Location())
+		if !windowEnd.After(windowStart) {
+			windowEnd = windowEnd.AddDate(0, 0, 1)
+		}
+		if windowStart.After(now) {
+			return false, windowStart, nil
+		}
+		if now.Before(windowEnd) {
+			return true, time.Time{}, nil
+		}
+	}
+	return false, time.Time{}, nil
+}
+
+// DrainPodSelectors returns the selectors of the pods to skip and of the pods to evict during the drain
+// of the nodes of the pool, a selector is nil when it is not configured
+func (s *SriovNetworkPoolConfig) DrainPodSelectors() (labels.Selector, labels.Selector, error) {
+	if s.Spec.DrainConfig == nil {
+		return nil, nil, nil
+	}
+	var skip, evict labels.Selector
+	var err error
+	if s.Spec.DrainConfig.SkipPodSelector != nil {
+		skip, err = metav1.LabelSelectorAsSelector(s.Spec.DrainConfig.SkipPodSelector)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid skipPodSelector: %v", err)
+		}
+	}
+	if s.Spec.DrainConfig.EvictPodSelector != nil {
+		evict, err = metav1.LabelSelectorAsSelector(s.Spec.DrainConfig.EvictPodSelector)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid evictPodSelector: %v", err)
+		}
+	}
+	return skip, evict, nil
+}
+
+// ValidateDrainConfig checks the drain configuration of the pool
+func (s *SriovNetworkPoolConfig) ValidateDrainConfig() error {
+	c := s.Spec.DrainConfig
+	if c == nil {
+		return nil
+	}
+	if c.Timeout != nil && c.Timeout.Duration <= 0 {
+		return fmt.Errorf("timeout must be positive")
+	}
+	if c.RequeueInterval != nil && c.RequeueInterval.Duration <= 0 {
+		return fmt.Errorf("requeueInterval must be positive")
+	}
+	if c.PDBForceTimeout != nil {
+		if c.PDBPolicy != PDBPolicyForce {
+			return fmt.Errorf("pdbForceTimeout can only be used with the %s pdbPolicy", PDBPolicyForce)
+		}
+		if c.PDBForceTimeout.Duration < 0 {
+			return fmt.Errorf("pdbForceTimeout can't be negative")
+		}
+	}
+	_, _, err := s.DrainPodSelectors()
+	return err
+}
+
 // GenerateBridgeName generate predictable name for the software bridge
 // current format is: br-0000_00_03.0
 func GenerateBridgeName(iface *InterfaceExt) string {
@@ -7,11 +7,15 @@ import (
 	"flag"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	intstrutil "k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
 
 	v1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
@@ -961,6 +965,167 @@ func TestGetEswitchModeFromStatus(t *testing.T) {
 	}
 }
 
+func TestSriovNetworkNodePolicy_Selected(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{
+		"feature.node.kubernetes.io/network-sriov.capable": "true",
+		"rack": "r2",
+	}}}
+
+	testtable := []struct {
+		tname        string
+		nodeSelector map[string]string
+		expressions  []metav1.LabelSelectorRequirement
+		expected     bool
+	}{
+		{
+			tname:        "empty selector",
+			nodeSelector: map[string]string{},
+			expected:     true,
+		},
+		{
+			tname:        "matching labels",
+			nodeSelector: map[string]string{"feature.node.kubernetes.io/network-sriov.capable": "true"},
+			expected:     true,
+		},
+		{
+			tname:        "not matching labels",
+			nodeSelector: map[string]string{"feature.node.kubernetes.io/network-sriov.capable": "false"},
+			expected:     false,
+		},
+		{
+			tname:        "matching In expression",
+			nodeSelector: map[string]string{"feature.node.kubernetes.io/network-sriov.capable": "true"},
+			expressions:  []metav1.LabelSelectorRequirement{{Key: "rack", Operator: metav1.LabelSelectorOpIn, Values: []string{"r1", "r2"}}},
+			expected:     true,
+		},
+		{
+			tname:       "not matching NotIn expression",
+			expressions: []metav1.LabelSelectorRequirement{{Key: "rack", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"r2"}}},
+			expected:    false,
+		},
+		{
+			tname:       "matching DoesNotExist expression",
+			expressions: []metav1.LabelSelectorRequirement{{Key: "node-role.kubernetes.io/master", Operator: metav1.LabelSelectorOpDoesNotExist}},
+			expected:    true,
+		},
+		{
+			tname:       "invalid expression",
+			expressions: []metav1.LabelSelectorRequirement{{Key: "rack", Operator: metav1.LabelSelectorOpExists, Values: []string{"r2"}}},
+			expected:    false,
+		},
+	}
+	for _, tc := range testtable {
+		t.Run(tc.tname, func(t *testing.T) {
+			policy := &v1.SriovNetworkNodePolicy{Spec: v1.SriovNetworkNodePolicySpec{
+				NodeSelector:            tc.nodeSelector,
+				NodeSelectorExpressions: tc.expressions,
+			}}
+			if selected := policy.Selected(node); selected != tc.expected {
+				t.Errorf("expected Selected to return %t, got %t", tc.expected, selected)
+			}
+		})
+	}
+}
+
+func TestPciAddressMatch(t *testing.T) {
+	testtable := []struct {
+		pattern  string
+		address  string
+		expected bool
+	}{
+		{"0000:3b:00.0", "0000:3b:00.0", true},
+		{"0000:3b:00.0", "0000:3b:00.1", false},
+		{"0000:3b:00.*", "0000:3b:00.1", true},
+		{"0000:3b:00.*", "0000:3c:00.1", false},
+		{"*:*:*.*", "0000:af:00.1", true},
+		{"0000:3b-3d:00.0", "0000:3c:00.0", true},
+		{"0000:3b-3d:00.0", "0000:3e:00.0", false},
+		{"0000:3b-3d:00.0-1", "0000:3d:00.1", true},
+		{"0000:3b-3d:00.0-1", "0000:3d:00.2", false},
+		{"0000:3b-3d:00", "0000:3b:00.0", false},
+	}
+	for _, tc := range testtable {
+		t.Run(tc.pattern+"/"+tc.address, func(t *testing.T) {
+			if match := v1.PciAddressMatch(tc.pattern, tc.address); match != tc.expected {
+				t.Errorf("expected PciAddressMatch to return %t, got %t", tc.expected, match)
+			}
+		})
+	}
+}
+
+func TestValidatePciAddressPattern(t *testing.T) {
+	for _, pattern := range []string{"0000:3b:00.0", "0000:3b:00.*", "0000:3b-3d:00.0"} {
+		if err := v1.ValidatePciAddressPattern(pattern); err != nil {
+			t.Errorf("unexpected error for %s: %v", pattern, err)
+		}
+	}
+	for _, pattern := range []string{"0000:3b:00", "0000:3d-3b:00.0", "0000:3b-3c-3d:00.0", "0000:xx:00.0"} {
+		if err := v1.ValidatePciAddressPattern(pattern); err == nil {
+			t.Errorf("expected an error for %s", pattern)
+		}
+	}
+}
+
+func TestSriovNetworkNicSelector_Selected(t *testing.T) {
+	numaNode := 1
+	iface := &v1.InterfaceExt{
+		Name:       "ens1f0",
+		PciAddress: "0000:3b:00.0",
+		Vendor:     "8086",
+		DeviceID:   "158b",
+		Driver:     "i40e",
+		LinkSpeed:  "25000 Mb/s",
+		NumaNode:   &numaNode,
+	}
+
+	testtable := []struct {
+		tname    string
+		selector v1.SriovNetworkNicSelector
+		expected bool
+	}{
+		{"wildcard root device", v1.SriovNetworkNicSelector{RootDevices: []string{"0000:3b:00.*"}}, true},
+		{"excluded PF name", v1.SriovNetworkNicSelector{Vendor: "8086", ExcludePfNames: []string{"ens1f0"}}, false},
+		{"excluded root device", v1.SriovNetworkNicSelector{Vendor: "8086", ExcludeRootDevices: []string{"0000:3a-3c:00.0"}}, false},
+		{"matching NUMA node", v1.SriovNetworkNicSelector{Vendor: "8086", NumaNodes: []int{0, 1}}, true},
+		{"not matching NUMA node", v1.SriovNetworkNicSelector{Vendor: "8086", NumaNodes: []int{0}}, false},
+		{"matching link speed", v1.SriovNetworkNicSelector{Vendor: "8086", LinkSpeeds: []int{25000}}, true},
+		{"not matching link speed", v1.SriovNetworkNicSelector{Vendor: "8086", LinkSpeeds: []int{100000}}, false},
+		{"matching driver", v1.SriovNetworkNicSelector{PfDrivers: []string{"i40e", "ice"}}, true},
+		{"not matching driver", v1.SriovNetworkNicSelector{PfDrivers: []string{"mlx5_core"}}, false},
+	}
+	for _, tc := range testtable {
+		t.Run(tc.tname, func(t *testing.T) {
+			if selected := tc.selector.Selected(iface); selected != tc.expected {
+				t.Errorf("expected Selected to return %t, got %t", tc.expected, selected)
+			}
+		})
+	}
+}
+
+func TestSriovNetworkNicSelector_NotSelectedReason(t *testing.T) {
+	iface := &v1.InterfaceExt{Name: "ens1f0", PciAddress: "0000:3b:00.0", Vendor: "8086", DeviceID: "158b"}
+
+	testtable := []struct {
+		tname    string
+		selector v1.SriovNetworkNicSelector
+		expected string
+	}{
+		{"selected", v1.SriovNetworkNicSelector{Vendor: "8086", PfNames: []string{"ens1f0#0-3"}}, ""},
+		{"vendor", v1.SriovNetworkNicSelector{Vendor: "15b3"}, `vendor "8086" doesn't match "15b3"`},
+		{"PF name", v1.SriovNetworkNicSelector{Vendor: "8086", PfNames: []string{"ens2f0"}}, `PF name "ens1f0" is not in the PF names`},
+		{"unknown NUMA node", v1.SriovNetworkNicSelector{NumaNodes: []int{0}}, "NUMA node is unknown"},
+		// the link speed is unknown while the link is down
+		{"unknown link speed", v1.SriovNetworkNicSelector{Vendor: "8086", LinkSpeeds: []int{25000}}, ""},
+	}
+	for _, tc := range testtable {
+		t.Run(tc.tname, func(t *testing.T) {
+			if reason := tc.selector.NotSelectedReason(iface); reason != tc.expected {
+				t.Errorf("expected NotSelectedReason to return %q, got %q", tc.expected, reason)
+			}
+		})
+	}
+}
+
 func TestSriovNetworkPoolConfig_MaxUnavailable(t *testing.T) {
 	testtable := []struct {
 		tname       string
@@ -1055,6 +1220,51 @@ func TestSriovNetworkPoolConfig_MaxUnavailable(t *testing.T) {
 	}
 }
 
+func TestHashVfMac(t *testing.T) {
+	mac, err := v1.HashVfMac("02:5a", "worker-0", "0000:3b:00.0", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(mac, "02:5a:") || len(mac) != 17 {
+		t.Errorf("HashVfMac() = %s, want a MAC address with the 02:5a prefix", mac)
+	}
+	again, _ := v1.HashVfMac("02:5a", "worker-0", "0000:3b:00.0", 3)
+	if mac != again {
+		t.Errorf("HashVfMac() is not deterministic: %s != %s", mac, again)
+	}
+	other, _ := v1.HashVfMac("02:5a", "worker-1", "0000:3b:00.0", 3)
+	if mac == other {
+		t.Errorf("HashVfMac() returned the same MAC address for two nodes: %s", mac)
+	}
+	for _, prefix := range []string{"", "01", "02:5a:00:01", "2:5a"} {
+		if _, err := v1.HashVfMac(prefix, "worker-0", "0000:3b:00.0", 3); err == nil {
+			t.Errorf("HashVfMac() with prefix %q should fail", prefix)
+		}
+	}
+}
+
+func TestParseMacPool(t *testing.T) {
+	ranges, err := v1.ParseMacPool([]string{"02:00:00:00:10:00-02:00:00:00:10:ff", "02:00:00:00:20:00"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ranges) != 2 || ranges[0].End-ranges[0].Start != 255 || ranges[1].Start != ranges[1].End {
+		t.Errorf("ParseMacPool() = %v", ranges)
+	}
+	if v1.Uint64ToMac(ranges[0].Start).String() != "02:00:00:00:10:00" {
+		t.Errorf("Uint64ToMac() = %s", v1.Uint64ToMac(ranges[0].Start))
+	}
+	for _, pool := range [][]string{
+		{"02:00:00:00:10:ff-02:00:00:00:10:00"},
+		{"02:00:00:00:10:00-04:00:00:00:10:00"},
+		{"not-a-mac"},
+	} {
+		if _, err := v1.ParseMacPool(pool); err == nil {
+			t.Errorf("ParseMacPool(%v) should fail", pool)
+		}
+	}
+}
+
 func TestNeedToUpdateSriov(t *testing.T) {
 	type args struct {
 		ifaceSpec   *v1.Interface
@@ -1113,6 +1323,87 @@ func TestNeedToUpdateSriov(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "VF admin MAC changed",
+			args: args{
+				ifaceSpec: &v1.Interface{NumVfs: 1, VfGroups: []v1.VfGroup{{
+					VfRange:    "0-0",
+					DeviceType: "vfio-pci",
+					VfMacs:     []v1.VfMac{{VfID: 0, Mac: "02:5a:00:00:00:01"}},
+				}}},
+				ifaceStatus: &v1.InterfaceExt{NumVfs: 1, VFs: []v1.VirtualFunction{
+					{VfID: 0, Driver: "vfio-pci", AdminMac: "00:00:00:00:00:00"},
+				}},
+			},
+			want: true,
+		},
+		{
+			name: "VF admin MAC already set",
+			args: args{
+				ifaceSpec: &v1.Interface{NumVfs: 1, VfGroups: []v1.VfGroup{{
+					VfRange:    "0-0",
+					DeviceType: "vfio-pci",
+					VfMacs:     []v1.VfMac{{VfID: 0, Mac: "02:5A:00:00:00:01"}},
+				}}},
+				ifaceStatus: &v1.InterfaceExt{NumVfs: 1, VFs: []v1.VirtualFunction{
+					{VfID: 0, Driver: "vfio-pci", AdminMac: "02:5a:00:00:00:01"},
+				}},
+			},
+			want: false,
+		},
+		{
+			name: "PF settings changed",
+			args: args{
+				ifaceSpec: &v1.Interface{NumVfs: 1, PfSettings: &v1.PfSettings{
+					RxRingSize: pointer.Int(4096),
+					Features:   map[string]bool{consts.PfFeatureLro: true},
+				}},
+				ifaceStatus: &v1.InterfaceExt{NumVfs: 1, PfSettings: &v1.PfSettings{
+					RxRingSize: pointer.Int(4096),
+					TxRingSize: pointer.Int(1024),
+					Features:   map[string]bool{consts.PfFeatureLro: false},
+				}},
+			},
+			want: true,
+		},
+		{
+			name: "PF ring size rounded up by the driver",
+			args: args{
+				ifaceSpec: &v1.Interface{NumVfs: 1, PfSettings: &v1.PfSettings{
+					RxRingSize:       pointer.Int(1000),
+					CombinedChannels: pointer.Int(63),
+				}},
+				ifaceStatus: &v1.InterfaceExt{NumVfs: 1, PfSettings: &v1.PfSettings{
+					RxRingSize:       pointer.Int(1024),
+					TxRingSize:       pointer.Int(1024),
+					CombinedChannels: pointer.Int(64),
+				}},
+			},
+			want: false,
+		},
+		{
+			name: "PF settings already applied",
+			args: args{
+				ifaceSpec: &v1.Interface{NumVfs: 1, PfSettings: &v1.PfSettings{
+					RxRingSize: pointer.Int(4096),
+					Pause:      &v1.PauseSettings{Rx: pointer.Bool(false)},
+				}},
+				ifaceStatus: &v1.InterfaceExt{NumVfs: 1, PfSettings: &v1.PfSettings{
+					RxRingSize: pointer.Int(4096),
+					TxRingSize: pointer.Int(1024),
+					Pause:      &v1.PauseSettings{Autoneg: pointer.Bool(true), Rx: pointer.Bool(false), Tx: pointer.Bool(false)},
+				}},
+			},
+			want: false,
+		},
+		{
+			name: "PF settings can't be read",
+			args: args{
+				ifaceSpec:   &v1.Interface{NumVfs: 1, PfSettings: &v1.PfSettings{Features: map[string]bool{consts.PfFeatureLro: true}}},
+				ifaceStatus: &v1.InterfaceExt{NumVfs: 1},
+			},
+			want: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1123,6 +1414,113 @@ func TestNeedToUpdateSriov(t *testing.T) {
 	}
 }
 
+func TestNeedToUpdateVFs(t *testing.T) {
+	desired := &v1.SriovNetworkNodeStateSpec{Interfaces: v1.Interfaces{{
+		PciAddress: "0000:86:00.0",
+		NumVfs:     2,
+		VfGroups: []v1.VfGroup{{
+			VfRange:    "0-1",
+			DeviceType: "vfio-pci",
+			VfDefaults: &v1.VfDefaults{Trust: "on", MaxTxRate: pointer.Int(1000)},
+		}},
+	}}}
+	// the trust mode of a VF bound to a userspace driver is not reported in the status
+	current := &v1.SriovNetworkNodeStateStatus{Interfaces: v1.InterfaceExts{{
+		PciAddress: "0000:86:00.0",
+		NumVfs:     2,
+		VFs: []v1.VirtualFunction{
+			{VfID: 0, Driver: "vfio-pci"},
+			{VfID: 1, Driver: "vfio-pci"},
+		},
+	}}}
+
+	tests := []struct {
+		name    string
+		applied *v1.Interface
+		want    bool
+	}{
+		{
+			name: "VF default attributes added",
+			applied: &v1.Interface{PciAddress: "0000:86:00.0", NumVfs: 2, VfGroups: []v1.VfGroup{{
+				VfRange:    "0-1",
+				DeviceType: "vfio-pci",
+			}}},
+			want: true,
+		},
+		{
+			name: "VF default attributes changed",
+			applied: &v1.Interface{PciAddress: "0000:86:00.0", NumVfs: 2, VfGroups: []v1.VfGroup{{
+				VfRange:    "0-1",
+				DeviceType: "vfio-pci",
+				VfDefaults: &v1.VfDefaults{Trust: "off", MaxTxRate: pointer.Int(1000)},
+			}}},
+			want: true,
+		},
+		{
+			name:    "VF default attributes already applied",
+			applied: desired.Interfaces[0].DeepCopy(),
+			want:    false,
+		},
+		{
+			name:    "no configuration applied by the operator",
+			applied: nil,
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := v1.NeedToUpdateVFs(desired, current, func(pciAddress string) *v1.Interface {
+				return tt.applied
+			})
+			if got != tt.want {
+				t.Errorf("NeedToUpdateVFs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPfSettingsChanged(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired *v1.PfSettings
+		applied *v1.PfSettings
+		want    bool
+	}{
+		{
+			name:    "no PF settings",
+			desired: nil,
+			applied: &v1.PfSettings{RxRingSize: pointer.Int(4096)},
+			want:    false,
+		},
+		{
+			name:    "ring size added",
+			desired: &v1.PfSettings{RxRingSize: pointer.Int(4096)},
+			applied: nil,
+			want:    true,
+		},
+		{
+			name:    "channels changed",
+			desired: &v1.PfSettings{CombinedChannels: pointer.Int(8)},
+			applied: &v1.PfSettings{CombinedChannels: pointer.Int(16)},
+			want:    true,
+		},
+		{
+			name:    "settings already applied",
+			desired: &v1.PfSettings{RxRingSize: pointer.Int(1000), TxRingSize: pointer.Int(1000)},
+			applied: &v1.PfSettings{RxRingSize: pointer.Int(1000), TxRingSize: pointer.Int(1000), CombinedChannels: pointer.Int(8)},
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := v1.PfSettingsChanged(&v1.Interface{PfSettings: tt.desired}, &v1.Interface{PfSettings: tt.applied})
+			if got != tt.want {
+				t.Errorf("PfSettingsChanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestSriovNetworkNodePolicyApplyBridgeConfig(t *testing.T) {
 	testtable := []struct {
 		tname           string
@@ -1534,3 +1932,115 @@ func TestNeedToUpdateBridges(t *testing.T) {
 		})
 	}
 }
+
+func TestSriovNetworkPoolConfig_MaintenanceWindowOpen(t *testing.T) {
+	// Wednesday 2024-01-10 12:30 UTC
+	now := time.Date(2024, 1, 10, 12, 30, 0, 0, time.UTC)
+	testtable := []struct {
+		tname        string
+		timeZone     string
+		windows      []v1.MaintenanceWindow
+		expectedOpen bool
+		expectedNext time.Time
+		expectedErr  bool
+	}{
+		{
+			tname:        "no window",
+			expectedOpen: true,
+		},
+		{
+			tname:        "inside a time range",
+			windows:      []v1.MaintenanceWindow{{StartTime: "12:00", EndTime: "13:00"}},
+			expectedOpen: true,
+		},
+		{
+			tname:        "before a time range",
+			windows:      []v1.MaintenanceWindow{{StartTime: "22:00", EndTime: "04:00"}},
+			expectedNext: time.Date(2024, 1, 10, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			tname:        "inside a time range opened the day before",
+			windows:      []v1.MaintenanceWindow{{Days: []string{"Tuesday"}, StartTime: "22:00", EndTime: "13:00"}},
+			expectedOpen: true,
+		},
+		{
+			tname:        "next allowed weekday",
+			windows:      []v1.MaintenanceWindow{{Days: []string{"Saturday", "Sunday"}, StartTime: "00:00", EndTime: "06:00"}},
+			expectedNext: time.Date(2024, 1, 13, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			tname:        "time range in a time zone",
+			timeZone:     "America/New_York",
+			windows:      []v1.MaintenanceWindow{{StartTime: "07:00", EndTime: "08:00"}},
+			expectedOpen: true,
+		},
+		{
+			tname:        "inside a cron window",
+			windows:      []v1.MaintenanceWindow{{Schedule: "0 12 * * 3", Duration: &metav1.Duration{Duration: time.Hour}}},
+			expectedOpen: true,
+		},
+		{
+			tname:        "after a cron window",
+			windows:      []v1.MaintenanceWindow{{Schedule: "0 11 * * *", Duration: &metav1.Duration{Duration: time.Hour}}},
+			expectedNext: time.Date(2024, 1, 11, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			tname: "earliest of several windows",
+			windows: []v1.MaintenanceWindow{
+				{Schedule: "0 2 * * *", Duration: &metav1.Duration{Duration: time.Hour}},
+				{StartTime: "20:00", EndTime: "21:00"},
+			},
+			expectedNext: time.Date(2024, 1, 10, 20, 0, 0, 0, time.UTC),
+		},
+		{
+			tname:       "schedule without duration",
+			windows:     []v1.MaintenanceWindow{{Schedule: "0 2 * * *"}},
+			expectedErr: true,
+		},
+		{
+			tname:       "invalid schedule",
+			windows:     []v1.MaintenanceWindow{{Schedule: "every night", Duration: &metav1.Duration{Duration: time.Hour}}},
+			expectedErr: true,
+		},
+		{
+			tname:       "missing end time",
+			windows:     []v1.MaintenanceWindow{{StartTime: "20:00"}},
+			expectedErr: true,
+		},
+		{
+			tname:       "invalid day",
+			windows:     []v1.MaintenanceWindow{{Days: []string{"Caturday"}, StartTime: "20:00", EndTime: "21:00"}},
+			expectedErr: true,
+		},
+		{
+			tname:       "invalid time zone",
+			timeZone:    "Mars/Olympus_Mons",
+			windows:     []v1.MaintenanceWindow{{StartTime: "20:00", EndTime: "21:00"}},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testtable {
+		t.Run(tc.tname, func(t *testing.T) {
+			pool := &v1.SriovNetworkPoolConfig{Spec: v1.SriovNetworkPoolConfigSpec{
+				MaintenanceWindows: tc.windows,
+				TimeZone:           tc.timeZone,
+			}}
+			open, next, err := pool.MaintenanceWindowOpen(now)
+			if tc.expectedErr {
+				if err == nil {
+					t.Errorf("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if open != tc.expectedOpen {
+				t.Errorf("unexpected open result want: %t got: %t", tc.expectedOpen, open)
+			}
+			if !next.Equal(tc.expectedNext) {
+				t.Errorf("unexpected next window want: %s got: %s", tc.expectedNext, next)
+			}
+		})
+	}
+}
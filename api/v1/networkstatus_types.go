@@ -0,0 +1,26 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NetworkStatus defines the observed state shared by all the network CRs
+type NetworkStatus struct {
+	// NetAttDefRef references the NetworkAttachmentDefinition rendered from the network
+	NetAttDefRef *NetAttDefReference `json:"netAttDefRef,omitempty"`
+	// ConfigHash is the sha256 hash of the rendered CNI configuration
+	ConfigHash string `json:"configHash,omitempty"`
+	// LastError contains the last error hit while rendering or applying the NetworkAttachmentDefinition
+	LastError string `json:"lastError,omitempty"`
+	// ObservedGeneration is the generation of the network the status refers to
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// NetAttDefReference identifies a NetworkAttachmentDefinition
+type NetAttDefReference struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
@@ -75,12 +75,14 @@ type SriovNetworkSpec struct {
 
 // SriovNetworkStatus defines the observed state of SriovNetwork
 type SriovNetworkStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	NetworkStatus `json:",inline"`
 }
 
+//+genclient
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // SriovNetwork is the Schema for the sriovnetworks API
 type SriovNetwork struct {
@@ -29,6 +29,9 @@ type SriovNetworkNodePolicySpec struct {
 	ResourceName string `json:"resourceName"`
 	// NodeSelector selects the nodes to be configured
 	NodeSelector map[string]string `json:"nodeSelector"`
+	// NodeSelectorExpressions is a list of label selector requirements that the nodes must also match,
+	// supported operators are In, NotIn, Exists and DoesNotExist
+	NodeSelectorExpressions []metav1.LabelSelectorRequirement `json:"nodeSelectorExpressions,omitempty"`
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=99
 	// Priority of the policy, higher priority policies can override lower ones.
@@ -65,6 +68,72 @@ type SriovNetworkNodePolicySpec struct {
 	// contains bridge configuration for matching PFs,
 	// valid only for eSwitchMode==switchdev
 	Bridge Bridge `json:"bridge,omitempty"`
+	// ethtool settings applied to the matching PFs
+	PfSettings *PfSettings `json:"pfSettings,omitempty"`
+	// default attributes set on the VFs by the config daemon,
+	// attachments can still override them with the SriovNetwork fields
+	VfDefaults *VfDefaults `json:"vfDefaults,omitempty"`
+	// deterministic admin MAC addresses assigned to the VFs,
+	// when not set the VFs keep the MAC address generated by the kernel
+	VfMacAllocation *VfMacAllocation `json:"vfMacAllocation,omitempty"`
+}
+
+// VfMacAllocation contains the scheme used to assign the admin MAC addresses of the VFs,
+// exactly one of prefix and pool must be set
+type VfMacAllocation struct {
+	// +kubebuilder:validation:Pattern=`^[0-9a-fA-F]{2}(:[0-9a-fA-F]{2}){0,2}$`
+	// Prefix of the MAC addresses, from 1 to 3 bytes, e.g. "02:5a". The remaining bytes are derived
+	// from a hash of the node name, the PF PCI address and the VF index.
+	Prefix string `json:"prefix,omitempty"`
+	// Ranges of MAC addresses the VF MACs are allocated from, e.g. "02:00:00:00:10:00-02:00:00:00:1f:ff".
+	// A MAC address stays assigned to the same VF as long as it is selected by the policy.
+	Pool []string `json:"pool,omitempty"`
+}
+
+// VfDefaults contains the default attributes of the VFs
+type VfDefaults struct {
+	// VF spoof check, (on|off)
+	// +kubebuilder:validation:Enum={"on","off"}
+	SpoofChk string `json:"spoofChk,omitempty"`
+	// VF trust mode (on|off)
+	// +kubebuilder:validation:Enum={"on","off"}
+	Trust string `json:"trust,omitempty"`
+	// VF link state (enable|disable|auto)
+	// +kubebuilder:validation:Enum={"auto","enable","disable"}
+	LinkState string `json:"linkState,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// Minimum tx rate, in Mbps, for the VF. min_tx_rate should be <= max_tx_rate.
+	MinTxRate *int `json:"minTxRate,omitempty"`
+	// +kubebuilder:validation:Minimum=0
+	// Maximum tx rate, in Mbps, for the VF. 0 means no rate limiting.
+	MaxTxRate *int `json:"maxTxRate,omitempty"`
+}
+
+// PfSettings contains the ethtool settings of the PF
+type PfSettings struct {
+	// +kubebuilder:validation:Minimum=1
+	// Number of RX ring descriptors of the PF
+	RxRingSize *int `json:"rxRingSize,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// Number of TX ring descriptors of the PF
+	TxRingSize *int `json:"txRingSize,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	// Number of combined channels of the PF
+	CombinedChannels *int `json:"combinedChannels,omitempty"`
+	// Features of the PF to enable or disable. Allowed keys "rx-vlan-filter", "hw-tc-offload", "lro".
+	Features map[string]bool `json:"features,omitempty"`
+	// Pause frames configuration of the PF
+	Pause *PauseSettings `json:"pause,omitempty"`
+}
+
+// PauseSettings contains the pause frames configuration of the PF
+type PauseSettings struct {
+	// Autonegotiate pause frames
+	Autoneg *bool `json:"autoneg,omitempty"`
+	// Enable RX pause frames
+	Rx *bool `json:"rx,omitempty"`
+	// Enable TX pause frames
+	Tx *bool `json:"tx,omitempty"`
 }
 
 type SriovNetworkNicSelector struct {
@@ -72,12 +141,23 @@ type SriovNetworkNicSelector struct {
 	Vendor string `json:"vendor,omitempty"`
 	// The device hex code of SR-IoV device. Allowed value "0d58", "1572", "158b", "1013", "1015", "1017", "101b".
 	DeviceID string `json:"deviceID,omitempty"`
-	// PCI address of SR-IoV PF.
+	// PCI address of SR-IoV PF. Each field of the address can be a wildcard "*" or an hexadecimal range,
+	// e.g. "0000:3b-3d:00.*".
 	RootDevices []string `json:"rootDevices,omitempty"`
 	// Name of SR-IoV PF.
 	PfNames []string `json:"pfNames,omitempty"`
 	// Infrastructure Networking selection filter. Allowed value "openstack/NetworkID:xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx"
 	NetFilter string `json:"netFilter,omitempty"`
+	// Name of SR-IoV PFs to exclude from the selection.
+	ExcludePfNames []string `json:"excludePfNames,omitempty"`
+	// PCI address of SR-IoV PFs to exclude from the selection, the same patterns as in rootDevices are allowed.
+	ExcludeRootDevices []string `json:"excludeRootDevices,omitempty"`
+	// NUMA nodes the SR-IoV PF is attached to.
+	NumaNodes []int `json:"numaNodes,omitempty"`
+	// Link speed of SR-IoV PF in Mb/s, e.g. 25000.
+	LinkSpeeds []int `json:"linkSpeeds,omitempty"`
+	// Kernel driver of SR-IoV PF, e.g. "ice" or "mlx5_core".
+	PfDrivers []string `json:"pfDrivers,omitempty"`
 }
 
 // contains spec for the bridge
@@ -129,12 +209,61 @@ type OVSInterfaceConfig struct {
 
 // SriovNetworkNodePolicyStatus defines the observed state of SriovNetworkNodePolicy
 type SriovNetworkNodePolicyStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// Number of nodes selected by the policy NodeSelector
+	MatchedNodeCount int `json:"matchedNodeCount,omitempty"`
+	// Number of matched nodes where the configuration was applied successfully
+	AppliedNodeCount int `json:"appliedNodeCount,omitempty"`
+	// Number of matched nodes where the configuration is not applied yet
+	PendingNodeCount int `json:"pendingNodeCount,omitempty"`
+	// Number of matched nodes where the configuration failed to apply
+	FailedNodeCount int `json:"failedNodeCount,omitempty"`
+	// Per node list of PFs configured by the policy
+	Nodes []PolicyNodeStatus `json:"nodes,omitempty"`
+	// Per node result of the dry-run, set only when the policy has the sriovnetwork.openshift.io/dry-run annotation
+	Preview []PolicyNodePreview `json:"preview,omitempty"`
+	// +listType=map
+	// +listMapKey=type
+	// Conditions represent the latest available observations of the policy state
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// PolicyNodeStatus contains the state of the policy on a single node
+type PolicyNodeStatus struct {
+	// Name of the node
+	Name string `json:"name"`
+	// PCI addresses of the PFs on the node that are configured by the policy
+	PciAddresses []string `json:"pciAddresses,omitempty"`
+	// SyncStatus reported by the SriovNetworkNodeState of the node
+	SyncStatus string `json:"syncStatus,omitempty"`
+}
+
+// PolicyNodePreview describes the changes a policy in dry-run mode would cause on a single node
+type PolicyNodePreview struct {
+	// Name of the node
+	Name string `json:"name"`
+	// Interfaces of the SriovNetworkNodeState spec which would be added or updated
+	UpdatedInterfaces Interfaces `json:"updatedInterfaces,omitempty"`
+	// PCI addresses of the interfaces which would be removed from the SriovNetworkNodeState spec
+	RemovedInterfaces []string `json:"removedInterfaces,omitempty"`
+	// Bridges of the SriovNetworkNodeState spec, set only if they would change
+	Bridges *Bridges `json:"bridges,omitempty"`
+	// Device plugin configuration which would be rendered for the node, in JSON
+	DevicePluginConfig string `json:"devicePluginConfig,omitempty"`
+	// DrainRequired is true if the node would be drained
+	DrainRequired bool `json:"drainRequired,omitempty"`
+	// RebootRequired is true if the node would be rebooted
+	RebootRequired bool `json:"rebootRequired,omitempty"`
+	// Error which prevented to compute the preview of the node
+	Error string `json:"error,omitempty"`
 }
 
+//+genclient
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Matched",type=integer,JSONPath=`.status.matchedNodeCount`
+//+kubebuilder:printcolumn:name="Applied",type=integer,JSONPath=`.status.appliedNodeCount`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // SriovNetworkNodePolicy is the Schema for the sriovnetworknodepolicies API
 type SriovNetworkNodePolicy struct {
@@ -33,24 +33,33 @@ type SriovNetworkNodeStateSpec struct {
 type Interfaces []Interface
 
 type Interface struct {
-	PciAddress        string    `json:"pciAddress"`
-	NumVfs            int       `json:"numVfs,omitempty"`
-	Mtu               int       `json:"mtu,omitempty"`
-	Name              string    `json:"name,omitempty"`
-	LinkType          string    `json:"linkType,omitempty"`
-	EswitchMode       string    `json:"eSwitchMode,omitempty"`
-	VfGroups          []VfGroup `json:"vfGroups,omitempty"`
-	ExternallyManaged bool      `json:"externallyManaged,omitempty"`
+	PciAddress        string      `json:"pciAddress"`
+	NumVfs            int         `json:"numVfs,omitempty"`
+	Mtu               int         `json:"mtu,omitempty"`
+	Name              string      `json:"name,omitempty"`
+	LinkType          string      `json:"linkType,omitempty"`
+	EswitchMode       string      `json:"eSwitchMode,omitempty"`
+	VfGroups          []VfGroup   `json:"vfGroups,omitempty"`
+	ExternallyManaged bool        `json:"externallyManaged,omitempty"`
+	PfSettings        *PfSettings `json:"pfSettings,omitempty"`
 }
 
 type VfGroup struct {
-	ResourceName string `json:"resourceName,omitempty"`
-	DeviceType   string `json:"deviceType,omitempty"`
-	VfRange      string `json:"vfRange,omitempty"`
-	PolicyName   string `json:"policyName,omitempty"`
-	Mtu          int    `json:"mtu,omitempty"`
-	IsRdma       bool   `json:"isRdma,omitempty"`
-	VdpaType     string `json:"vdpaType,omitempty"`
+	ResourceName string      `json:"resourceName,omitempty"`
+	DeviceType   string      `json:"deviceType,omitempty"`
+	VfRange      string      `json:"vfRange,omitempty"`
+	PolicyName   string      `json:"policyName,omitempty"`
+	Mtu          int         `json:"mtu,omitempty"`
+	IsRdma       bool        `json:"isRdma,omitempty"`
+	VdpaType     string      `json:"vdpaType,omitempty"`
+	VfDefaults   *VfDefaults `json:"vfDefaults,omitempty"`
+	VfMacs       []VfMac     `json:"vfMacs,omitempty"`
+}
+
+// VfMac contains the admin MAC address assigned to a VF
+type VfMac struct {
+	VfID int    `json:"vfID"`
+	Mac  string `json:"mac"`
 }
 
 type InterfaceExt struct {
@@ -69,6 +78,8 @@ type InterfaceExt struct {
 	EswitchMode       string            `json:"eSwitchMode,omitempty"`
 	ExternallyManaged bool              `json:"externallyManaged,omitempty"`
 	TotalVfs          int               `json:"totalvfs,omitempty"`
+	NumaNode          *int              `json:"numaNode,omitempty"`
+	PfSettings        *PfSettings       `json:"pfSettings,omitempty"`
 	VFs               []VirtualFunction `json:"Vfs,omitempty"`
 }
 type InterfaceExts []InterfaceExt
@@ -87,6 +98,12 @@ type VirtualFunction struct {
 	VdpaType        string `json:"vdpaType,omitempty"`
 	RepresentorName string `json:"representorName,omitempty"`
 	GUID            string `json:"guid,omitempty"`
+	SpoofChk        string `json:"spoofChk,omitempty"`
+	Trust           string `json:"trust,omitempty"`
+	LinkState       string `json:"linkState,omitempty"`
+	MinTxRate       int    `json:"minTxRate,omitempty"`
+	MaxTxRate       int    `json:"maxTxRate,omitempty"`
+	AdminMac        string `json:"adminMac,omitempty"`
 }
 
 // Bridges contains list of bridges
@@ -128,8 +145,13 @@ type SriovNetworkNodeStateStatus struct {
 	System        System        `json:"system,omitempty"`
 	SyncStatus    string        `json:"syncStatus,omitempty"`
 	LastSyncError string        `json:"lastSyncError,omitempty"`
+	// +listType=map
+	// +listMapKey=type
+	// Conditions represent the latest available observations of the node configuration state
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+//+genclient
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="Sync Status",type=string,JSONPath=`.status.syncStatus`
@@ -25,6 +25,78 @@ type SriovNetworkPoolConfigSpec struct {
 	// +kubebuilder:validation:Enum=shared;exclusive
 	// RDMA subsystem. Allowed value "shared", "exclusive".
 	RdmaMode string `json:"rdmaMode,omitempty"`
+
+	// maintenanceWindows defines when the nodes of the pool can be drained or rebooted.
+	// The drain and reboot requests received outside of the windows are held in the Drain_Pending state
+	// until the next window opens, the changes that don't require a drain are applied immediately.
+	// The nodes can be drained at any time if no window is defined.
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// timeZone is the IANA time zone name used to evaluate the maintenance windows, UTC if empty
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// requireDrainApproval holds the drain and reboot requests of the nodes of the pool in the Drain_Approval_Pending
+	// state until the SriovNetworkNodeState of the node is annotated with sriovnetwork.openshift.io/drain-approved=true.
+	// The approval is removed once the node is drained and configured.
+	RequireDrainApproval bool `json:"requireDrainApproval,omitempty"`
+
+	// drainConfig defines how the nodes of the pool are drained
+	DrainConfig *DrainConfig `json:"drainConfig,omitempty"`
+}
+
+const (
+	// PDBPolicyRetry keeps retrying the drain of a node until the pods can be evicted
+	PDBPolicyRetry = "Retry"
+	// PDBPolicyFail stops draining a node when its pods can't be evicted and retries later
+	PDBPolicyFail = "Fail"
+	// PDBPolicyForce deletes the pods which can't be evicted once pdbForceTimeout elapsed since the start of the drain
+	PDBPolicyForce = "Force"
+)
+
+// DrainConfig defines how the nodes of a pool are drained
+type DrainConfig struct {
+	// timeout of each attempt to evict the pods of a node, 90s by default
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// gracePeriodSeconds overrides the termination grace period of the evicted pods,
+	// the grace period of each pod is used if not set
+	// +kubebuilder:validation:Minimum=0
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+	// skipPodSelector selects the pods which are never evicted
+	SkipPodSelector *metav1.LabelSelector `json:"skipPodSelector,omitempty"`
+	// evictPodSelector restricts the eviction to the selected pods
+	EvictPodSelector *metav1.LabelSelector `json:"evictPodSelector,omitempty"`
+	// pdbPolicy defines what happens when the pods of a node can't be evicted, for example because of
+	// a PodDisruptionBudget. Retry keeps retrying, Fail stops draining the node and moves it to the Drain_Failed
+	// state until the drain is retried after a backoff or a change of the node configuration, Force deletes the pods
+	// without respecting the PodDisruptionBudgets once pdbForceTimeout elapsed since the start of the drain. Retry by default.
+	// +kubebuilder:validation:Enum=Retry;Fail;Force
+	PDBPolicy string `json:"pdbPolicy,omitempty"`
+	// pdbForceTimeout is the time after which the pods are deleted with the Force pdbPolicy, 10m by default
+	PDBForceTimeout *metav1.Duration `json:"pdbForceTimeout,omitempty"`
+	// requeueInterval is the interval between two checks of a drain in progress or of a node waiting
+	// for its turn to be drained, 5s by default
+	RequeueInterval *metav1.Duration `json:"requeueInterval,omitempty"`
+}
+
+// MaintenanceWindow defines a period of time during which the nodes of the pool can be drained.
+// The window is defined either by a cron schedule and a duration, or by weekdays and a time range.
+type MaintenanceWindow struct {
+	// schedule is a cron expression with five fields (minute hour day-of-month month day-of-week)
+	// defining when the window opens, duration must be set together with schedule
+	Schedule string `json:"schedule,omitempty"`
+	// duration of the windows opened by schedule
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// days of the week when the window opens, every day if empty
+	// +kubebuilder:validation:items:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+	Days []string `json:"days,omitempty"`
+	// startTime is the time of the day when the window opens, in the HH:MM format
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	StartTime string `json:"startTime,omitempty"`
+	// endTime is the time of the day when the window closes, in the HH:MM format.
+	// An endTime before the startTime closes the window the next day.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	EndTime string `json:"endTime,omitempty"`
 }
 
 type OvsHardwareOffloadConfig struct {
@@ -38,10 +110,42 @@ type OvsHardwareOffloadConfig struct {
 
 // SriovNetworkPoolConfigStatus defines the observed state of SriovNetworkPoolConfig
 type SriovNetworkPoolConfigStatus struct {
+	// Number of nodes selected by the pool
+	NodeCount int `json:"nodeCount,omitempty"`
+	// Names of the nodes selected by the pool
+	Nodes []string `json:"nodes,omitempty"`
+	// MaxUnavailable resolved to the number of nodes of the pool that can be drained in parallel.
+	// -1 means there is no limit.
+	MaxUnavailable *int `json:"maxUnavailable,omitempty"`
+	// Nodes that requested a drain and wait for their turn
+	DrainRequestedNodes []string `json:"drainRequestedNodes,omitempty"`
+	// Nodes currently being drained
+	DrainingNodes []string `json:"drainingNodes,omitempty"`
+	// Nodes drained and being configured
+	DrainCompleteNodes []string `json:"drainCompleteNodes,omitempty"`
+	// Nodes that requested a drain outside of the maintenance windows of the pool
+	DrainPendingNodes []string `json:"drainPendingNodes,omitempty"`
+	// Nodes that requested a drain and wait for an approval
+	DrainApprovalPendingNodes []string `json:"drainApprovalPendingNodes,omitempty"`
+	// Nodes whose drain failed with the Fail pdbPolicy
+	DrainFailedNodes []string `json:"drainFailedNodes,omitempty"`
+	// Next time a maintenance window of the pool opens, empty when a window is open or no window is defined
+	NextMaintenanceWindow *metav1.Time `json:"nextMaintenanceWindow,omitempty"`
+	// Nodes selected by more than one pool, the operator doesn't drain them
+	ConflictingNodes []string `json:"conflictingNodes,omitempty"`
+	// +listType=map
+	// +listMapKey=type
+	// Conditions represent the latest available observations of the pool state
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+//+genclient
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Nodes",type=integer,JSONPath=`.status.nodeCount`
+//+kubebuilder:printcolumn:name="Max Unavailable",type=integer,JSONPath=`.status.maxUnavailable`
+//+kubebuilder:printcolumn:name="Degraded",type=string,JSONPath=`.status.conditions[?(@.type=="Degraded")].status`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // SriovNetworkPoolConfig is the Schema for the sriovnetworkpoolconfigs API
 type SriovNetworkPoolConfig struct {
@@ -73,10 +73,44 @@ type SriovOperatorConfigStatus struct {
 	Injector string `json:"injector,omitempty"`
 	// Show the runtime status of the operator admission controller webhook
 	OperatorWebhook string `json:"operatorWebhook,omitempty"`
+	// Components reports the rollout state of the components deployed by the operator
+	// +listType=map
+	// +listMapKey=name
+	Components []ComponentStatus `json:"components,omitempty"`
+	// FeatureGates contains the effective state of all the known feature gates
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+	// DisabledPlugins is the list of sriov-network-config-daemon plugins disabled on the nodes
+	DisabledPlugins []string `json:"disabledPlugins,omitempty"`
+	// ObservedGeneration is the generation of the config the status refers to
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// ComponentStatus reports the rollout state of a DaemonSet managed by the operator
+type ComponentStatus struct {
+	// Name of the component
+	Name string `json:"name"`
+	// Enabled is false when the component is disabled in the SriovOperatorConfig
+	Enabled bool `json:"enabled"`
+	// DesiredNumberScheduled is the number of nodes that should run the component
+	DesiredNumberScheduled int32 `json:"desiredNumberScheduled"`
+	// NumberReady is the number of nodes running a ready pod of the component
+	NumberReady int32 `json:"numberReady"`
+	// UpdatedNumberScheduled is the number of nodes running the latest version of the component
+	UpdatedNumberScheduled int32 `json:"updatedNumberScheduled"`
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+//+genclient
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+//+kubebuilder:printcolumn:name="Degraded",type=string,JSONPath=`.status.conditions[?(@.type=="Degraded")].status`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // SriovOperatorConfig is the Schema for the sriovoperatorconfigs API
 type SriovOperatorConfig struct {
@@ -89,13 +89,87 @@ func (in ByPriority) DeepCopy() ByPriority {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
+func (in *ComponentStatus) DeepCopy() *ComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainConfig) DeepCopyInto(out *DrainConfig) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.GracePeriodSeconds != nil {
+		in, out := &in.GracePeriodSeconds, &out.GracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SkipPodSelector != nil {
+		in, out := &in.SkipPodSelector, &out.SkipPodSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EvictPodSelector != nil {
+		in, out := &in.EvictPodSelector, &out.EvictPodSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PDBForceTimeout != nil {
+		in, out := &in.PDBForceTimeout, &out.PDBForceTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RequeueInterval != nil {
+		in, out := &in.RequeueInterval, &out.RequeueInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainConfig.
+func (in *DrainConfig) DeepCopy() *DrainConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Interface) DeepCopyInto(out *Interface) {
 	*out = *in
 	if in.VfGroups != nil {
 		in, out := &in.VfGroups, &out.VfGroups
 		*out = make([]VfGroup, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PfSettings != nil {
+		in, out := &in.PfSettings, &out.PfSettings
+		*out = new(PfSettings)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -112,6 +186,16 @@ func (in *Interface) DeepCopy() *Interface {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InterfaceExt) DeepCopyInto(out *InterfaceExt) {
 	*out = *in
+	if in.NumaNode != nil {
+		in, out := &in.NumaNode, &out.NumaNode
+		*out = new(int)
+		**out = **in
+	}
+	if in.PfSettings != nil {
+		in, out := &in.PfSettings, &out.PfSettings
+		*out = new(PfSettings)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.VFs != nil {
 		in, out := &in.VFs, &out.VFs
 		*out = make([]VirtualFunction, len(*in))
@@ -171,6 +255,88 @@ func (in Interfaces) DeepCopy() Interfaces {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MacRange) DeepCopyInto(out *MacRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MacRange.
+func (in *MacRange) DeepCopy() *MacRange {
+	if in == nil {
+		return nil
+	}
+	out := new(MacRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetAttDefReference) DeepCopyInto(out *NetAttDefReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetAttDefReference.
+func (in *NetAttDefReference) DeepCopy() *NetAttDefReference {
+	if in == nil {
+		return nil
+	}
+	out := new(NetAttDefReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkStatus) DeepCopyInto(out *NetworkStatus) {
+	*out = *in
+	if in.NetAttDefRef != nil {
+		in, out := &in.NetAttDefRef, &out.NetAttDefRef
+		*out = new(NetAttDefReference)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkStatus.
+func (in *NetworkStatus) DeepCopy() *NetworkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OVSBridgeConfig) DeepCopyInto(out *OVSBridgeConfig) {
 	*out = *in
@@ -282,7 +448,7 @@ func (in *OVSNetwork) DeepCopyInto(out *OVSNetwork) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSNetwork.
@@ -364,6 +530,7 @@ func (in *OVSNetworkSpec) DeepCopy() *OVSNetworkSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OVSNetworkStatus) DeepCopyInto(out *OVSNetworkStatus) {
 	*out = *in
+	in.NetworkStatus.DeepCopyInto(&out.NetworkStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OVSNetworkStatus.
@@ -423,6 +590,78 @@ func (in *OvsHardwareOffloadConfig) DeepCopy() *OvsHardwareOffloadConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PauseSettings) DeepCopyInto(out *PauseSettings) {
+	*out = *in
+	if in.Autoneg != nil {
+		in, out := &in.Autoneg, &out.Autoneg
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Rx != nil {
+		in, out := &in.Rx, &out.Rx
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Tx != nil {
+		in, out := &in.Tx, &out.Tx
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PauseSettings.
+func (in *PauseSettings) DeepCopy() *PauseSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(PauseSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PfSettings) DeepCopyInto(out *PfSettings) {
+	*out = *in
+	if in.RxRingSize != nil {
+		in, out := &in.RxRingSize, &out.RxRingSize
+		*out = new(int)
+		**out = **in
+	}
+	if in.TxRingSize != nil {
+		in, out := &in.TxRingSize, &out.TxRingSize
+		*out = new(int)
+		**out = **in
+	}
+	if in.CombinedChannels != nil {
+		in, out := &in.CombinedChannels, &out.CombinedChannels
+		*out = new(int)
+		**out = **in
+	}
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Pause != nil {
+		in, out := &in.Pause, &out.Pause
+		*out = new(PauseSettings)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PfSettings.
+func (in *PfSettings) DeepCopy() *PfSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(PfSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in PluginNameSlice) DeepCopyInto(out *PluginNameSlice) {
 	{
@@ -442,13 +681,65 @@ func (in PluginNameSlice) DeepCopy() PluginNameSlice {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyNodePreview) DeepCopyInto(out *PolicyNodePreview) {
+	*out = *in
+	if in.UpdatedInterfaces != nil {
+		in, out := &in.UpdatedInterfaces, &out.UpdatedInterfaces
+		*out = make(Interfaces, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RemovedInterfaces != nil {
+		in, out := &in.RemovedInterfaces, &out.RemovedInterfaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Bridges != nil {
+		in, out := &in.Bridges, &out.Bridges
+		*out = new(Bridges)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyNodePreview.
+func (in *PolicyNodePreview) DeepCopy() *PolicyNodePreview {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyNodePreview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyNodeStatus) DeepCopyInto(out *PolicyNodeStatus) {
+	*out = *in
+	if in.PciAddresses != nil {
+		in, out := &in.PciAddresses, &out.PciAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyNodeStatus.
+func (in *PolicyNodeStatus) DeepCopy() *PolicyNodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyNodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SriovIBNetwork) DeepCopyInto(out *SriovIBNetwork) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovIBNetwork.
@@ -519,6 +810,7 @@ func (in *SriovIBNetworkSpec) DeepCopy() *SriovIBNetworkSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SriovIBNetworkStatus) DeepCopyInto(out *SriovIBNetworkStatus) {
 	*out = *in
+	in.NetworkStatus.DeepCopyInto(&out.NetworkStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovIBNetworkStatus.
@@ -537,7 +829,7 @@ func (in *SriovNetwork) DeepCopyInto(out *SriovNetwork) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetwork.
@@ -603,6 +895,31 @@ func (in *SriovNetworkNicSelector) DeepCopyInto(out *SriovNetworkNicSelector) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ExcludePfNames != nil {
+		in, out := &in.ExcludePfNames, &out.ExcludePfNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludeRootDevices != nil {
+		in, out := &in.ExcludeRootDevices, &out.ExcludeRootDevices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NumaNodes != nil {
+		in, out := &in.NumaNodes, &out.NumaNodes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.LinkSpeeds != nil {
+		in, out := &in.LinkSpeeds, &out.LinkSpeeds
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.PfDrivers != nil {
+		in, out := &in.PfDrivers, &out.PfDrivers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkNicSelector.
@@ -621,7 +938,7 @@ func (in *SriovNetworkNodePolicy) DeepCopyInto(out *SriovNetworkNodePolicy) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkNodePolicy.
@@ -684,8 +1001,30 @@ func (in *SriovNetworkNodePolicySpec) DeepCopyInto(out *SriovNetworkNodePolicySp
 			(*out)[key] = val
 		}
 	}
+	if in.NodeSelectorExpressions != nil {
+		in, out := &in.NodeSelectorExpressions, &out.NodeSelectorExpressions
+		*out = make([]metav1.LabelSelectorRequirement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.NicSelector.DeepCopyInto(&out.NicSelector)
 	in.Bridge.DeepCopyInto(&out.Bridge)
+	if in.PfSettings != nil {
+		in, out := &in.PfSettings, &out.PfSettings
+		*out = new(PfSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VfDefaults != nil {
+		in, out := &in.VfDefaults, &out.VfDefaults
+		*out = new(VfDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VfMacAllocation != nil {
+		in, out := &in.VfMacAllocation, &out.VfMacAllocation
+		*out = new(VfMacAllocation)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkNodePolicySpec.
@@ -701,6 +1040,27 @@ func (in *SriovNetworkNodePolicySpec) DeepCopy() *SriovNetworkNodePolicySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SriovNetworkNodePolicyStatus) DeepCopyInto(out *SriovNetworkNodePolicyStatus) {
 	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]PolicyNodeStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Preview != nil {
+		in, out := &in.Preview, &out.Preview
+		*out = make([]PolicyNodePreview, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkNodePolicyStatus.
@@ -808,6 +1168,13 @@ func (in *SriovNetworkNodeStateStatus) DeepCopyInto(out *SriovNetworkNodeStateSt
 	}
 	in.Bridges.DeepCopyInto(&out.Bridges)
 	out.System = in.System
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkNodeStateStatus.
@@ -826,7 +1193,7 @@ func (in *SriovNetworkPoolConfig) DeepCopyInto(out *SriovNetworkPoolConfig) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkPoolConfig.
@@ -893,6 +1260,18 @@ func (in *SriovNetworkPoolConfigSpec) DeepCopyInto(out *SriovNetworkPoolConfigSp
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DrainConfig != nil {
+		in, out := &in.DrainConfig, &out.DrainConfig
+		*out = new(DrainConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkPoolConfigSpec.
@@ -908,6 +1287,62 @@ func (in *SriovNetworkPoolConfigSpec) DeepCopy() *SriovNetworkPoolConfigSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SriovNetworkPoolConfigStatus) DeepCopyInto(out *SriovNetworkPoolConfigStatus) {
 	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(int)
+		**out = **in
+	}
+	if in.DrainRequestedNodes != nil {
+		in, out := &in.DrainRequestedNodes, &out.DrainRequestedNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DrainingNodes != nil {
+		in, out := &in.DrainingNodes, &out.DrainingNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DrainCompleteNodes != nil {
+		in, out := &in.DrainCompleteNodes, &out.DrainCompleteNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DrainPendingNodes != nil {
+		in, out := &in.DrainPendingNodes, &out.DrainPendingNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DrainApprovalPendingNodes != nil {
+		in, out := &in.DrainApprovalPendingNodes, &out.DrainApprovalPendingNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DrainFailedNodes != nil {
+		in, out := &in.DrainFailedNodes, &out.DrainFailedNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NextMaintenanceWindow != nil {
+		in, out := &in.NextMaintenanceWindow, &out.NextMaintenanceWindow
+		*out = (*in).DeepCopy()
+	}
+	if in.ConflictingNodes != nil {
+		in, out := &in.ConflictingNodes, &out.ConflictingNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkPoolConfigStatus.
@@ -948,6 +1383,7 @@ func (in *SriovNetworkSpec) DeepCopy() *SriovNetworkSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SriovNetworkStatus) DeepCopyInto(out *SriovNetworkStatus) {
 	*out = *in
+	in.NetworkStatus.DeepCopyInto(&out.NetworkStatus)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovNetworkStatus.
@@ -966,7 +1402,7 @@ func (in *SriovOperatorConfig) DeepCopyInto(out *SriovOperatorConfig) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovOperatorConfig.
@@ -1056,6 +1492,32 @@ func (in *SriovOperatorConfigSpec) DeepCopy() *SriovOperatorConfigSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SriovOperatorConfigStatus) DeepCopyInto(out *SriovOperatorConfigStatus) {
 	*out = *in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]ComponentStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DisabledPlugins != nil {
+		in, out := &in.DisabledPlugins, &out.DisabledPlugins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SriovOperatorConfigStatus.
@@ -1113,9 +1575,44 @@ func (in *TrunkConfig) DeepCopy() *TrunkConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VfDefaults) DeepCopyInto(out *VfDefaults) {
+	*out = *in
+	if in.MinTxRate != nil {
+		in, out := &in.MinTxRate, &out.MinTxRate
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxTxRate != nil {
+		in, out := &in.MaxTxRate, &out.MaxTxRate
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VfDefaults.
+func (in *VfDefaults) DeepCopy() *VfDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(VfDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VfGroup) DeepCopyInto(out *VfGroup) {
 	*out = *in
+	if in.VfDefaults != nil {
+		in, out := &in.VfDefaults, &out.VfDefaults
+		*out = new(VfDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VfMacs != nil {
+		in, out := &in.VfMacs, &out.VfMacs
+		*out = make([]VfMac, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VfGroup.
@@ -1128,6 +1625,41 @@ func (in *VfGroup) DeepCopy() *VfGroup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VfMac) DeepCopyInto(out *VfMac) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VfMac.
+func (in *VfMac) DeepCopy() *VfMac {
+	if in == nil {
+		return nil
+	}
+	out := new(VfMac)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VfMacAllocation) DeepCopyInto(out *VfMacAllocation) {
+	*out = *in
+	if in.Pool != nil {
+		in, out := &in.Pool, &out.Pool
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VfMacAllocation.
+func (in *VfMacAllocation) DeepCopy() *VfMacAllocation {
+	if in == nil {
+		return nil
+	}
+	out := new(VfMacAllocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualFunction) DeepCopyInto(out *VirtualFunction) {
 	*out = *in
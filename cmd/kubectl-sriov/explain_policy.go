@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+)
+
+var explainPolicyCmd = &cobra.Command{
+	Use:   "explain-policy <name>",
+	Short: "Shows which nodes and PFs a SriovNetworkNodePolicy selects and why the others are skipped",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runExplainPolicy(cmd.Context(), clients, args[0])
+	},
+}
+
+func runExplainPolicy(ctx context.Context, c *pluginClients, name string) error {
+	policy, err := c.sriovClient.SriovnetworkV1().SriovNetworkNodePolicies(c.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get the SriovNetworkNodePolicy %s: %v", name, err)
+	}
+	nodes, err := c.kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list the nodes: %v", err)
+	}
+	sort.Slice(nodes.Items, func(i, j int) bool { return nodes.Items[i].Name < nodes.Items[j].Name })
+	nodeStates, err := c.sriovClient.SriovnetworkV1().SriovNetworkNodeStates(c.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list the SriovNetworkNodeStates: %v", err)
+	}
+	nodeStatesByName := map[string]*sriovnetworkv1.SriovNetworkNodeState{}
+	for i := range nodeStates.Items {
+		nodeStatesByName[nodeStates.Items[i].Name] = &nodeStates.Items[i]
+	}
+
+	fmt.Fprintf(c.out, "Policy %s: resourceName %s, numVfs %d, priority %d\n", policy.Name, policy.Spec.ResourceName, policy.Spec.NumVfs, policy.Spec.Priority)
+	if policy.IsDryRun() {
+		fmt.Fprintln(c.out, "The policy is in dry-run mode, it is not applied on the nodes")
+	}
+	if policy.Spec.NicSelector.IsEmpty() {
+		fmt.Fprintln(c.out, "The nicSelector is empty, the policy doesn't select any PF")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NODE\tPF\tPCI ADDRESS\tRESULT")
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		if !policy.Selected(node) {
+			fmt.Fprintf(w, "%s\t-\t-\tskipped: the node selector doesn't match the node labels\n", node.Name)
+			continue
+		}
+		ns, ok := nodeStatesByName[node.Name]
+		if !ok {
+			fmt.Fprintf(w, "%s\t-\t-\tskipped: no SriovNetworkNodeState for the node\n", node.Name)
+			continue
+		}
+
+		pfs := 0
+		for j := range ns.Status.Interfaces {
+			iface := &ns.Status.Interfaces[j]
+			if iface.TotalVfs == 0 {
+				continue
+			}
+			pfs++
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", node.Name, iface.Name, iface.PciAddress, explainPF(policy, ns, iface))
+		}
+		if pfs == 0 {
+			fmt.Fprintf(w, "%s\t-\t-\tskipped: no SR-IOV capable PF reported by the node\n", node.Name)
+		}
+	}
+	return w.Flush()
+}
+
+// explainPF returns whether the PF is selected by the policy and if the node state already configures it
+func explainPF(policy *sriovnetworkv1.SriovNetworkNodePolicy, ns *sriovnetworkv1.SriovNetworkNodeState, iface *sriovnetworkv1.InterfaceExt) string {
+	if reason := policy.Spec.NicSelector.NotSelectedReason(iface); reason != "" {
+		return "skipped: " + reason
+	}
+	for _, specIface := range ns.Spec.Interfaces {
+		if specIface.PciAddress != iface.PciAddress {
+			continue
+		}
+		for _, group := range specIface.VfGroups {
+			if group.PolicyName == policy.Name {
+				return fmt.Sprintf("selected, VFs %s configured", group.VfRange)
+			}
+		}
+	}
+	return "selected, not configured in the node state yet"
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/client/clientset/versioned"
+)
+
+const (
+	componentName = "kubectl-sriov"
+	// defaultOperatorNamespace is used when neither the kubeconfig context nor the environment set the namespace
+	defaultOperatorNamespace = "openshift-sriov-network-operator"
+)
+
+// pluginClients holds the clients and the output shared by the subcommands
+type pluginClients struct {
+	namespace   string
+	sriovClient versioned.Interface
+	kubeClient  kubernetes.Interface
+	out         io.Writer
+}
+
+var (
+	namespace string
+	clients   = &pluginClients{}
+
+	rootCmd = &cobra.Command{
+		Use:   componentName,
+		Short: "Shows the status of the SR-IOV network operator",
+		Long: `Shows the status of the SR-IOV network operator and helps troubleshooting its configuration
+
+Installed in the PATH, it can be run as a kubectl plugin: kubectl sriov status`,
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			restConfig, err := ctrl.GetConfig()
+			if err != nil {
+				return fmt.Errorf("failed to load the kubeconfig: %v", err)
+			}
+			sriovClient, err := versioned.NewForConfig(restConfig)
+			if err != nil {
+				return fmt.Errorf("failed to create the SR-IOV clientset: %v", err)
+			}
+			kubeClient, err := kubernetes.NewForConfig(restConfig)
+			if err != nil {
+				return fmt.Errorf("failed to create the kubernetes clientset: %v", err)
+			}
+			if namespace == "" {
+				namespace = defaultNamespace()
+			}
+			clients.namespace = namespace
+			clients.sriovClient = sriovClient
+			clients.kubeClient = kubeClient
+			clients.out = cmd.OutOrStdout()
+			return nil
+		},
+	}
+)
+
+func init() {
+	rootCmd.PersistentFlags().StringVarP(&namespace, "namespace", "n", "",
+		"namespace of the SR-IOV network operator, defaults to the namespace of the kubeconfig context, then to the NAMESPACE environment variable, then to "+defaultOperatorNamespace)
+	rootCmd.AddCommand(statusCmd, explainPolicyCmd, resourcesCmd)
+}
+
+// defaultNamespace returns the namespace of the current kubeconfig context,
+// the NAMESPACE environment variable or the default operator namespace
+func defaultNamespace() string {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if f := flag.Lookup("kubeconfig"); f != nil {
+		loadingRules.ExplicitPath = f.Value.String()
+	}
+	rawConfig, err := loadingRules.Load()
+	if err == nil {
+		if kubeContext, ok := rawConfig.Contexts[rawConfig.CurrentContext]; ok && kubeContext.Namespace != "" {
+			return kubeContext.Namespace
+		}
+	}
+	if ns := os.Getenv("NAMESPACE"); ns != "" {
+		return ns
+	}
+	return defaultOperatorNamespace
+}
+
+func main() {
+	// adds the --kubeconfig flag registered by controller-runtime
+	rootCmd.PersistentFlags().AddGoFlagSet(flag.CommandLine)
+
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/client/clientset/versioned/fake"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+)
+
+const testNamespace = "sriov-network-operator"
+
+func newTestNode(name string, labels map[string]string, resources corev1.ResourceList) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Status:     corev1.NodeStatus{Capacity: resources, Allocatable: resources},
+	}
+}
+
+func newTestNodeState(name string) *sriovnetworkv1.SriovNetworkNodeState {
+	return &sriovnetworkv1.SriovNetworkNodeState{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+			SyncStatus: consts.SyncStatusSucceeded,
+			Interfaces: sriovnetworkv1.InterfaceExts{
+				{Name: "ens1f0", PciAddress: "0000:3b:00.0", Vendor: "8086", DeviceID: "158b", TotalVfs: 64, NumVfs: 4},
+				{Name: "ens2f0", PciAddress: "0000:5e:00.0", Vendor: "15b3", DeviceID: "1017", TotalVfs: 8},
+				{Name: "eno1", PciAddress: "0000:01:00.0", Vendor: "8086"},
+			},
+		},
+	}
+}
+
+// lines returns the output lines without the repeated spaces of the table
+func lines(out *bytes.Buffer) []string {
+	var result []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		result = append(result, strings.Join(strings.Fields(line), " "))
+	}
+	return result
+}
+
+var _ = Describe("kubectl-sriov", func() {
+	var (
+		out  *bytes.Buffer
+		ctx  context.Context
+		node *corev1.Node
+		ns   *sriovnetworkv1.SriovNetworkNodeState
+	)
+
+	newClients := func(sriovObjs ...*sriovnetworkv1.SriovNetworkNodePolicy) *pluginClients {
+		objs := []runtime.Object{ns}
+		for _, p := range sriovObjs {
+			objs = append(objs, p)
+		}
+		return &pluginClients{
+			namespace:   testNamespace,
+			sriovClient: fake.NewSimpleClientset(objs...),
+			kubeClient:  kubefake.NewSimpleClientset(node, newTestNode("node-2", nil, nil)),
+			out:         out,
+		}
+	}
+
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+		ctx = context.Background()
+		node = newTestNode("node-1", map[string]string{"sriov": "true"}, corev1.ResourceList{
+			"openshift.io/intel": resource.MustParse("4"),
+		})
+		ns = newTestNodeState("node-1")
+		ns.Annotations = map[string]string{consts.NodeStateDrainAnnotationCurrent: consts.Draining}
+		ns.Spec.Interfaces = sriovnetworkv1.Interfaces{{
+			Name: "ens1f0", PciAddress: "0000:3b:00.0", NumVfs: 8,
+			VfGroups: []sriovnetworkv1.VfGroup{{PolicyName: "intel", ResourceName: "intel", VfRange: "0-7"}},
+		}}
+	})
+
+	Context("status", func() {
+		It("should list the SR-IOV PFs of every node", func() {
+			Expect(runStatus(ctx, newClients())).To(Succeed())
+			Expect(lines(out)).To(Equal([]string{
+				"NODE SYNC STATUS DRAIN STATE PF PCI ADDRESS NUMVFS (DESIRED/ACTUAL)",
+				"node-1 Succeeded Draining ens1f0 0000:3b:00.0 8/4",
+				"node-1 Succeeded Draining ens2f0 0000:5e:00.0 0/0",
+			}))
+		})
+	})
+
+	Context("explain-policy", func() {
+		It("should explain the selected and skipped nodes and PFs", func() {
+			policy := &sriovnetworkv1.SriovNetworkNodePolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "intel", Namespace: testNamespace},
+				Spec: sriovnetworkv1.SriovNetworkNodePolicySpec{
+					ResourceName: "intel",
+					NumVfs:       8,
+					NodeSelector: map[string]string{"sriov": "true"},
+					NicSelector:  sriovnetworkv1.SriovNetworkNicSelector{Vendor: "8086"},
+				},
+			}
+			Expect(runExplainPolicy(ctx, newClients(policy), "intel")).To(Succeed())
+			Expect(lines(out)).To(Equal([]string{
+				"Policy intel: resourceName intel, numVfs 8, priority 0",
+				"NODE PF PCI ADDRESS RESULT",
+				"node-1 ens1f0 0000:3b:00.0 selected, VFs 0-7 configured",
+				`node-1 ens2f0 0000:5e:00.0 skipped: vendor "15b3" doesn't match "8086"`,
+				"node-2 - - skipped: the node selector doesn't match the node labels",
+			}))
+		})
+
+		It("should fail for an unknown policy", func() {
+			Expect(runExplainPolicy(ctx, newClients(), "unknown")).NotTo(Succeed())
+		})
+	})
+
+	Context("resources", func() {
+		It("should compare the capacity and the allocatable of the resources", func() {
+			policies := []*sriovnetworkv1.SriovNetworkNodePolicy{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "intel", Namespace: testNamespace},
+					Spec:       sriovnetworkv1.SriovNetworkNodePolicySpec{ResourceName: "intel", NodeSelector: map[string]string{"sriov": "true"}},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "mlx", Namespace: testNamespace},
+					Spec:       sriovnetworkv1.SriovNetworkNodePolicySpec{ResourceName: "mlx", NodeSelector: map[string]string{"sriov": "true"}},
+				},
+			}
+			Expect(runResources(ctx, newClients(policies...), "openshift.io")).To(Succeed())
+			Expect(lines(out)).To(Equal([]string{
+				"NODE RESOURCE CAPACITY ALLOCATABLE",
+				"node-1 openshift.io/intel 4 4",
+				"node-1 openshift.io/mlx 0 0",
+			}))
+		})
+	})
+
+	Context("namespace", func() {
+		writeKubeconfig := func(namespace string) {
+			kubeconfig := filepath.Join(GinkgoT().TempDir(), "config")
+			Expect(os.WriteFile(kubeconfig, []byte(`apiVersion: v1
+kind: Config
+current-context: test
+contexts:
+- name: test
+  context:
+    cluster: test
+    namespace: `+namespace+`
+clusters:
+- name: test
+  cluster:
+    server: https://127.0.0.1:6443
+`), 0600)).To(Succeed())
+			GinkgoT().Setenv("KUBECONFIG", kubeconfig)
+		}
+
+		It("should use the namespace of the kubeconfig context", func() {
+			writeKubeconfig("sriov-system")
+			GinkgoT().Setenv("NAMESPACE", "from-env")
+			Expect(defaultNamespace()).To(Equal("sriov-system"))
+		})
+
+		It("should fall back to the NAMESPACE environment variable", func() {
+			writeKubeconfig("")
+			GinkgoT().Setenv("NAMESPACE", "from-env")
+			Expect(defaultNamespace()).To(Equal("from-env"))
+		})
+
+		It("should fall back to the default operator namespace", func() {
+			writeKubeconfig("")
+			GinkgoT().Setenv("NAMESPACE", "")
+			Expect(defaultNamespace()).To(Equal(defaultOperatorNamespace))
+		})
+	})
+})
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+)
+
+var (
+	resourcePrefix string
+
+	resourcesCmd = &cobra.Command{
+		Use:   "resources",
+		Short: "Shows the capacity and the allocatable of the SR-IOV resources of every node",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runResources(cmd.Context(), clients, resourcePrefix)
+		},
+	}
+)
+
+func init() {
+	resourcesCmd.Flags().StringVar(&resourcePrefix, "resource-prefix", "openshift.io", "RESOURCE_PREFIX configured in the operator")
+}
+
+func runResources(ctx context.Context, c *pluginClients, prefix string) error {
+	policies, err := c.sriovClient.SriovnetworkV1().SriovNetworkNodePolicies(c.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list the SriovNetworkNodePolicies: %v", err)
+	}
+	nodes, err := c.kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list the nodes: %v", err)
+	}
+	sort.Slice(nodes.Items, func(i, j int) bool { return nodes.Items[i].Name < nodes.Items[j].Name })
+
+	// the policies exposing each resource
+	resources := map[string][]*sriovnetworkv1.SriovNetworkNodePolicy{}
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		if policy.Spec.ResourceName == "" {
+			continue
+		}
+		resourceName := prefix + "/" + policy.Spec.ResourceName
+		resources[resourceName] = append(resources[resourceName], policy)
+	}
+	resourceNames := make([]string, 0, len(resources))
+	for resourceName := range resources {
+		resourceNames = append(resourceNames, resourceName)
+	}
+	sort.Strings(resourceNames)
+
+	w := tabwriter.NewWriter(c.out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NODE\tRESOURCE\tCAPACITY\tALLOCATABLE")
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		for _, resourceName := range resourceNames {
+			capacity, hasCapacity := node.Status.Capacity[corev1.ResourceName(resourceName)]
+			allocatable := node.Status.Allocatable[corev1.ResourceName(resourceName)]
+			// a node selected by a policy without the resource is listed to spot a missing device plugin
+			if !hasCapacity && !selectedByAny(node, resources[resourceName]) {
+				continue
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", node.Name, resourceName, capacity.String(), allocatable.String())
+		}
+	}
+	return w.Flush()
+}
+
+func selectedByAny(node *corev1.Node, policies []*sriovnetworkv1.SriovNetworkNodePolicy) bool {
+	for _, policy := range policies {
+		if policy.Selected(node) {
+			return true
+		}
+	}
+	return false
+}
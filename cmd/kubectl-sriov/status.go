@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Shows the PFs, the number of VFs, the sync status and the drain state of every node",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runStatus(cmd.Context(), clients)
+	},
+}
+
+func runStatus(ctx context.Context, c *pluginClients) error {
+	nodeStates, err := c.sriovClient.SriovnetworkV1().SriovNetworkNodeStates(c.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list the SriovNetworkNodeStates: %v", err)
+	}
+	sort.Slice(nodeStates.Items, func(i, j int) bool { return nodeStates.Items[i].Name < nodeStates.Items[j].Name })
+
+	w := tabwriter.NewWriter(c.out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NODE\tSYNC STATUS\tDRAIN STATE\tPF\tPCI ADDRESS\tNUMVFS (DESIRED/ACTUAL)")
+	for i := range nodeStates.Items {
+		ns := &nodeStates.Items[i]
+		syncStatus := ns.Status.SyncStatus
+		if syncStatus == "" {
+			syncStatus = "-"
+		}
+		drainState, ok := ns.GetAnnotations()[consts.NodeStateDrainAnnotationCurrent]
+		if !ok {
+			drainState = consts.DrainIdle
+		}
+
+		pfs := statusPFs(ns)
+		if len(pfs) == 0 {
+			fmt.Fprintf(w, "%s\t%s\t%s\t-\t-\t-\n", ns.Name, syncStatus, drainState)
+			continue
+		}
+		for _, pf := range pfs {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d/%d\n", ns.Name, syncStatus, drainState, pf.name, pf.pciAddress, pf.desired, pf.actual)
+		}
+	}
+	return w.Flush()
+}
+
+type pfStatus struct {
+	name       string
+	pciAddress string
+	desired    int
+	actual     int
+}
+
+// statusPFs returns the SR-IOV capable PFs of the node and the PFs configured in the spec
+func statusPFs(ns *sriovnetworkv1.SriovNetworkNodeState) []pfStatus {
+	pfs := map[string]*pfStatus{}
+	for _, iface := range ns.Status.Interfaces {
+		if iface.TotalVfs == 0 {
+			continue
+		}
+		pfs[iface.PciAddress] = &pfStatus{name: iface.Name, pciAddress: iface.PciAddress, actual: iface.NumVfs}
+	}
+	for _, iface := range ns.Spec.Interfaces {
+		pf, ok := pfs[iface.PciAddress]
+		if !ok {
+			pf = &pfStatus{name: iface.Name, pciAddress: iface.PciAddress}
+			pfs[iface.PciAddress] = pf
+		}
+		pf.desired = iface.NumVfs
+	}
+
+	result := make([]pfStatus, 0, len(pfs))
+	for _, pf := range pfs {
+		result = append(result, *pf)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].pciAddress < result[j].pciAddress })
+	return result
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+
+	sriovv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/daemon"
+	snolog "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/log"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+var (
+	applyCmd = &cobra.Command{
+		Use:   "apply -f nodestate.yaml",
+		Short: "Applies a local SriovNetworkNodeState on the host",
+		Long: `Runs the config daemon plugins against the spec of a local SriovNetworkNodeState, without an API server.
+The node is neither drained nor rebooted, the drain and reboot decisions of the plugins are printed instead.`,
+		Args: cobra.NoArgs,
+		RunE: runApplyCmd,
+	}
+
+	applyOpts struct {
+		file            string
+		dryRun          bool
+		disabledPlugins stringList
+	}
+)
+
+// pluginDecision is the result of the OnNodeStateChange call of a plugin
+type pluginDecision struct {
+	Plugin         string `json:"plugin"`
+	DrainRequired  bool   `json:"drainRequired"`
+	RebootRequired bool   `json:"rebootRequired"`
+}
+
+// applyResult reports the drain and reboot decisions of the plugins
+type applyResult struct {
+	Plugins        []pluginDecision `json:"plugins"`
+	DrainRequired  bool             `json:"drainRequired"`
+	RebootRequired bool             `json:"rebootRequired"`
+	Applied        bool             `json:"applied"`
+}
+
+func init() {
+	rootCmd.AddCommand(applyCmd)
+	addOfflineFlags(applyCmd)
+	applyCmd.Flags().StringVarP(&applyOpts.file, "filename", "f", "", "SriovNetworkNodeState manifest to apply")
+	applyCmd.Flags().BoolVar(&applyOpts.dryRun, "dry-run", false, "only print the drain and reboot decisions of the plugins")
+	applyCmd.Flags().VarP(&applyOpts.disabledPlugins, "disable-plugins", "", "comma-separated list of plugins to disable")
+}
+
+func runApplyCmd(cmd *cobra.Command, args []string) error {
+	snolog.InitLog()
+	setupLog := log.Log.WithName("sriov-network-config-daemon-apply")
+
+	if applyOpts.file == "" {
+		return fmt.Errorf("a SriovNetworkNodeState manifest is required")
+	}
+	for _, p := range applyOpts.disabledPlugins {
+		if _, ok := vars.DisableablePlugins[p]; !ok {
+			return fmt.Errorf("%s plugin cannot be disabled", p)
+		}
+	}
+	nodeState, err := readNodeStateFile(applyOpts.file)
+	if err != nil {
+		return err
+	}
+
+	hostHelpers, conf, err := initOffline(setupLog)
+	if err != nil {
+		return err
+	}
+	conf.Spec = nodeState.Spec
+	// the status of the manifest may be outdated, use the devices of the host like the daemon does
+	discovered, err := getNetworkNodeState(setupLog, conf, PhasePost, hostHelpers)
+	if err != nil {
+		return err
+	}
+	nodeState.Status.Interfaces = discovered.Status.Interfaces
+	nodeState.Status.Bridges = discovered.Status.Bridges
+
+	plugins, err := daemon.LoadPlugins(nodeState, hostHelpers, applyOpts.disabledPlugins)
+	if err != nil {
+		return fmt.Errorf("failed to load the plugins: %v", err)
+	}
+	pluginNames := make([]string, 0, len(plugins))
+	for name := range plugins {
+		pluginNames = append(pluginNames, name)
+	}
+	sort.Strings(pluginNames)
+
+	result := applyResult{}
+	for _, name := range pluginNames {
+		reqDrain, reqReboot, err := plugins[name].OnNodeStateChange(nodeState)
+		if err != nil {
+			return fmt.Errorf("failed to run OnNodeStateChange for the %s plugin: %v", name, err)
+		}
+		result.Plugins = append(result.Plugins, pluginDecision{Plugin: name, DrainRequired: reqDrain, RebootRequired: reqReboot})
+		result.DrainRequired = result.DrainRequired || reqDrain
+		result.RebootRequired = result.RebootRequired || reqReboot
+	}
+
+	if !applyOpts.dryRun {
+		if result.DrainRequired {
+			setupLog.Info("WARNING: the plugins require a drain, the node is not drained")
+		}
+		// the generic and the virtual plugins are applied after the reboot, like in the daemon
+		if err := daemon.ApplyPlugins(plugins, result.RebootRequired); err != nil {
+			return fmt.Errorf("failed to apply configuration: %v", err)
+		}
+		result.Applied = true
+		if result.RebootRequired {
+			setupLog.Info("the plugins require a reboot to complete the configuration, the node is not rebooted")
+		}
+	}
+	return printOutput(cmd.OutOrStdout(), result)
+}
+
+func readNodeStateFile(path string) (*sriovv1.SriovNetworkNodeState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the SriovNetworkNodeState manifest %s: %v", path, err)
+	}
+	nodeState := &sriovv1.SriovNetworkNodeState{}
+	if err := yaml.Unmarshal(data, nodeState); err != nil {
+		return nil, fmt.Errorf("failed to parse the SriovNetworkNodeState manifest %s: %v", path, err)
+	}
+	return nodeState, nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sriovv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	snolog "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/log"
+)
+
+var (
+	discoverCmd = &cobra.Command{
+		Use:   "discover",
+		Short: "Prints the SR-IOV devices and the bridges discovered on the host",
+		Long:  "Prints the SR-IOV devices and the managed bridges the config daemon reports in the SriovNetworkNodeState status, without an API server",
+		Args:  cobra.NoArgs,
+		RunE:  runDiscoverCmd,
+	}
+)
+
+// discoverResult is the part of the SriovNetworkNodeState status discovered on the host
+type discoverResult struct {
+	Interfaces sriovv1.InterfaceExts `json:"interfaces"`
+	Bridges    sriovv1.Bridges       `json:"bridges,omitempty"`
+}
+
+func init() {
+	rootCmd.AddCommand(discoverCmd)
+	addOfflineFlags(discoverCmd)
+}
+
+func runDiscoverCmd(cmd *cobra.Command, args []string) error {
+	snolog.InitLog()
+	setupLog := log.Log.WithName("sriov-network-config-daemon-discover")
+
+	hostHelpers, conf, err := initOffline(setupLog)
+	if err != nil {
+		return err
+	}
+	// discover the devices and the bridges like the sriov-config service after the network is configured
+	nodeState, err := getNetworkNodeState(setupLog, conf, PhasePost, hostHelpers)
+	if err != nil {
+		return err
+	}
+	return printOutput(cmd.OutOrStdout(), discoverResult{
+		Interfaces: nodeState.Status.Interfaces,
+		Bridges:    nodeState.Status.Bridges,
+	})
+}
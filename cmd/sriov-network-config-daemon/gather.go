@@ -0,0 +1,314 @@
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	yamlv3 "gopkg.in/yaml.v3"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
+
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper"
+	snolog "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/log"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/systemd"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+const (
+	// gatherErrorsFile lists the items which could not be collected
+	gatherErrorsFile = "errors.txt"
+)
+
+var (
+	gatherCmd = &cobra.Command{
+		Use:   "gather",
+		Short: "Collects the SR-IOV state of the host in a tarball",
+		Long: `Collects the sysfs attributes and the devlink settings of the SR-IOV PFs, the udev rules, the OVS database,
+the PF applied states, the checkpoint and the files of the systemd mode of the config daemon in a tarball.
+The collection continues when an item can't be collected, the failures are listed in the errors.txt file of the tarball.`,
+		Args: cobra.NoArgs,
+		RunE: runGatherCmd,
+	}
+
+	gatherOpts struct {
+		file          string
+		onHost        bool
+		ovsSocketPath string
+	}
+
+	// sysfs attributes collected for every SR-IOV capable PCI device
+	gatherSysfsAttributes = []string{"vendor", "device", "numa_node", "sriov_numvfs", "sriov_totalvfs", "sriov_drivers_autoprobe"}
+	// devlink parameters collected for every SR-IOV capable PCI device
+	gatherDevlinkParams = []string{"flow_steering_mode"}
+)
+
+func init() {
+	rootCmd.AddCommand(gatherCmd)
+	gatherCmd.Flags().StringVarP(&gatherOpts.file, "filename", "f", filepath.Join(os.TempDir(), "sriov-gather.tar.gz"), "path of the tarball, - to write it to the standard output")
+	gatherCmd.Flags().BoolVar(&gatherOpts.onHost, "on-host", false, "the command runs on the host instead of the config daemon container, where the host file system is mounted in /host")
+	gatherCmd.Flags().StringVar(&gatherOpts.ovsSocketPath, "ovs-socket-path", vars.OVSDBSocketPath, "path for OVSDB socket")
+}
+
+func runGatherCmd(cmd *cobra.Command, args []string) error {
+	snolog.InitLog()
+	setupLog := log.Log.WithName("sriov-network-config-daemon-gather")
+
+	vars.InChroot = gatherOpts.onHost
+	vars.OVSDBSocketPath = gatherOpts.ovsSocketPath
+	if gatherOpts.onHost {
+		// the checkpoint directory is configured for the config daemon container
+		vars.Destdir = strings.TrimPrefix(vars.Destdir, consts.Host)
+	}
+
+	hostHelpers, err := newHostHelpersFunc()
+	if err != nil {
+		return fmt.Errorf("failed to create hostHelpers: %v", err)
+	}
+
+	out := cmd.OutOrStdout()
+	if gatherOpts.file != "-" {
+		f, err := os.Create(gatherOpts.file)
+		if err != nil {
+			return fmt.Errorf("failed to create the tarball %s: %v", gatherOpts.file, err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	archive := newGatherArchive(out, setupLog)
+	gatherSysfsAndDevlink(archive, hostHelpers)
+	gatherUdevRules(archive)
+	gatherStore(archive, hostHelpers)
+	gatherOVSDB(archive, hostHelpers)
+	gatherSystemd(archive)
+	if err := archive.close(); err != nil {
+		return fmt.Errorf("failed to write the tarball: %v", err)
+	}
+
+	if gatherOpts.file != "-" {
+		fmt.Fprintln(cmd.OutOrStdout(), gatherOpts.file)
+	}
+	return nil
+}
+
+// gatherSysfsAndDevlink collects the sysfs attributes and the devlink settings of the SR-IOV capable devices
+func gatherSysfsAndDevlink(archive *gatherArchive, hostHelpers helper.HostHelpersInterface) {
+	devicesPath := filepath.Join(vars.FilesystemRoot, consts.SysBusPciDevices)
+	devices, err := os.ReadDir(devicesPath)
+	if err != nil {
+		archive.addError("sysfs", err)
+		return
+	}
+	for _, device := range devices {
+		pciAddr := device.Name()
+		devicePath := filepath.Join(devicesPath, pciAddr)
+		if _, err := os.Stat(filepath.Join(devicePath, "sriov_totalvfs")); err != nil {
+			continue
+		}
+
+		sysfs := map[string]string{}
+		for _, attr := range gatherSysfsAttributes {
+			data, err := os.ReadFile(filepath.Join(devicePath, attr))
+			if err != nil {
+				continue
+			}
+			sysfs[attr] = strings.TrimSpace(string(data))
+		}
+		if driver, err := os.Readlink(filepath.Join(devicePath, "driver")); err == nil {
+			sysfs["driver"] = filepath.Base(driver)
+		}
+		if netDevs, err := os.ReadDir(filepath.Join(devicePath, "net")); err == nil {
+			names := make([]string, 0, len(netDevs))
+			for _, netDev := range netDevs {
+				names = append(names, netDev.Name())
+			}
+			sysfs["net"] = strings.Join(names, ",")
+		}
+		if links, err := filepath.Glob(filepath.Join(devicePath, "virtfn*")); err == nil {
+			vfs := make([]string, 0, len(links))
+			for _, link := range links {
+				if target, err := os.Readlink(link); err == nil {
+					vfs = append(vfs, filepath.Base(link)+"="+filepath.Base(target))
+				}
+			}
+			sort.Strings(vfs)
+			sysfs["virtfn"] = strings.Join(vfs, ",")
+		}
+		archive.addYAML(filepath.Join("sysfs", pciAddr+".yaml"), sysfs)
+
+		devlink := map[string]string{"eswitch_mode": hostHelpers.GetNicSriovMode(pciAddr)}
+		for _, param := range gatherDevlinkParams {
+			if value, err := hostHelpers.GetDevlinkDeviceParam(pciAddr, param); err == nil {
+				devlink[param] = value
+			}
+		}
+		archive.addYAML(filepath.Join("devlink", pciAddr+".yaml"), devlink)
+	}
+}
+
+// gatherUdevRules collects the udev rules and the scripts the operator installs on the host
+func gatherUdevRules(archive *gatherArchive) {
+	for _, dir := range []string{consts.UdevFolder, consts.UdevRulesFolder} {
+		archive.addDirFiles(filepath.Join("udev", strings.TrimPrefix(dir, consts.UdevFolder)), utils.GetHostExtensionPath(dir))
+	}
+}
+
+// gatherStore collects the PF applied states and the checkpoint saved by the store manager
+func gatherStore(archive *gatherArchive, hostHelpers helper.HostHelpersInterface) {
+	pfs, err := os.ReadDir(utils.GetHostExtensionPath(consts.PfAppliedConfig))
+	if err != nil {
+		archive.addError("PF applied states", err)
+	}
+	for _, pf := range pfs {
+		pfStatus, exist, err := hostHelpers.LoadPfsStatus(pf.Name())
+		if err != nil {
+			archive.addError("PF applied state "+pf.Name(), err)
+			continue
+		}
+		if exist {
+			archive.addYAML(filepath.Join("store", "pci", pf.Name()+".yaml"), pfStatus)
+		}
+	}
+
+	checkpoint, err := hostHelpers.GetCheckPointNodeState()
+	if err != nil {
+		archive.addError("checkpoint", err)
+	} else if checkpoint != nil {
+		archive.addYAML(filepath.Join("store", "checkpoint.yaml"), checkpoint)
+	}
+
+	// the switchdev configuration and the OVS store files live next to the PF applied states
+	for _, path := range []string{consts.SriovSwitchDevConfPath, consts.ManagedOVSBridgesPath} {
+		archive.addHostFile(filepath.Join("store", filepath.Base(path)), path)
+	}
+}
+
+// gatherOVSDB collects the content of the OVS database
+func gatherOVSDB(archive *gatherArchive, hostHelpers helper.HostHelpersInterface) {
+	// ovsdb-client expects the unix:<path> format for the socket
+	socket := "unix:" + strings.TrimPrefix(vars.OVSDBSocketPath, "unix://")
+	// the config daemon image doesn't ship the OVS tools, run the host binary
+	stdout, stderr, err := hostHelpers.RunCommand("/bin/sh", "-c",
+		fmt.Sprintf("%s ovsdb-client dump %s Open_vSwitch", utils.GetChrootExtension(), socket))
+	if err != nil {
+		archive.addError("OVS database", fmt.Errorf("%v: %s", err, strings.TrimSpace(stderr)))
+		return
+	}
+	archive.addFile(filepath.Join("ovs", "ovsdb-dump.txt"), []byte(stdout))
+}
+
+// gatherSystemd collects the configuration and the result files of the sriov-config systemd services as they
+// are written on the host, the result is parsed as the config daemon reads it
+func gatherSystemd(archive *gatherArchive) {
+	archive.addHostFile(filepath.Join("systemd", filepath.Base(systemd.SriovSystemdConfigPath)), systemd.SriovSystemdConfigPath)
+
+	result, err := systemd.ReadSriovResult()
+	if err != nil {
+		archive.addError("systemd result", err)
+		return
+	}
+	// an empty result is returned when the file doesn't exist
+	if *result == (systemd.SriovResult{}) {
+		return
+	}
+	data, err := yamlv3.Marshal(result)
+	if err != nil {
+		archive.addError("systemd result", err)
+		return
+	}
+	archive.addFile(filepath.Join("systemd", filepath.Base(systemd.SriovSystemdResultPath)), data)
+}
+
+// gatherArchive writes the collected items in a gzipped tarball and keeps track of the failures
+type gatherArchive struct {
+	gz     *gzip.Writer
+	tw     *tar.Writer
+	log    logr.Logger
+	now    time.Time
+	errors []string
+}
+
+func newGatherArchive(out io.Writer, log logr.Logger) *gatherArchive {
+	gz := gzip.NewWriter(out)
+	return &gatherArchive{gz: gz, tw: tar.NewWriter(gz), log: log, now: time.Now()}
+}
+
+func (a *gatherArchive) addError(item string, err error) {
+	a.log.Info("failed to collect", "item", item, "error", err.Error())
+	a.errors = append(a.errors, fmt.Sprintf("%s: %v", item, err))
+}
+
+func (a *gatherArchive) addFile(name string, data []byte) {
+	hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), ModTime: a.now}
+	if err := a.tw.WriteHeader(hdr); err != nil {
+		a.addError(name, err)
+		return
+	}
+	if _, err := a.tw.Write(data); err != nil {
+		a.addError(name, err)
+	}
+}
+
+func (a *gatherArchive) addYAML(name string, obj interface{}) {
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		a.addError(name, err)
+		return
+	}
+	a.addFile(name, data)
+}
+
+// addHostFile adds a file of the host, missing files are skipped
+func (a *gatherArchive) addHostFile(name, path string) {
+	data, err := os.ReadFile(utils.GetHostExtensionPath(path))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			a.addError(path, err)
+		}
+		return
+	}
+	a.addFile(name, data)
+}
+
+// addDirFiles adds the regular files of a directory, without its subdirectories
+func (a *gatherArchive) addDirFiles(name, dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			a.addError(dir, err)
+		}
+		return
+	}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			a.addError(filepath.Join(dir, entry.Name()), err)
+			continue
+		}
+		a.addFile(filepath.Join(name, entry.Name()), data)
+	}
+}
+
+func (a *gatherArchive) close() error {
+	if len(a.errors) > 0 {
+		a.addFile(gatherErrorsFile, []byte(strings.Join(a.errors, "\n")+"\n"))
+	}
+	if err := a.tw.Close(); err != nil {
+		return err
+	}
+	return a.gz.Close()
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spf13/cobra"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper"
+	helperMock "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper/mock"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util/fakefilesystem"
+	testHelpers "github.com/k8snetworkplumbingwg/sriov-network-operator/test/util/helpers"
+)
+
+// readTarball returns the content of the files of a gzipped tarball
+func readTarball(data []byte) map[string]string {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+	tr := tar.NewReader(gz)
+	files := map[string]string{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		content, err := io.ReadAll(tr)
+		ExpectWithOffset(1, err).NotTo(HaveOccurred())
+		files[hdr.Name] = string(content)
+	}
+}
+
+var _ = Describe("Gather", func() {
+	var (
+		hostHelpers *helperMock.MockHostHelpersInterface
+		testCtrl    *gomock.Controller
+	)
+
+	BeforeEach(func() {
+		restoreOrigFuncs()
+		origGatherOpts := gatherOpts
+		origInChroot := vars.InChroot
+		DeferCleanup(func() {
+			gatherOpts = origGatherOpts
+			vars.InChroot = origInChroot
+		})
+
+		testCtrl = gomock.NewController(GinkgoT())
+		hostHelpers = helperMock.NewMockHostHelpersInterface(testCtrl)
+		newHostHelpersFunc = func() (helper.HostHelpersInterface, error) {
+			return hostHelpers, nil
+		}
+		gatherOpts.file = "-"
+	})
+	AfterEach(func() {
+		testCtrl.Finish()
+	})
+
+	It("collects the SR-IOV state of the host", func() {
+		testHelpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
+			Dirs: []string{
+				"/sys/bus/pci/devices/0000:d8:00.0/net/enp216s0f0np0",
+				"/sys/bus/pci/devices/0000:00:1f.0",
+				"/host/etc/udev/rules.d",
+				"/host/etc/sriov-operator/pci",
+			},
+			Files: map[string][]byte{
+				"/sys/bus/pci/devices/0000:d8:00.0/sriov_totalvfs":       []byte("8\n"),
+				"/sys/bus/pci/devices/0000:d8:00.0/sriov_numvfs":         []byte("4\n"),
+				"/host/etc/udev/rules.d/20-switchdev-0000:d8:00.0.rules": []byte("switchdev rule"),
+				"/host/etc/udev/switchdev-vf-link-name.sh":               []byte("script"),
+				"/host/etc/sriov-operator/pci/0000:d8:00.0":              []byte("{}"),
+				"/host/etc/sriov-operator/managed-ovs-bridges.json":      []byte("{}"),
+				"/host/etc/sriov-operator/sriov-interface-result.yaml":   []byte("syncStatus: Succeeded\n"),
+			},
+		})
+		hostHelpers.EXPECT().GetNicSriovMode("0000:d8:00.0").Return("switchdev")
+		hostHelpers.EXPECT().GetDevlinkDeviceParam("0000:d8:00.0", "flow_steering_mode").Return("smfs", nil)
+		hostHelpers.EXPECT().LoadPfsStatus("0000:d8:00.0").Return(&sriovnetworkv1.Interface{
+			PciAddress: "0000:d8:00.0", NumVfs: 4}, true, nil)
+		hostHelpers.EXPECT().GetCheckPointNodeState().Return(nil, nil)
+		hostHelpers.EXPECT().RunCommand("/bin/sh", "-c",
+			fmt.Sprintf("chroot %s/host ovsdb-client dump unix:/var/run/openvswitch/db.sock Open_vSwitch", vars.FilesystemRoot)).
+			Return("", "failed to connect", fmt.Errorf("exit status 1"))
+
+		out := &bytes.Buffer{}
+		cmd := &cobra.Command{}
+		cmd.SetOut(out)
+		Expect(runGatherCmd(cmd, []string{})).To(Succeed())
+
+		files := readTarball(out.Bytes())
+		Expect(files).To(HaveLen(8))
+		Expect(files).To(HaveKeyWithValue("sysfs/0000:d8:00.0.yaml", "net: enp216s0f0np0\nsriov_numvfs: \"4\"\nsriov_totalvfs: \"8\"\nvirtfn: \"\"\n"))
+		Expect(files).To(HaveKeyWithValue("devlink/0000:d8:00.0.yaml", "eswitch_mode: switchdev\nflow_steering_mode: smfs\n"))
+		Expect(files).To(HaveKeyWithValue("udev/rules.d/20-switchdev-0000:d8:00.0.rules", "switchdev rule"))
+		Expect(files).To(HaveKeyWithValue("udev/switchdev-vf-link-name.sh", "script"))
+		Expect(files).To(HaveKeyWithValue("store/pci/0000:d8:00.0.yaml", "numVfs: 4\npciAddress: 0000:d8:00.0\n"))
+		Expect(files).To(HaveKey("store/managed-ovs-bridges.json"))
+		Expect(files).To(HaveKeyWithValue("systemd/sriov-interface-result.yaml", "syncStatus: Succeeded\nlastSyncError: \"\"\n"))
+		Expect(files).To(HaveKeyWithValue("errors.txt", "OVS database: exit status 1: failed to connect\n"))
+	})
+})
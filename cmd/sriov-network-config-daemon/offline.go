@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/systemd"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+const (
+	outputYAML = "yaml"
+	outputJSON = "json"
+)
+
+// offlineOpts are the flags shared by the commands which inspect or configure the host without an API server
+var offlineOpts struct {
+	onHost                bool
+	unsupportedNics       bool
+	manageSoftwareBridges bool
+	ovsSocketPath         string
+	platform              string
+	clusterType           string
+	output                string
+}
+
+// coreOSReleaseIDs are the IDs of the OpenShift node operating systems in os-release
+var coreOSReleaseIDs = map[string]bool{"rhcos": true, "scos": true}
+
+func addOfflineFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&offlineOpts.onHost, "on-host", false, "the command runs on the host instead of the config daemon container, where the host file system is mounted in /host")
+	cmd.Flags().BoolVar(&offlineOpts.unsupportedNics, "unsupported-nics", false, "allow the NICs which are not in the supported NICs list")
+	cmd.Flags().BoolVar(&offlineOpts.manageSoftwareBridges, "manage-software-bridges", false, "enable management of software bridges")
+	cmd.Flags().StringVar(&offlineOpts.ovsSocketPath, "ovs-socket-path", vars.OVSDBSocketPath, "path for OVSDB socket")
+	cmd.Flags().StringVar(&offlineOpts.platform, "platform", "", "platform of the node, empty for baremetal, supported values are: openstack")
+	cmd.Flags().StringVar(&offlineOpts.clusterType, "cluster-type", "", fmt.Sprintf("type of the cluster of the node, supported values are: %s, %s, "+
+		"detected from the CLUSTER_TYPE environment variable or the operating system of the host when empty", consts.ClusterTypeOpenshift, consts.ClusterTypeKubernetes))
+	cmd.Flags().StringVarP(&offlineOpts.output, "output", "o", outputYAML, fmt.Sprintf("output format, supported values are: %s, %s", outputYAML, outputJSON))
+}
+
+// initOffline configures the global variables from the flags and returns the host helpers and the
+// configuration used to discover the devices like the sriov-config service does
+func initOffline(setupLog logr.Logger) (helper.HostHelpersInterface, *systemd.SriovConfig, error) {
+	if offlineOpts.output != outputYAML && offlineOpts.output != outputJSON {
+		return nil, nil, fmt.Errorf("invalid value for \"--output\" argument, valid values are: %s, %s", outputYAML, outputJSON)
+	}
+	conf := &systemd.SriovConfig{
+		PlatformType:          consts.Baremetal,
+		UnsupportedNics:       offlineOpts.unsupportedNics,
+		ManageSoftwareBridges: offlineOpts.manageSoftwareBridges,
+		OVSDBSocketPath:       offlineOpts.ovsSocketPath,
+	}
+	if offlineOpts.platform != "" {
+		platformType, ok := vars.PlatformsMap[offlineOpts.platform]
+		if !ok {
+			return nil, nil, fmt.Errorf("unsupported platform %s", offlineOpts.platform)
+		}
+		conf.PlatformType = platformType
+	}
+
+	vars.InChroot = offlineOpts.onHost
+	clusterType, err := offlineClusterType()
+	if err != nil {
+		return nil, nil, err
+	}
+	vars.ClusterType = clusterType
+	vars.PlatformType = conf.PlatformType
+	vars.DevMode = conf.UnsupportedNics
+	vars.ManageSoftwareBridges = conf.ManageSoftwareBridges
+	vars.OVSDBSocketPath = conf.OVSDBSocketPath
+
+	if !conf.UnsupportedNics {
+		if err := initSupportedNics(); err != nil {
+			return nil, nil, fmt.Errorf("failed to initialize list of supported NIC ids, use --unsupported-nics to allow all the NICs: %v", err)
+		}
+	}
+
+	hostHelpers, err := newHostHelpersFunc()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create hostHelpers: %v", err)
+	}
+	setupLog.V(2).Info("offline mode initialized", "platform", conf.PlatformType.String(), "cluster-type", vars.ClusterType, "on-host", vars.InChroot)
+	return hostHelpers, conf, nil
+}
+
+// offlineClusterType returns the cluster type of the flag, or of the CLUSTER_TYPE environment variable
+// like the daemon, and otherwise detects OpenShift from the operating system of the host
+func offlineClusterType() (string, error) {
+	switch offlineOpts.clusterType {
+	case consts.ClusterTypeOpenshift, consts.ClusterTypeKubernetes:
+		return offlineOpts.clusterType, nil
+	case "":
+	default:
+		return "", fmt.Errorf("invalid value for \"--cluster-type\" argument, valid values are: %s, %s",
+			consts.ClusterTypeOpenshift, consts.ClusterTypeKubernetes)
+	}
+	if vars.ClusterType != "" {
+		return vars.ClusterType, nil
+	}
+
+	data, err := os.ReadFile(utils.GetHostExtensionPath("/etc/os-release"))
+	if err != nil {
+		return "", fmt.Errorf("failed to detect the cluster type, use --cluster-type to set it: %v", err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		value, found := strings.CutPrefix(strings.TrimSpace(line), "ID=")
+		if found && coreOSReleaseIDs[strings.Trim(value, `"'`)] {
+			return consts.ClusterTypeOpenshift, nil
+		}
+	}
+	return consts.ClusterTypeKubernetes, nil
+}
+
+func printOutput(out io.Writer, obj interface{}) error {
+	var (
+		data []byte
+		err  error
+	)
+	if offlineOpts.output == outputJSON {
+		data, err = json.MarshalIndent(obj, "", "  ")
+		data = append(data, '\n')
+	} else {
+		data, err = yaml.Marshal(obj)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal the output: %v", err)
+	}
+	_, err = out.Write(data)
+	return err
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/daemon"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper"
+	helperMock "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper/mock"
+	plugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins/generic"
+	pluginsMock "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins/mock"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+var testNodeState = `apiVersion: sriovnetwork.openshift.io/v1
+kind: SriovNetworkNodeState
+metadata:
+  name: worker-0
+  namespace: sriov-network-operator
+spec:
+  interfaces:
+  - pciAddress: "0000:d8:00.0"
+    name: enp216s0f0np0
+    numVfs: 4
+    vfGroups:
+    - resourceName: legacy
+      vfRange: 0-3
+      policyName: test-legacy
+`
+
+var _ = Describe("Offline commands", func() {
+	var (
+		hostHelpers   *helperMock.MockHostHelpersInterface
+		genericPlugin *pluginsMock.MockVendorPlugin
+		testCtrl      *gomock.Controller
+		out           *bytes.Buffer
+		cmd           *cobra.Command
+	)
+
+	BeforeEach(func() {
+		restoreOrigFuncs()
+		origGenericPlugin := daemon.GenericPlugin
+		origClusterType := vars.ClusterType
+		origOfflineOpts := offlineOpts
+		origApplyOpts := applyOpts
+		origInChroot := vars.InChroot
+		origPlatformType := vars.PlatformType
+		DeferCleanup(func() {
+			vars.InChroot = origInChroot
+			vars.PlatformType = origPlatformType
+			daemon.GenericPlugin = origGenericPlugin
+			vars.ClusterType = origClusterType
+			offlineOpts = origOfflineOpts
+			applyOpts = origApplyOpts
+			vars.DevMode = false
+		})
+
+		testCtrl = gomock.NewController(GinkgoT())
+		hostHelpers = helperMock.NewMockHostHelpersInterface(testCtrl)
+		genericPlugin = pluginsMock.NewMockVendorPlugin(testCtrl)
+		genericPlugin.EXPECT().Name().Return(generic.PluginName).AnyTimes()
+
+		newHostHelpersFunc = func() (helper.HostHelpersInterface, error) {
+			return hostHelpers, nil
+		}
+		daemon.GenericPlugin = func(_ helper.HostHelpersInterface, _ ...generic.Option) (plugin.VendorPlugin, error) {
+			return genericPlugin, nil
+		}
+		// the k8s plugin is only loaded on kubernetes clusters
+		vars.ClusterType = consts.ClusterTypeOpenshift
+		offlineOpts.output = outputYAML
+		offlineOpts.unsupportedNics = true
+
+		hostHelpers.EXPECT().DiscoverSriovDevices(hostHelpers).Return([]sriovnetworkv1.InterfaceExt{{
+			Name: "enp216s0f0np0", PciAddress: "0000:d8:00.0", Vendor: "1234", TotalVfs: 8,
+		}}, nil)
+
+		out = &bytes.Buffer{}
+		cmd = &cobra.Command{}
+		cmd.SetOut(out)
+	})
+	AfterEach(func() {
+		testCtrl.Finish()
+	})
+
+	It("discover prints the devices of the host", func() {
+		Expect(runDiscoverCmd(cmd, []string{})).To(Succeed())
+
+		result := &discoverResult{}
+		Expect(yaml.Unmarshal(out.Bytes(), result)).To(Succeed())
+		Expect(result.Interfaces).To(HaveLen(1))
+		Expect(result.Interfaces[0].Name).To(Equal("enp216s0f0np0"))
+		Expect(result.Interfaces[0].TotalVfs).To(Equal(8))
+	})
+
+	Context("apply", func() {
+		BeforeEach(func() {
+			applyOpts.file = filepath.Join(GinkgoT().TempDir(), "nodestate.yaml")
+			Expect(os.WriteFile(applyOpts.file, []byte(testNodeState), 0644)).To(Succeed())
+		})
+
+		It("prints the drain and reboot decisions in dry-run mode", func() {
+			applyOpts.dryRun = true
+			genericPlugin.EXPECT().OnNodeStateChange(newNodeStateContainsDeviceMatcher("enp216s0f0np0")).Return(true, false, nil)
+
+			Expect(runApplyCmd(cmd, []string{})).To(Succeed())
+
+			result := &applyResult{}
+			Expect(yaml.Unmarshal(out.Bytes(), result)).To(Succeed())
+			Expect(result).To(Equal(&applyResult{
+				Plugins:       []pluginDecision{{Plugin: generic.PluginName, DrainRequired: true}},
+				DrainRequired: true,
+			}))
+		})
+
+		It("applies the plugins", func() {
+			genericPlugin.EXPECT().OnNodeStateChange(newNodeStateContainsDeviceMatcher("enp216s0f0np0")).Return(true, false, nil)
+			genericPlugin.EXPECT().Apply().Return(nil)
+
+			Expect(runApplyCmd(cmd, []string{})).To(Succeed())
+
+			result := &applyResult{}
+			Expect(yaml.Unmarshal(out.Bytes(), result)).To(Succeed())
+			Expect(result.Applied).To(BeTrue())
+		})
+
+		It("doesn't apply the generic plugin before a reboot", func() {
+			genericPlugin.EXPECT().OnNodeStateChange(gomock.Any()).Return(true, true, nil)
+
+			Expect(runApplyCmd(cmd, []string{})).To(Succeed())
+
+			result := &applyResult{}
+			Expect(yaml.Unmarshal(out.Bytes(), result)).To(Succeed())
+			Expect(result.RebootRequired).To(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("Offline cluster type", func() {
+	BeforeEach(func() {
+		origClusterType := vars.ClusterType
+		origOfflineOpts := offlineOpts
+		origFilesystemRoot := vars.FilesystemRoot
+		origInChroot := vars.InChroot
+		DeferCleanup(func() {
+			vars.ClusterType = origClusterType
+			offlineOpts = origOfflineOpts
+			vars.FilesystemRoot = origFilesystemRoot
+			vars.InChroot = origInChroot
+		})
+		vars.ClusterType = ""
+		vars.InChroot = false
+		vars.FilesystemRoot = GinkgoT().TempDir()
+		Expect(os.MkdirAll(filepath.Join(vars.FilesystemRoot, "host", "etc"), 0755)).To(Succeed())
+	})
+
+	writeOSRelease := func(content string) {
+		ExpectWithOffset(1, os.WriteFile(filepath.Join(vars.FilesystemRoot, "host", "etc", "os-release"), []byte(content), 0644)).To(Succeed())
+	}
+
+	It("detects OpenShift from the operating system of the host", func() {
+		writeOSRelease("NAME=\"Red Hat Enterprise Linux CoreOS\"\nID=\"rhcos\"\nID_LIKE=\"rhel fedora\"\n")
+		Expect(offlineClusterType()).To(Equal(consts.ClusterTypeOpenshift))
+	})
+
+	It("detects Kubernetes on other operating systems", func() {
+		writeOSRelease("NAME=\"Ubuntu\"\nID=ubuntu\nID_LIKE=debian\n")
+		Expect(offlineClusterType()).To(Equal(consts.ClusterTypeKubernetes))
+	})
+
+	It("uses the cluster type of the environment", func() {
+		vars.ClusterType = consts.ClusterTypeOpenshift
+		Expect(offlineClusterType()).To(Equal(consts.ClusterTypeOpenshift))
+	})
+
+	It("uses the cluster type of the flag", func() {
+		writeOSRelease("ID=\"rhcos\"\n")
+		offlineOpts.clusterType = consts.ClusterTypeKubernetes
+		Expect(offlineClusterType()).To(Equal(consts.ClusterTypeKubernetes))
+
+		offlineOpts.clusterType = "other"
+		_, err := offlineClusterType()
+		Expect(err).To(HaveOccurred())
+	})
+})
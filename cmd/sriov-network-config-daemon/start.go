@@ -88,6 +88,7 @@ var (
 		parallelNicConfig     bool
 		manageSoftwareBridges bool
 		ovsSocketPath         string
+		metricsBindAddress    string
 	}
 )
 
@@ -100,6 +101,7 @@ func init() {
 	startCmd.PersistentFlags().BoolVar(&startOpts.parallelNicConfig, "parallel-nic-config", false, "perform NIC configuration in parallel")
 	startCmd.PersistentFlags().BoolVar(&startOpts.manageSoftwareBridges, "manage-software-bridges", false, "enable management of software bridges")
 	startCmd.PersistentFlags().StringVar(&startOpts.ovsSocketPath, "ovs-socket-path", vars.OVSDBSocketPath, "path for OVSDB socket")
+	startCmd.PersistentFlags().StringVar(&startOpts.metricsBindAddress, "metrics-bind-address", "", "address to serve the config daemon metrics on, metrics are not served if empty")
 }
 
 func runStartCmd(cmd *cobra.Command, args []string) error {
@@ -281,6 +283,10 @@ func runStartCmd(cmd *cobra.Command, args []string) error {
 	}
 	go nodeWriter.Run(stopCh, refreshCh, syncCh)
 
+	if startOpts.metricsBindAddress != "" {
+		go daemon.RunMetricsServer(startOpts.metricsBindAddress, stopCh)
+	}
+
 	// Init feature gates once to prevent race conditions.
 	defaultConfig := &sriovnetworkv1.SriovOperatorConfig{}
 	err = kClient.Get(context.Background(), types.NamespacedName{Namespace: vars.Namespace, Name: consts.DefaultConfigName}, defaultConfig)
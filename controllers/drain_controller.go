@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -51,6 +52,35 @@ type DrainReconcile struct {
 	drainer  drain.DrainInterface
 
 	drainCheckMutex sync.Mutex
+	// deniedDrains tracks the nodes whose drain request is held by the limit of draining nodes, it is protected by drainCheckMutex
+	deniedDrains map[string]bool
+
+	// drainStartTimes tracks when the drain of each node started to report the drain duration
+	drainStartTimes     map[string]time.Time
+	drainStartTimesLock sync.Mutex
+
+	// drainFailures tracks the failed drains of the nodes to retry them
+	drainFailures     map[string]drainFailure
+	drainFailuresLock sync.Mutex
+}
+
+// drainFailure is a failed drain of a node
+type drainFailure struct {
+	// generation of the node state whose drain failed
+	generation int64
+	// time of the last failure
+	failedAt time.Time
+	// number of consecutive failures for the generation
+	failures int
+}
+
+// backoff returns the delay before retrying the drain, doubled after each failure
+func (f drainFailure) backoff() time.Duration {
+	backoff := drainRetryBackoff
+	for i := 1; i < f.failures && backoff < maxDrainRetryBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxDrainRetryBackoff)
 }
 
 func NewDrainReconcileController(client client.Client, Scheme *runtime.Scheme, recorder record.EventRecorder, platformHelper platforms.Interface) (*DrainReconcile, error) {
@@ -60,11 +90,11 @@ func NewDrainReconcileController(client client.Client, Scheme *runtime.Scheme, r
 	}
 
 	return &DrainReconcile{
-		client,
-		Scheme,
-		recorder,
-		drainer,
-		sync.Mutex{}}, nil
+		Client:          client,
+		Scheme:          Scheme,
+		recorder:        recorder,
+		drainer:         drainer,
+		drainStartTimes: map[string]time.Time{}}, nil
 }
 
 //+kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch;update;patch
@@ -128,6 +158,8 @@ func (dr *DrainReconcile) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	// Check the node request
 	if nodeDrainAnnotation == constants.DrainIdle {
 		// this cover the case the node is on idle
+		dr.forgetDeniedDrain(node.Name)
+		dr.forgetDrainFailure(node.Name)
 
 		// node request to be on idle and the currect state is idle
 		// we don't do anything
@@ -136,16 +168,36 @@ func (dr *DrainReconcile) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 			return reconcile.Result{}, nil
 		}
 
-		// we have two options here:
+		// we have three options here:
 		// 1. node request idle and the current status is drain complete
 		// this means the daemon finish is work, so we need to clean the drain
 		//
 		// 2. the operator is still draining the node but maybe the sriov policy changed and the daemon
 		//  doesn't need to drain anymore, so we can stop the drain
+		//
+		// 3. the drain failed with the Fail pdbPolicy and the daemon doesn't need it anymore, so we un-cordon the node
 		if nodeStateDrainAnnotationCurrent == constants.DrainComplete ||
-			nodeStateDrainAnnotationCurrent == constants.Draining {
+			nodeStateDrainAnnotationCurrent == constants.Draining ||
+			nodeStateDrainAnnotationCurrent == constants.DrainFailed {
 			return dr.handleNodeIdleNodeStateDrainingOrCompleted(ctx, &reqLogger, node, nodeNetworkState)
 		}
+
+		// the drain request was held until the next maintenance window or an approval, but the daemon doesn't need it anymore,
+		// an approval given in the meantime doesn't apply to the next request
+		if nodeStateDrainAnnotationCurrent == constants.DrainPending ||
+			nodeStateDrainAnnotationCurrent == constants.DrainApprovalPending {
+			err = utils.RemoveAnnotationFromObject(ctx, nodeNetworkState, constants.NodeStateDrainApprovedAnnotation, dr.Client)
+			if err != nil {
+				reqLogger.Error(err, "failed to remove the drain approval annotation")
+				return ctrl.Result{}, err
+			}
+			err = utils.AnnotateObject(ctx, nodeNetworkState, constants.NodeStateDrainAnnotationCurrent, constants.DrainIdle, dr.Client)
+			if err != nil {
+				reqLogger.Error(err, "failed to annotate node with annotation", "annotation", constants.DrainIdle)
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, nil
+		}
 	}
 
 	// this cover the case a node request to drain or reboot
@@ -182,6 +234,96 @@ func (dr *DrainReconcile) ensureAnnotationExists(ctx context.Context, object cli
 	return value, true, nil
 }
 
+// drainDenied counts the drain requests held by the limit of draining nodes, a request is counted
+// once however many times it is checked again, the caller must hold drainCheckMutex
+func (dr *DrainReconcile) drainDenied(nodeName, poolName string) {
+	if dr.deniedDrains[nodeName] {
+		return
+	}
+	if dr.deniedDrains == nil {
+		dr.deniedDrains = map[string]bool{}
+	}
+	dr.deniedDrains[nodeName] = true
+	drainRequestsDenied.WithLabelValues(poolName).Inc()
+}
+
+// forgetDeniedDrain clears the denial of the drain request of the node once the request is started or withdrawn
+func (dr *DrainReconcile) forgetDeniedDrain(nodeName string) {
+	dr.drainCheckMutex.Lock()
+	defer dr.drainCheckMutex.Unlock()
+	delete(dr.deniedDrains, nodeName)
+}
+
+// drainStarted records the start of the drain of the node
+func (dr *DrainReconcile) drainStarted(nodeName string) {
+	dr.drainStartTimesLock.Lock()
+	defer dr.drainStartTimesLock.Unlock()
+	dr.drainStartTimes[nodeName] = time.Now()
+}
+
+// drainStartTime returns the start of the drain of the node, a drain started before the operator
+// restarted is considered started now
+func (dr *DrainReconcile) drainStartTime(nodeName string) time.Time {
+	dr.drainStartTimesLock.Lock()
+	defer dr.drainStartTimesLock.Unlock()
+	if start, ok := dr.drainStartTimes[nodeName]; ok {
+		return start
+	}
+	start := time.Now()
+	dr.drainStartTimes[nodeName] = start
+	return start
+}
+
+// drainCompleted reports the duration of the drain of the node, drains started before
+// the operator restarted are reported from the restart when their start was looked up
+func (dr *DrainReconcile) drainCompleted(nodeName string) {
+	dr.drainStartTimesLock.Lock()
+	defer dr.drainStartTimesLock.Unlock()
+	if start, ok := dr.drainStartTimes[nodeName]; ok {
+		nodeDrainDuration.Observe(time.Since(start).Seconds())
+		delete(dr.drainStartTimes, nodeName)
+	}
+}
+
+// drainFailed records a failed drain of the node and returns the delay before it is retried
+func (dr *DrainReconcile) drainFailed(nodeName string, generation int64) time.Duration {
+	dr.drainFailuresLock.Lock()
+	defer dr.drainFailuresLock.Unlock()
+	if dr.drainFailures == nil {
+		dr.drainFailures = map[string]drainFailure{}
+	}
+	failure := dr.drainFailures[nodeName]
+	if failure.generation != generation {
+		failure = drainFailure{generation: generation}
+	}
+	failure.failedAt = time.Now()
+	failure.failures++
+	dr.drainFailures[nodeName] = failure
+	return failure.backoff()
+}
+
+// drainRetryDelay returns the delay before retrying the failed drain of the node, the drain is retried at once
+// when the node state changed since the failure, a drain failed before the operator restarted is considered failed now
+func (dr *DrainReconcile) drainRetryDelay(nodeName string, generation int64) time.Duration {
+	dr.drainFailuresLock.Lock()
+	failure, ok := dr.drainFailures[nodeName]
+	dr.drainFailuresLock.Unlock()
+	if !ok {
+		return dr.drainFailed(nodeName, generation)
+	}
+	if failure.generation != generation {
+		return 0
+	}
+	return time.Until(failure.failedAt.Add(failure.backoff()))
+}
+
+// forgetDrainFailure clears the failed drain of the node once the request is withdrawn
+func (dr *DrainReconcile) forgetDrainFailure(nodeName string) {
+	dr.drainFailuresLock.Lock()
+	defer dr.drainFailuresLock.Unlock()
+	delete(dr.drainFailures, nodeName)
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (dr *DrainReconcile) SetupWithManager(mgr ctrl.Manager) error {
 	createUpdateEnqueue := handler.Funcs{
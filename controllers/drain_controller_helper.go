@@ -3,12 +3,14 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -18,10 +20,21 @@ import (
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	constants "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/drain"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 )
 
+const (
+	// defaultDrainRequeueInterval is the interval between two checks of a drain when the pool doesn't configure it
+	defaultDrainRequeueInterval = 5 * time.Second
+	// defaultPDBForceTimeout is the time after which the pods are deleted with the Force pdbPolicy
+	defaultPDBForceTimeout = 10 * time.Minute
+	// drainRetryBackoff is the delay before retrying a failed drain, it doubles after each failure up to maxDrainRetryBackoff
+	drainRetryBackoff    = time.Minute
+	maxDrainRetryBackoff = 30 * time.Minute
+)
+
 func (dr *DrainReconcile) handleNodeIdleNodeStateDrainingOrCompleted(ctx context.Context,
 	reqLogger *logr.Logger,
 	node *corev1.Node,
@@ -43,8 +56,19 @@ func (dr *DrainReconcile) handleNodeIdleNodeStateDrainingOrCompleted(ctx context
 			corev1.EventTypeWarning,
 			"DrainController",
 			"node complete drain was not completed")
-		// TODO: make this time configurable
-		return reconcile.Result{RequeueAfter: 5 * time.Second}, nil
+		nodePool, _, err := dr.findNodePoolConfig(ctx, node)
+		if err != nil {
+			reqLogger.Error(err, "failed to find the pool for the requested node")
+			return ctrl.Result{}, err
+		}
+		return reconcile.Result{RequeueAfter: drainRequeueInterval(nodePool)}, nil
+	}
+
+	// the approval is only valid for one drain
+	err = utils.RemoveAnnotationFromObject(ctx, nodeNetworkState, constants.NodeStateDrainApprovedAnnotation, dr.Client)
+	if err != nil {
+		reqLogger.Error(err, "failed to remove the drain approval annotation", "annotation", constants.NodeStateDrainApprovedAnnotation)
+		return ctrl.Result{}, err
 	}
 
 	// move the node state back to idle
@@ -74,9 +98,34 @@ func (dr *DrainReconcile) handleNodeDrainOrReboot(ctx context.Context,
 		return ctrl.Result{}, nil
 	}
 
+	// the drain failed with the Fail pdbPolicy, it is retried after a backoff or once the node state changes
+	if nodeStateDrainAnnotationCurrent == constants.DrainFailed {
+		if delay := dr.drainRetryDelay(node.Name, nodeNetworkState.GetGeneration()); delay > 0 {
+			reqLogger.Info("node drain failed, waiting before retrying it", "retryAfter", delay)
+			return ctrl.Result{RequeueAfter: delay}, nil
+		}
+		// the node is still cordoned and counted as draining, the drain restarts without checking the pool limit
+		reqLogger.Info("retrying the failed drain of the node")
+		err := utils.AnnotateObject(ctx, nodeNetworkState, constants.NodeStateDrainAnnotationCurrent, constants.Draining, dr.Client)
+		if err != nil {
+			reqLogger.Error(err, "failed to annotate node with annotation", "annotation", constants.Draining)
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+
+	// find the relevant node pool
+	nodePool, nodeList, err := dr.findNodePoolConfig(ctx, node)
+	if err != nil {
+		reqLogger.Error(err, "failed to find the pool for the requested node")
+		return ctrl.Result{}, err
+	}
+
 	// we need to start the drain, but first we need to check that we can drain the node
-	if nodeStateDrainAnnotationCurrent == constants.DrainIdle {
-		result, err := dr.tryDrainNode(ctx, node)
+	if nodeStateDrainAnnotationCurrent == constants.DrainIdle ||
+		nodeStateDrainAnnotationCurrent == constants.DrainPending ||
+		nodeStateDrainAnnotationCurrent == constants.DrainApprovalPending {
+		result, err := dr.tryDrainNode(ctx, node, nodePool, nodeList)
 		if err != nil {
 			reqLogger.Error(err, "failed to check if we can drain the node")
 			return ctrl.Result{}, err
@@ -88,10 +137,31 @@ func (dr *DrainReconcile) handleNodeDrainOrReboot(ctx context.Context,
 		}
 	}
 
+	opts, err := dr.drainOptions(nodePool, node.Name)
+	if err != nil {
+		reqLogger.Error(err, "failed to read the drain configuration of the pool", "pool", nodePool.Name)
+		return ctrl.Result{}, err
+	}
+
+	// the config daemon publishes the resources of the reconfigured PFs when the drain is scoped, only the pods
+	// requesting them are evicted when the node is not rebooted
+	if resources, ok := nodeNetworkState.GetAnnotations()[constants.NodeStateDrainResourcesAnnotation]; ok &&
+		nodeDrainAnnotation == constants.DrainRequired {
+		opts.ResourceNames = []string{}
+		if resources != "" {
+			opts.ResourceNames = strings.Split(resources, ",")
+		}
+		reqLogger.Info("drain scoped to the consumers of the reconfigured PFs", "resources", opts.ResourceNames,
+			"pfs", nodeNetworkState.GetAnnotations()[constants.NodeStateDrainPfsAnnotation])
+	}
+
 	// call the drain function that will also call drain to other platform providers like openshift
-	drained, err := dr.drainer.DrainNode(ctx, node, nodeDrainAnnotation == constants.RebootRequired)
+	drained, err := dr.drainer.DrainNode(ctx, node, nodeDrainAnnotation == constants.RebootRequired, opts)
 	if err != nil {
 		reqLogger.Error(err, "error trying to drain the node")
+		if nodePool.Spec.DrainConfig != nil && nodePool.Spec.DrainConfig.PDBPolicy == sriovnetworkv1.PDBPolicyFail {
+			return dr.failDrain(ctx, reqLogger, nodeNetworkState, err)
+		}
 		dr.recorder.Event(nodeNetworkState,
 			corev1.EventTypeWarning,
 			"DrainController",
@@ -106,7 +176,7 @@ func (dr *DrainReconcile) handleNodeDrainOrReboot(ctx context.Context,
 			corev1.EventTypeWarning,
 			"DrainController",
 			"node drain operation was not completed")
-		return reconcile.Result{RequeueAfter: 5 * time.Second}, nil
+		return reconcile.Result{RequeueAfter: drainRequeueInterval(nodePool)}, nil
 	}
 
 	// if we manage to drain we label the node state with drain completed and finish
@@ -116,6 +186,7 @@ func (dr *DrainReconcile) handleNodeDrainOrReboot(ctx context.Context,
 		return ctrl.Result{}, err
 	}
 
+	dr.drainCompleted(node.Name)
 	reqLogger.Info("node drained successfully")
 	dr.recorder.Event(nodeNetworkState,
 		corev1.EventTypeWarning,
@@ -124,7 +195,10 @@ func (dr *DrainReconcile) handleNodeDrainOrReboot(ctx context.Context,
 	return ctrl.Result{}, nil
 }
 
-func (dr *DrainReconcile) tryDrainNode(ctx context.Context, node *corev1.Node) (*reconcile.Result, error) {
+func (dr *DrainReconcile) tryDrainNode(ctx context.Context,
+	node *corev1.Node,
+	nodePool *sriovnetworkv1.SriovNetworkPoolConfig,
+	nodeList []corev1.Node) (*reconcile.Result, error) {
 	// configure logs
 	reqLogger := log.FromContext(ctx)
 	reqLogger.Info("checkForNodeDrain():")
@@ -133,12 +207,41 @@ func (dr *DrainReconcile) tryDrainNode(ctx context.Context, node *corev1.Node) (
 	dr.drainCheckMutex.Lock()
 	defer dr.drainCheckMutex.Unlock()
 
-	// find the relevant node pool
-	nodePool, nodeList, err := dr.findNodePoolConfig(ctx, node)
+	// hold the request until the node is approved
+	if nodePool.Spec.RequireDrainApproval {
+		approved, err := dr.isDrainApproved(ctx, node)
+		if err != nil {
+			return nil, err
+		}
+		if !approved {
+			reqLogger.Info("the pool requires a drain approval, the drain request is pending", "pool", nodePool.Name)
+			return dr.holdDrainRequest(ctx, node, constants.DrainApprovalPending,
+				fmt.Sprintf("node drain waits for an approval, annotate the SriovNetworkNodeState with %s=true to proceed",
+					constants.NodeStateDrainApprovedAnnotation),
+				constants.ResyncPeriod)
+		}
+	}
+
+	// hold the request until the next maintenance window of the pool
+	open, nextWindow, err := nodePool.MaintenanceWindowOpen(time.Now())
 	if err != nil {
-		reqLogger.Error(err, "failed to find the pool for the requested node")
+		reqLogger.Error(err, "failed to evaluate the maintenance windows of the pool", "pool", nodePool.Name)
 		return nil, err
 	}
+	if !open {
+		reqLogger.Info("outside of the maintenance windows of the pool, the drain request is pending",
+			"pool", nodePool.Name, "nextMaintenanceWindow", nextWindow)
+		message := "node drain is pending until the next maintenance window"
+		// check the windows again periodically as the pool can be updated in the meantime
+		requeueAfter := constants.ResyncPeriod
+		if !nextWindow.IsZero() {
+			message = fmt.Sprintf("node drain is pending until the next maintenance window at %s", nextWindow.Format(time.RFC3339))
+			if time.Until(nextWindow) < requeueAfter {
+				requeueAfter = time.Until(nextWindow) + time.Second
+			}
+		}
+		return dr.holdDrainRequest(ctx, node, constants.DrainPending, message, requeueAfter)
+	}
 
 	// check how many nodes we can drain in parallel for the specific pool
 	maxUnv, err := nodePool.MaxUnavailable(len(nodeList))
@@ -165,8 +268,10 @@ func (dr *DrainReconcile) tryDrainNode(ctx context.Context, node *corev1.Node) (
 			currentSnns = snns.DeepCopy()
 		}
 
+		// the nodes whose drain failed stay cordoned
 		if utils.ObjectHasAnnotation(snns, constants.NodeStateDrainAnnotationCurrent, constants.Draining) ||
-			utils.ObjectHasAnnotation(snns, constants.NodeStateDrainAnnotationCurrent, constants.DrainComplete) {
+			utils.ObjectHasAnnotation(snns, constants.NodeStateDrainAnnotationCurrent, constants.DrainComplete) ||
+			utils.ObjectHasAnnotation(snns, constants.NodeStateDrainAnnotationCurrent, constants.DrainFailed) {
 			current++
 		}
 	}
@@ -179,8 +284,8 @@ func (dr *DrainReconcile) tryDrainNode(ctx context.Context, node *corev1.Node) (
 	} else if current >= maxUnv {
 		// the node requested to be drained, but we are at the limit so we re-enqueue the request
 		reqLogger.Info("MaxParallelNodeConfiguration limit reached for draining nodes re-enqueue the request")
-		// TODO: make this time configurable
-		return &reconcile.Result{RequeueAfter: 5 * time.Second}, nil
+		dr.drainDenied(node.Name, nodePool.Name)
+		return &reconcile.Result{RequeueAfter: drainRequeueInterval(nodePool)}, nil
 	}
 
 	if currentSnns == nil {
@@ -192,10 +297,107 @@ func (dr *DrainReconcile) tryDrainNode(ctx context.Context, node *corev1.Node) (
 		reqLogger.Error(err, "failed to annotate node with annotation", "annotation", constants.Draining)
 		return nil, err
 	}
+	delete(dr.deniedDrains, node.Name)
+	dr.drainStarted(node.Name)
 
 	return nil, nil
 }
 
+// failDrain moves the node state to the drain failed state, the request is re-enqueued to retry the drain after a backoff
+func (dr *DrainReconcile) failDrain(ctx context.Context,
+	reqLogger *logr.Logger,
+	nodeNetworkState *sriovnetworkv1.SriovNetworkNodeState,
+	drainErr error) (ctrl.Result, error) {
+	err := utils.AnnotateObject(ctx, nodeNetworkState, constants.NodeStateDrainAnnotationCurrent, constants.DrainFailed, dr.Client)
+	if err != nil {
+		reqLogger.Error(err, "failed to annotate node with annotation", "annotation", constants.DrainFailed)
+		return ctrl.Result{}, err
+	}
+	retryAfter := dr.drainFailed(nodeNetworkState.GetName(), nodeNetworkState.GetGeneration())
+	dr.recorder.Event(nodeNetworkState,
+		corev1.EventTypeWarning,
+		"DrainController",
+		fmt.Sprintf("node drain failed, the pool pdbPolicy is %s, retrying in %s: %v", sriovnetworkv1.PDBPolicyFail, retryAfter, drainErr))
+	return ctrl.Result{RequeueAfter: retryAfter}, nil
+}
+
+// drainOptions returns the options to drain the node with the drain configuration of its pool
+func (dr *DrainReconcile) drainOptions(nodePool *sriovnetworkv1.SriovNetworkPoolConfig, nodeName string) (drain.DrainOptions, error) {
+	opts := drain.DefaultDrainOptions()
+	drainConfig := nodePool.Spec.DrainConfig
+	if drainConfig == nil {
+		return opts, nil
+	}
+	if drainConfig.Timeout != nil {
+		opts.Timeout = drainConfig.Timeout.Duration
+	}
+	if drainConfig.GracePeriodSeconds != nil {
+		opts.GracePeriodSeconds = int(*drainConfig.GracePeriodSeconds)
+	}
+	var err error
+	opts.SkipPodSelector, opts.EvictPodSelector, err = nodePool.DrainPodSelectors()
+	if err != nil {
+		return opts, err
+	}
+	// the pods are deleted without respecting the PodDisruptionBudgets once the drain takes too long
+	if drainConfig.PDBPolicy == sriovnetworkv1.PDBPolicyForce {
+		forceTimeout := defaultPDBForceTimeout
+		if drainConfig.PDBForceTimeout != nil {
+			forceTimeout = drainConfig.PDBForceTimeout.Duration
+		}
+		opts.DisableEviction = time.Since(dr.drainStartTime(nodeName)) >= forceTimeout
+	}
+	return opts, nil
+}
+
+// drainRequeueInterval returns the interval between two checks of a drain of the nodes of the pool
+func drainRequeueInterval(nodePool *sriovnetworkv1.SriovNetworkPoolConfig) time.Duration {
+	if nodePool.Spec.DrainConfig != nil && nodePool.Spec.DrainConfig.RequeueInterval != nil {
+		return nodePool.Spec.DrainConfig.RequeueInterval.Duration
+	}
+	return defaultDrainRequeueInterval
+}
+
+// holdDrainRequest moves the node state to a pending state and re-enqueues the request
+func (dr *DrainReconcile) holdDrainRequest(ctx context.Context,
+	node *corev1.Node,
+	pendingState, message string,
+	requeueAfter time.Duration) (*reconcile.Result, error) {
+	reqLogger := log.FromContext(ctx)
+
+	snns := &sriovnetworkv1.SriovNetworkNodeState{}
+	err := dr.Get(ctx, client.ObjectKey{Name: node.GetName(), Namespace: vars.Namespace}, snns)
+	if err != nil {
+		return nil, err
+	}
+	if !utils.ObjectHasAnnotation(snns, constants.NodeStateDrainAnnotationCurrent, pendingState) {
+		err = utils.AnnotateObject(ctx, snns, constants.NodeStateDrainAnnotationCurrent, pendingState, dr.Client)
+		if err != nil {
+			reqLogger.Error(err, "failed to annotate node with annotation", "annotation", pendingState)
+			return nil, err
+		}
+		// report the requirements published by the config daemon with the request
+		for _, condType := range []string{sriovnetworkv1.NodeStateConditionDrainRequired, sriovnetworkv1.NodeStateConditionRebootRequired} {
+			if cond := meta.FindStatusCondition(snns.Status.Conditions, condType); cond != nil && cond.Status == metav1.ConditionTrue {
+				message = fmt.Sprintf("%s; %s", message, cond.Message)
+			}
+		}
+		dr.recorder.Event(snns, corev1.EventTypeNormal, "DrainController", message)
+	}
+
+	return &reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// isDrainApproved returns true if the node state of the node carries the drain approval annotation
+func (dr *DrainReconcile) isDrainApproved(ctx context.Context, node *corev1.Node) (bool, error) {
+	snns := &sriovnetworkv1.SriovNetworkNodeState{}
+	err := dr.Get(ctx, client.ObjectKey{Name: node.GetName(), Namespace: vars.Namespace}, snns)
+	if err != nil {
+		return false, err
+	}
+	return utils.ObjectHasAnnotation(snns, constants.NodeStateDrainApprovedAnnotation, "true"), nil
+}
+
 func (dr *DrainReconcile) findNodePoolConfig(ctx context.Context, node *corev1.Node) (*sriovnetworkv1.SriovNetworkPoolConfig, []corev1.Node, error) {
 	logger := log.FromContext(ctx)
 	logger.Info("findNodePoolConfig():")
@@ -0,0 +1,498 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	intstrutil "k8s.io/apimachinery/pkg/util/intstr"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	constants "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/drain"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+func newDrainTestClient(t *testing.T, poolSpec sriovnetworkv1.SriovNetworkPoolConfigSpec, nodeStateAnnotations map[string]string) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	utilruntime.Must(sriovnetworkv1.AddToScheme(scheme))
+	utilruntime.Must(corev1.AddToScheme(scheme))
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"pool": "night"},
+			Annotations: map[string]string{constants.NodeDrainAnnotation: constants.DrainRequired}}},
+		&sriovnetworkv1.SriovNetworkNodeState{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Namespace: vars.Namespace,
+			Annotations: nodeStateAnnotations}},
+		&sriovnetworkv1.SriovNetworkPoolConfig{ObjectMeta: metav1.ObjectMeta{Name: "night", Namespace: vars.Namespace}, Spec: poolSpec},
+	).Build()
+}
+
+func newWindowPoolSpec(window sriovnetworkv1.MaintenanceWindow) sriovnetworkv1.SriovNetworkPoolConfigSpec {
+	return sriovnetworkv1.SriovNetworkPoolConfigSpec{
+		NodeSelector:       &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "night"}},
+		MaintenanceWindows: []sriovnetworkv1.MaintenanceWindow{window},
+	}
+}
+
+// fakeDrainer completes all the drains
+type fakeDrainer struct{}
+
+func (fakeDrainer) DrainNode(context.Context, *corev1.Node, bool, drain.DrainOptions) (bool, error) {
+	return true, nil
+}
+
+func (fakeDrainer) CompleteDrainNode(context.Context, *corev1.Node) (bool, error) { return true, nil }
+
+// failingDrainer fails all the drains and records the options of the last drain
+type failingDrainer struct {
+	opts drain.DrainOptions
+}
+
+func (d *failingDrainer) DrainNode(_ context.Context, _ *corev1.Node, _ bool, opts drain.DrainOptions) (bool, error) {
+	d.opts = opts
+	return false, fmt.Errorf("cannot evict pod as it would violate the pod's disruption budget")
+}
+
+func (d *failingDrainer) CompleteDrainNode(context.Context, *corev1.Node) (bool, error) {
+	return true, nil
+}
+
+// tryDrainNode finds the pool of the node and checks if the node can be drained
+func tryDrainNode(t *testing.T, dr *DrainReconcile, node *corev1.Node) (*reconcile.Result, error) {
+	t.Helper()
+	nodePool, nodeList, err := dr.findNodePoolConfig(context.Background(), node)
+	if err != nil {
+		t.Fatalf("failed to find the pool of the node: %v", err)
+	}
+	return dr.tryDrainNode(context.Background(), node, nodePool, nodeList)
+}
+
+// handleNodeDrainOrReboot handles the drain or reboot request of the node with its current node state
+func handleNodeDrainOrReboot(t *testing.T, c client.Client, dr *DrainReconcile, node *corev1.Node, nodeDrainAnnotation string) (ctrl.Result, error) {
+	t.Helper()
+	nodeState := &sriovnetworkv1.SriovNetworkNodeState{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "node-1", Namespace: vars.Namespace}, nodeState); err != nil {
+		t.Fatalf("failed to get the node state: %v", err)
+	}
+	reqLogger := ctrl.Log
+	return dr.handleNodeDrainOrReboot(context.Background(), &reqLogger, node, nodeState, nodeDrainAnnotation,
+		nodeState.GetAnnotations()[constants.NodeStateDrainAnnotationCurrent])
+}
+
+func getDrainState(t *testing.T, c client.Client) string {
+	t.Helper()
+	nodeState := &sriovnetworkv1.SriovNetworkNodeState{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "node-1", Namespace: vars.Namespace}, nodeState); err != nil {
+		t.Fatalf("failed to get the node state: %v", err)
+	}
+	return nodeState.GetAnnotations()[constants.NodeStateDrainAnnotationCurrent]
+}
+
+func TestTryDrainNodeMaintenanceWindow(t *testing.T) {
+	now := time.Now().UTC()
+	closedWindow := sriovnetworkv1.MaintenanceWindow{
+		StartTime: now.Add(2 * time.Hour).Format("15:04"),
+		EndTime:   now.Add(3 * time.Hour).Format("15:04"),
+	}
+	openWindow := sriovnetworkv1.MaintenanceWindow{
+		StartTime: now.Add(-time.Hour).Format("15:04"),
+		EndTime:   now.Add(time.Hour).Format("15:04"),
+	}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"pool": "night"}}}
+
+	t.Run("the request is pending outside of the windows", func(t *testing.T) {
+		c := newDrainTestClient(t, newWindowPoolSpec(closedWindow),
+			map[string]string{constants.NodeStateDrainAnnotationCurrent: constants.DrainIdle})
+		dr := &DrainReconcile{Client: c, recorder: record.NewFakeRecorder(10), drainStartTimes: map[string]time.Time{}}
+
+		result, err := tryDrainNode(t, dr, node)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result == nil || result.RequeueAfter <= 0 || result.RequeueAfter > constants.ResyncPeriod {
+			t.Errorf("unexpected result: %+v", result)
+		}
+		if state := getDrainState(t, c); state != constants.DrainPending {
+			t.Errorf("unexpected drain state want: %s got: %s", constants.DrainPending, state)
+		}
+	})
+
+	t.Run("the pending request starts draining inside a window", func(t *testing.T) {
+		c := newDrainTestClient(t, newWindowPoolSpec(openWindow),
+			map[string]string{constants.NodeStateDrainAnnotationCurrent: constants.DrainPending})
+		dr := &DrainReconcile{Client: c, recorder: record.NewFakeRecorder(10), drainStartTimes: map[string]time.Time{}}
+
+		result, err := tryDrainNode(t, dr, node)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result != nil {
+			t.Errorf("unexpected result: %+v", result)
+		}
+		if state := getDrainState(t, c); state != constants.Draining {
+			t.Errorf("unexpected drain state want: %s got: %s", constants.Draining, state)
+		}
+	})
+
+	t.Run("a withdrawn pending request goes back to idle", func(t *testing.T) {
+		c := newDrainTestClient(t, newWindowPoolSpec(closedWindow),
+			map[string]string{constants.NodeStateDrainAnnotationCurrent: constants.DrainPending})
+		nodeObj := &corev1.Node{}
+		if err := c.Get(context.Background(), types.NamespacedName{Name: "node-1"}, nodeObj); err != nil {
+			t.Fatalf("failed to get the node: %v", err)
+		}
+		nodeObj.Annotations[constants.NodeDrainAnnotation] = constants.DrainIdle
+		if err := c.Update(context.Background(), nodeObj); err != nil {
+			t.Fatalf("failed to update the node: %v", err)
+		}
+		dr := &DrainReconcile{Client: c, recorder: record.NewFakeRecorder(10), drainStartTimes: map[string]time.Time{}}
+
+		_, err := dr.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "node-1"}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if state := getDrainState(t, c); state != constants.DrainIdle {
+			t.Errorf("unexpected drain state want: %s got: %s", constants.DrainIdle, state)
+		}
+	})
+}
+
+func TestTryDrainNodeApproval(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"pool": "night"}}}
+	poolSpec := sriovnetworkv1.SriovNetworkPoolConfigSpec{
+		NodeSelector:         &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "night"}},
+		RequireDrainApproval: true,
+	}
+
+	t.Run("the request waits for an approval", func(t *testing.T) {
+		c := newDrainTestClient(t, poolSpec, map[string]string{constants.NodeStateDrainAnnotationCurrent: constants.DrainIdle})
+		recorder := record.NewFakeRecorder(10)
+		dr := &DrainReconcile{Client: c, recorder: recorder, drainStartTimes: map[string]time.Time{}}
+
+		result, err := tryDrainNode(t, dr, node)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result == nil || result.RequeueAfter != constants.ResyncPeriod {
+			t.Errorf("unexpected result: %+v", result)
+		}
+		if state := getDrainState(t, c); state != constants.DrainApprovalPending {
+			t.Errorf("unexpected drain state want: %s got: %s", constants.DrainApprovalPending, state)
+		}
+		if len(recorder.Events) != 1 {
+			t.Errorf("expected one event, got %d", len(recorder.Events))
+		}
+	})
+
+	t.Run("the approved request starts draining", func(t *testing.T) {
+		c := newDrainTestClient(t, poolSpec, map[string]string{
+			constants.NodeStateDrainAnnotationCurrent:  constants.DrainApprovalPending,
+			constants.NodeStateDrainApprovedAnnotation: "true",
+		})
+		dr := &DrainReconcile{Client: c, recorder: record.NewFakeRecorder(10), drainStartTimes: map[string]time.Time{}}
+
+		result, err := tryDrainNode(t, dr, node)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result != nil {
+			t.Errorf("unexpected result: %+v", result)
+		}
+		if state := getDrainState(t, c); state != constants.Draining {
+			t.Errorf("unexpected drain state want: %s got: %s", constants.Draining, state)
+		}
+	})
+	t.Run("the approval is removed once the node is configured", func(t *testing.T) {
+		c := newDrainTestClient(t, poolSpec, map[string]string{
+			constants.NodeStateDrainAnnotationCurrent:  constants.DrainComplete,
+			constants.NodeStateDrainApprovedAnnotation: "true",
+		})
+		nodeObj := &corev1.Node{}
+		if err := c.Get(context.Background(), types.NamespacedName{Name: "node-1"}, nodeObj); err != nil {
+			t.Fatalf("failed to get the node: %v", err)
+		}
+		nodeObj.Annotations[constants.NodeDrainAnnotation] = constants.DrainIdle
+		if err := c.Update(context.Background(), nodeObj); err != nil {
+			t.Fatalf("failed to update the node: %v", err)
+		}
+		dr := &DrainReconcile{Client: c, recorder: record.NewFakeRecorder(10), drainer: fakeDrainer{},
+			drainStartTimes: map[string]time.Time{}}
+
+		_, err := dr.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "node-1"}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		nodeState := &sriovnetworkv1.SriovNetworkNodeState{}
+		if err := c.Get(context.Background(), types.NamespacedName{Name: "node-1", Namespace: vars.Namespace}, nodeState); err != nil {
+			t.Fatalf("failed to get the node state: %v", err)
+		}
+		if nodeState.GetAnnotations()[constants.NodeStateDrainAnnotationCurrent] != constants.DrainIdle {
+			t.Errorf("unexpected drain state %s", nodeState.GetAnnotations()[constants.NodeStateDrainAnnotationCurrent])
+		}
+		if _, ok := nodeState.GetAnnotations()[constants.NodeStateDrainApprovedAnnotation]; ok {
+			t.Errorf("the drain approval was not removed")
+		}
+	})
+	t.Run("the approval is removed when the pending request is withdrawn", func(t *testing.T) {
+		c := newDrainTestClient(t, poolSpec, map[string]string{
+			constants.NodeStateDrainAnnotationCurrent:  constants.DrainApprovalPending,
+			constants.NodeStateDrainApprovedAnnotation: "true",
+		})
+		nodeObj := &corev1.Node{}
+		if err := c.Get(context.Background(), types.NamespacedName{Name: "node-1"}, nodeObj); err != nil {
+			t.Fatalf("failed to get the node: %v", err)
+		}
+		nodeObj.Annotations[constants.NodeDrainAnnotation] = constants.DrainIdle
+		if err := c.Update(context.Background(), nodeObj); err != nil {
+			t.Fatalf("failed to update the node: %v", err)
+		}
+		dr := &DrainReconcile{Client: c, recorder: record.NewFakeRecorder(10), drainStartTimes: map[string]time.Time{}}
+
+		_, err := dr.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "node-1"}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		nodeState := &sriovnetworkv1.SriovNetworkNodeState{}
+		if err := c.Get(context.Background(), types.NamespacedName{Name: "node-1", Namespace: vars.Namespace}, nodeState); err != nil {
+			t.Fatalf("failed to get the node state: %v", err)
+		}
+		if nodeState.GetAnnotations()[constants.NodeStateDrainAnnotationCurrent] != constants.DrainIdle {
+			t.Errorf("unexpected drain state %s", nodeState.GetAnnotations()[constants.NodeStateDrainAnnotationCurrent])
+		}
+		if _, ok := nodeState.GetAnnotations()[constants.NodeStateDrainApprovedAnnotation]; ok {
+			t.Errorf("the drain approval was not removed")
+		}
+	})
+}
+
+func TestHandleNodeDrainOrRebootDrainConfig(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"pool": "night"}}}
+	newPoolSpec := func(drainConfig *sriovnetworkv1.DrainConfig) sriovnetworkv1.SriovNetworkPoolConfigSpec {
+		return sriovnetworkv1.SriovNetworkPoolConfigSpec{
+			NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "night"}},
+			DrainConfig:  drainConfig,
+		}
+	}
+	drainNode := func(t *testing.T, c client.Client, dr *DrainReconcile) (ctrl.Result, error) {
+		t.Helper()
+		return handleNodeDrainOrReboot(t, c, dr, node, constants.DrainRequired)
+	}
+
+	t.Run("the pool options are passed to the drainer", func(t *testing.T) {
+		gracePeriod := int64(30)
+		c := newDrainTestClient(t, newPoolSpec(&sriovnetworkv1.DrainConfig{
+			Timeout:            &metav1.Duration{Duration: 5 * time.Minute},
+			GracePeriodSeconds: &gracePeriod,
+			SkipPodSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+			PDBPolicy:          sriovnetworkv1.PDBPolicyForce,
+			PDBForceTimeout:    &metav1.Duration{Duration: time.Minute},
+		}), map[string]string{constants.NodeStateDrainAnnotationCurrent: constants.Draining})
+		drainer := &failingDrainer{}
+		dr := &DrainReconcile{Client: c, recorder: record.NewFakeRecorder(10), drainer: drainer,
+			drainStartTimes: map[string]time.Time{"node-1": time.Now().Add(-2 * time.Minute)}}
+
+		if _, err := drainNode(t, c, dr); err == nil {
+			t.Fatalf("expected the drain error to be returned with the Force pdbPolicy")
+		}
+		if drainer.opts.Timeout != 5*time.Minute || drainer.opts.GracePeriodSeconds != 30 {
+			t.Errorf("unexpected drain options: %+v", drainer.opts)
+		}
+		if drainer.opts.SkipPodSelector == nil || drainer.opts.SkipPodSelector.String() != "app=db" || drainer.opts.EvictPodSelector != nil {
+			t.Errorf("unexpected pod selectors: %+v", drainer.opts)
+		}
+		if !drainer.opts.DisableEviction {
+			t.Errorf("expected the eviction to be disabled after the pdbForceTimeout")
+		}
+		if state := getDrainState(t, c); state != constants.Draining {
+			t.Errorf("unexpected drain state want: %s got: %s", constants.Draining, state)
+		}
+	})
+
+	t.Run("the node moves to the drain failed state with the Fail pdbPolicy", func(t *testing.T) {
+		c := newDrainTestClient(t, newPoolSpec(&sriovnetworkv1.DrainConfig{PDBPolicy: sriovnetworkv1.PDBPolicyFail}),
+			map[string]string{constants.NodeStateDrainAnnotationCurrent: constants.Draining})
+		recorder := record.NewFakeRecorder(10)
+		dr := &DrainReconcile{Client: c, recorder: recorder, drainer: &failingDrainer{}, drainStartTimes: map[string]time.Time{}}
+
+		result, err := drainNode(t, c, dr)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.RequeueAfter != drainRetryBackoff {
+			t.Errorf("unexpected result: %+v", result)
+		}
+		if state := getDrainState(t, c); state != constants.DrainFailed {
+			t.Errorf("unexpected drain state want: %s got: %s", constants.DrainFailed, state)
+		}
+		if len(recorder.Events) != 1 {
+			t.Errorf("expected one event, got %d", len(recorder.Events))
+		}
+
+		// the failed drain is not retried before the backoff
+		result, err = drainNode(t, c, dr)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.RequeueAfter <= 0 || result.RequeueAfter > drainRetryBackoff {
+			t.Errorf("unexpected result: %+v", result)
+		}
+		if state := getDrainState(t, c); state != constants.DrainFailed {
+			t.Errorf("unexpected drain state want: %s got: %s", constants.DrainFailed, state)
+		}
+		if len(recorder.Events) != 1 {
+			t.Errorf("expected no new event, got %d", len(recorder.Events))
+		}
+
+		// the failed drain is retried after the backoff, and fails again with a longer backoff
+		failure := dr.drainFailures["node-1"]
+		failure.failedAt = failure.failedAt.Add(-drainRetryBackoff)
+		dr.drainFailures["node-1"] = failure
+		result, err = drainNode(t, c, dr)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.Requeue {
+			t.Errorf("unexpected result: %+v", result)
+		}
+		if state := getDrainState(t, c); state != constants.Draining {
+			t.Errorf("unexpected drain state want: %s got: %s", constants.Draining, state)
+		}
+		result, err = drainNode(t, c, dr)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.RequeueAfter != 2*drainRetryBackoff {
+			t.Errorf("unexpected result: %+v", result)
+		}
+
+		// the drain is retried at once when the node state changes
+		failure = dr.drainFailures["node-1"]
+		failure.generation--
+		dr.drainFailures["node-1"] = failure
+		result, err = drainNode(t, c, dr)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.Requeue {
+			t.Errorf("unexpected result: %+v", result)
+		}
+		if state := getDrainState(t, c); state != constants.Draining {
+			t.Errorf("unexpected drain state want: %s got: %s", constants.Draining, state)
+		}
+	})
+
+	t.Run("the requeue interval of the pool is used when the limit of draining nodes is reached", func(t *testing.T) {
+		maxUnavailable := intstrutil.FromInt(0)
+		poolSpec := newPoolSpec(&sriovnetworkv1.DrainConfig{RequeueInterval: &metav1.Duration{Duration: 30 * time.Second}})
+		poolSpec.MaxUnavailable = &maxUnavailable
+		c := newDrainTestClient(t, poolSpec, map[string]string{constants.NodeStateDrainAnnotationCurrent: constants.DrainIdle})
+		dr := &DrainReconcile{Client: c, recorder: record.NewFakeRecorder(10), drainer: fakeDrainer{}, drainStartTimes: map[string]time.Time{}}
+		denied := testutil.ToFloat64(drainRequestsDenied.WithLabelValues("night"))
+
+		result, err := drainNode(t, c, dr)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.RequeueAfter != 30*time.Second {
+			t.Errorf("unexpected result: %+v", result)
+		}
+
+		// the request is denied once however many times it is requeued
+		if _, err := drainNode(t, c, dr); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if count := testutil.ToFloat64(drainRequestsDenied.WithLabelValues("night")); count != denied+1 {
+			t.Errorf("unexpected denied drain requests want: %v got: %v", denied+1, count)
+		}
+
+		// a new request is counted again once the previous one is withdrawn
+		dr.forgetDeniedDrain("node-1")
+		if _, err := drainNode(t, c, dr); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if count := testutil.ToFloat64(drainRequestsDenied.WithLabelValues("night")); count != denied+2 {
+			t.Errorf("unexpected denied drain requests want: %v got: %v", denied+2, count)
+		}
+	})
+}
+
+func TestHandleNodeDrainOrRebootDrainScope(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"pool": "night"}}}
+	poolSpec := sriovnetworkv1.SriovNetworkPoolConfigSpec{
+		NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "night"}},
+	}
+
+	tests := []struct {
+		name                string
+		nodeDrainAnnotation string
+		annotations         map[string]string
+		expected            []string
+	}{
+		{
+			name:                "the drain is not scoped",
+			nodeDrainAnnotation: constants.DrainRequired,
+			annotations:         map[string]string{},
+			expected:            nil,
+		},
+		{
+			name:                "the drain is scoped to the resources of the reconfigured PFs",
+			nodeDrainAnnotation: constants.DrainRequired,
+			annotations: map[string]string{
+				constants.NodeStateDrainPfsAnnotation:       "0000:d8:00.0",
+				constants.NodeStateDrainResourcesAnnotation: "openshift.io/intel_a,openshift.io/intel_b",
+			},
+			expected: []string{"openshift.io/intel_a", "openshift.io/intel_b"},
+		},
+		{
+			name:                "the reconfigured PFs have no resources",
+			nodeDrainAnnotation: constants.DrainRequired,
+			annotations: map[string]string{
+				constants.NodeStateDrainPfsAnnotation:       "0000:d8:00.0",
+				constants.NodeStateDrainResourcesAnnotation: "",
+			},
+			expected: []string{},
+		},
+		{
+			name:                "the scope is ignored for a reboot",
+			nodeDrainAnnotation: constants.RebootRequired,
+			annotations: map[string]string{
+				constants.NodeStateDrainPfsAnnotation:       "0000:d8:00.0",
+				constants.NodeStateDrainResourcesAnnotation: "openshift.io/intel_a",
+			},
+			expected: nil,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.annotations[constants.NodeStateDrainAnnotationCurrent] = constants.Draining
+			c := newDrainTestClient(t, poolSpec, tc.annotations)
+			drainer := &failingDrainer{}
+			dr := &DrainReconcile{Client: c, recorder: record.NewFakeRecorder(10), drainer: drainer, drainStartTimes: map[string]time.Time{}}
+
+			if _, err := handleNodeDrainOrReboot(t, c, dr, node, tc.nodeDrainAnnotation); err == nil {
+				t.Fatalf("expected the drain error to be returned")
+			}
+			if (tc.expected == nil) != (drainer.opts.ResourceNames == nil) || len(tc.expected) != len(drainer.opts.ResourceNames) {
+				t.Fatalf("unexpected resource names want: %v got: %v", tc.expected, drainer.opts.ResourceNames)
+			}
+			for i := range tc.expected {
+				if tc.expected[i] != drainer.opts.ResourceNames[i] {
+					t.Errorf("unexpected resource names want: %v got: %v", tc.expected, drainer.opts.ResourceNames)
+				}
+			}
+		})
+	}
+}
@@ -18,11 +18,15 @@ package controllers
 
 import (
 	"context"
+	"crypto/sha256"
+	"fmt"
 	"reflect"
 
 	netattdefv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -46,6 +50,8 @@ type networkCRInstance interface {
 	RenderNetAttDef() (*uns.Unstructured, error)
 	// return name of the target namespace for the network
 	NetworkNamespace() string
+	// return the status of the network
+	GetNetworkStatus() *sriovnetworkv1.NetworkStatus
 }
 
 // interface which controller should implement to be compatible with genericNetworkReconciler
@@ -124,20 +130,34 @@ func (r *genericNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		}
 		return reconcile.Result{}, err
 	}
+	netAttDef, reason, err := r.applyNetAttDef(ctx, instance)
+	if statusErr := r.updateNetworkStatus(ctx, instance, netAttDef, reason, err); statusErr != nil {
+		reqLogger.Error(statusErr, "Couldn't update network status")
+		if err == nil {
+			return reconcile.Result{}, statusErr
+		}
+	}
+	return reconcile.Result{}, err
+}
+
+// applyNetAttDef renders the NetworkAttachmentDefinition for the network and creates or updates it in the target namespace.
+// The returned reason explains the outcome and is used for the Ready condition of the network.
+func (r *genericNetworkReconciler) applyNetAttDef(ctx context.Context, instance networkCRInstance) (*netattdefv1.NetworkAttachmentDefinition, string, error) {
+	reqLogger := log.FromContext(ctx).WithValues(r.controller.Name(), types.NamespacedName{Namespace: instance.GetNamespace(), Name: instance.GetName()})
 	raw, err := instance.RenderNetAttDef()
 	if err != nil {
-		return reconcile.Result{}, err
+		return nil, sriovnetworkv1.NetworkReasonRenderFailed, err
 	}
 	netAttDef := &netattdefv1.NetworkAttachmentDefinition{}
 	err = r.Scheme.Convert(raw, netAttDef, nil)
 	if err != nil {
-		return reconcile.Result{}, err
+		return nil, sriovnetworkv1.NetworkReasonRenderFailed, err
 	}
 	// format CNI config json in CR for easier readability
 	netAttDef.Spec.Config, err = formatJSON(netAttDef.Spec.Config)
 	if err != nil {
 		reqLogger.Error(err, "Couldn't process rendered NetworkAttachmentDefinition config", "Namespace", netAttDef.Namespace, "Name", netAttDef.Name)
-		return reconcile.Result{}, err
+		return nil, sriovnetworkv1.NetworkReasonRenderFailed, err
 	}
 	if lnns, ok := instance.GetAnnotations()[sriovnetworkv1.LASTNETWORKNAMESPACE]; ok && netAttDef.GetNamespace() != lnns {
 		err = r.Delete(ctx, &netattdefv1.NetworkAttachmentDefinition{
@@ -148,7 +168,7 @@ func (r *genericNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		})
 		if err != nil {
 			reqLogger.Error(err, "Couldn't delete NetworkAttachmentDefinition CR", "Namespace", instance.GetName(), "Name", lnns)
-			return reconcile.Result{}, err
+			return netAttDef, sriovnetworkv1.NetworkReasonApplyFailed, err
 		}
 	}
 	// Check if this NetworkAttachmentDefinition already exists
@@ -160,23 +180,23 @@ func (r *genericNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			err = r.Get(ctx, types.NamespacedName{Name: netAttDef.Namespace}, targetNamespace)
 			if errors.IsNotFound(err) {
 				reqLogger.Info("Target namespace doesn't exist, NetworkAttachmentDefinition will be created when namespace is available", "Namespace", netAttDef.Namespace, "Name", netAttDef.Name)
-				return reconcile.Result{}, nil
+				return netAttDef, sriovnetworkv1.NetworkReasonNamespaceNotFound, nil
 			}
 
 			reqLogger.Info("NetworkAttachmentDefinition CR not exist, creating")
 			err = r.Create(ctx, netAttDef)
 			if err != nil {
 				reqLogger.Error(err, "Couldn't create NetworkAttachmentDefinition CR", "Namespace", netAttDef.Namespace, "Name", netAttDef.Name)
-				return reconcile.Result{}, err
+				return netAttDef, sriovnetworkv1.NetworkReasonApplyFailed, err
 			}
 
 			err = utils.AnnotateObject(ctx, instance, sriovnetworkv1.LASTNETWORKNAMESPACE, netAttDef.Namespace, r.Client)
 			if err != nil {
-				return reconcile.Result{}, err
+				return netAttDef, sriovnetworkv1.NetworkReasonApplyFailed, err
 			}
 		} else {
 			reqLogger.Error(err, "Couldn't get NetworkAttachmentDefinition CR", "Namespace", netAttDef.Namespace, "Name", netAttDef.Name)
-			return reconcile.Result{}, err
+			return netAttDef, sriovnetworkv1.NetworkReasonApplyFailed, err
 		}
 	} else {
 		reqLogger.Info("NetworkAttachmentDefinition CR already exist")
@@ -186,12 +206,50 @@ func (r *genericNetworkReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			err = r.Update(ctx, netAttDef)
 			if err != nil {
 				reqLogger.Error(err, "Couldn't update NetworkAttachmentDefinition CR", "Namespace", netAttDef.Namespace, "Name", netAttDef.Name)
-				return reconcile.Result{}, err
+				return netAttDef, sriovnetworkv1.NetworkReasonApplyFailed, err
 			}
 		}
 	}
 
-	return ctrl.Result{}, nil
+	return netAttDef, sriovnetworkv1.NetworkReasonNetAttDefApplied, nil
+}
+
+// updateNetworkStatus reports the outcome of the NetworkAttachmentDefinition rendering in the network status
+func (r *genericNetworkReconciler) updateNetworkStatus(ctx context.Context, instance networkCRInstance,
+	netAttDef *netattdefv1.NetworkAttachmentDefinition, reason string, applyErr error) error {
+	newInstance := instance.DeepCopyObject().(networkCRInstance)
+	status := newInstance.GetNetworkStatus()
+	status.ObservedGeneration = instance.GetGeneration()
+	status.LastError = ""
+	readyCond := metav1.Condition{Type: sriovnetworkv1.ConditionReady, ObservedGeneration: instance.GetGeneration(),
+		Status: metav1.ConditionFalse, Reason: reason}
+
+	if netAttDef != nil {
+		status.NetAttDefRef = &sriovnetworkv1.NetAttDefReference{Name: netAttDef.Name, Namespace: netAttDef.Namespace}
+		status.ConfigHash = fmt.Sprintf("%x", sha256.Sum256([]byte(netAttDef.Spec.Config)))
+	} else {
+		// the rendering failed, the NetworkAttachmentDefinition doesn't reflect the network anymore
+		status.NetAttDefRef = nil
+		status.ConfigHash = ""
+	}
+
+	switch {
+	case applyErr != nil:
+		status.LastError = applyErr.Error()
+		readyCond.Message = applyErr.Error()
+	case reason == sriovnetworkv1.NetworkReasonNamespaceNotFound:
+		readyCond.Message = fmt.Sprintf("target namespace %s doesn't exist", netAttDef.Namespace)
+	default:
+		readyCond.Status = metav1.ConditionTrue
+		readyCond.Message = fmt.Sprintf("NetworkAttachmentDefinition %s/%s is up to date", netAttDef.Namespace, netAttDef.Name)
+	}
+	meta.SetStatusCondition(&status.Conditions, readyCond)
+
+	if equality.Semantic.DeepEqual(instance.GetNetworkStatus(), status) {
+		return nil
+	}
+	// use a merge patch as the object may have been annotated during the reconcile
+	return r.Status().Patch(ctx, newInstance, client.MergeFrom(instance))
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	constants "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+const (
+	metricsNamespace = "sriov"
+	metricsSubsystem = "operator"
+)
+
+var (
+	drainRequestsDenied = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "drain_requests_denied_total",
+		Help:      "Number of drain requests postponed because the pool reached its MaxUnavailable",
+	}, []string{"pool"})
+	nodeDrainDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "node_drain_duration_seconds",
+		Help:      "Time between the start of a node drain and its completion",
+		Buckets:   []float64{10, 30, 60, 120, 300, 600, 1200, 1800, 3600, 7200},
+	})
+
+	policiesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, metricsSubsystem, "policies"),
+		"Number of SriovNetworkNodePolicies",
+		nil, nil)
+	nodeStatesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, metricsSubsystem, "node_states"),
+		"Number of SriovNetworkNodeStates by sync status",
+		[]string{"sync_status"}, nil)
+	nodeDrainStatesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, metricsSubsystem, "node_drain_states"),
+		"Number of nodes by drain state",
+		[]string{"state"}, nil)
+
+	registerMetricsOnce sync.Once
+)
+
+// RegisterMetrics adds the operator metrics to the controller-runtime registry,
+// the gauges are computed from the objects of the reader on every scrape
+func RegisterMetrics(reader client.Reader) {
+	registerMetricsOnce.Do(func() {
+		metrics.Registry.MustRegister(
+			drainRequestsDenied,
+			nodeDrainDuration,
+			&operatorCollector{reader: reader},
+		)
+	})
+}
+
+// operatorCollector reports the number of policies, node states and draining nodes
+type operatorCollector struct {
+	reader client.Reader
+}
+
+func (c *operatorCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- policiesDesc
+	ch <- nodeStatesDesc
+	ch <- nodeDrainStatesDesc
+}
+
+func (c *operatorCollector) Collect(ch chan<- prometheus.Metric) {
+	logger := log.Log.WithName("operatorCollector")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	policies := &sriovnetworkv1.SriovNetworkNodePolicyList{}
+	if err := c.reader.List(ctx, policies, client.InNamespace(vars.Namespace)); err != nil {
+		logger.Error(err, "failed to list SriovNetworkNodePolicies")
+		ch <- prometheus.NewInvalidMetric(policiesDesc, err)
+	} else {
+		ch <- prometheus.MustNewConstMetric(policiesDesc, prometheus.GaugeValue, float64(len(policies.Items)))
+	}
+
+	nodeStates := &sriovnetworkv1.SriovNetworkNodeStateList{}
+	if err := c.reader.List(ctx, nodeStates, client.InNamespace(vars.Namespace)); err != nil {
+		logger.Error(err, "failed to list SriovNetworkNodeStates")
+		ch <- prometheus.NewInvalidMetric(nodeStatesDesc, err)
+		ch <- prometheus.NewInvalidMetric(nodeDrainStatesDesc, err)
+		return
+	}
+
+	syncStatuses := map[string]int{
+		constants.SyncStatusSucceeded:  0,
+		constants.SyncStatusInProgress: 0,
+		constants.SyncStatusFailed:     0,
+	}
+	drainStates := map[string]int{
+		constants.DrainIdle:            0,
+		constants.DrainPending:         0,
+		constants.DrainApprovalPending: 0,
+		constants.DrainFailed:          0,
+		constants.Draining:             0,
+		constants.DrainComplete:        0,
+	}
+	for _, ns := range nodeStates.Items {
+		// the node states the daemon didn't report yet have no sync status
+		if ns.Status.SyncStatus != "" {
+			syncStatuses[ns.Status.SyncStatus]++
+		}
+		// the drain controller initializes a missing annotation to idle
+		drainState, ok := ns.GetAnnotations()[constants.NodeStateDrainAnnotationCurrent]
+		if !ok {
+			drainState = constants.DrainIdle
+		}
+		drainStates[drainState]++
+	}
+	for status, count := range syncStatuses {
+		ch <- prometheus.MustNewConstMetric(nodeStatesDesc, prometheus.GaugeValue, float64(count), status)
+	}
+	for state, count := range drainStates {
+		ch <- prometheus.MustNewConstMetric(nodeDrainStatesDesc, prometheus.GaugeValue, float64(count), state)
+	}
+}
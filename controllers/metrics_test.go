@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	constants "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+func TestOperatorCollector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(sriovnetworkv1.AddToScheme(scheme))
+	utilruntime.Must(corev1.AddToScheme(scheme))
+
+	nodeState := func(name, syncStatus, drainState string) client.Object {
+		ns := &sriovnetworkv1.SriovNetworkNodeState{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: vars.Namespace}}
+		if drainState != "" {
+			ns.Annotations = map[string]string{constants.NodeStateDrainAnnotationCurrent: drainState}
+		}
+		ns.Status.SyncStatus = syncStatus
+		return ns
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&sriovnetworkv1.SriovNetworkNodePolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy-1", Namespace: vars.Namespace}},
+		&sriovnetworkv1.SriovNetworkNodePolicy{ObjectMeta: metav1.ObjectMeta{Name: "policy-2", Namespace: vars.Namespace}},
+		nodeState("node-1", constants.SyncStatusSucceeded, constants.DrainIdle),
+		nodeState("node-2", constants.SyncStatusInProgress, constants.Draining),
+		nodeState("node-3", constants.SyncStatusInProgress, constants.DrainComplete),
+		nodeState("node-4", constants.SyncStatusFailed, ""),
+		nodeState("node-5", "", constants.DrainIdle),
+	).Build()
+
+	expected := `
+# HELP sriov_operator_node_drain_states Number of nodes by drain state
+# TYPE sriov_operator_node_drain_states gauge
+sriov_operator_node_drain_states{state="DrainComplete"} 1
+sriov_operator_node_drain_states{state="Drain_Approval_Pending"} 0
+sriov_operator_node_drain_states{state="Drain_Failed"} 0
+sriov_operator_node_drain_states{state="Drain_Pending"} 0
+sriov_operator_node_drain_states{state="Draining"} 1
+sriov_operator_node_drain_states{state="Idle"} 3
+# HELP sriov_operator_node_states Number of SriovNetworkNodeStates by sync status
+# TYPE sriov_operator_node_states gauge
+sriov_operator_node_states{sync_status="Failed"} 1
+sriov_operator_node_states{sync_status="InProgress"} 2
+sriov_operator_node_states{sync_status="Succeeded"} 1
+# HELP sriov_operator_policies Number of SriovNetworkNodePolicies
+# TYPE sriov_operator_policies gauge
+sriov_operator_policies 2
+`
+	if err := testutil.CollectAndCompare(&operatorCollector{reader: c}, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDrainDurationMetric(t *testing.T) {
+	sampleCount := func() uint64 {
+		m := &dto.Metric{}
+		if err := nodeDrainDuration.Write(m); err != nil {
+			t.Fatalf("failed to read the drain duration histogram: %v", err)
+		}
+		return m.GetHistogram().GetSampleCount()
+	}
+	dr := &DrainReconcile{drainStartTimes: map[string]time.Time{}}
+	count := sampleCount()
+
+	// a drain started before the operator restarted is not reported
+	dr.drainCompleted("node-1")
+	if sampleCount() != count {
+		t.Errorf("unexpected drain duration reported for an unknown drain start")
+	}
+
+	dr.drainStarted("node-1")
+	dr.drainCompleted("node-1")
+	if sampleCount() != count+1 {
+		t.Errorf("drain duration not reported")
+	}
+	if _, ok := dr.drainStartTimes["node-1"]; ok {
+		t.Errorf("drain start time not cleared")
+	}
+}
@@ -2,24 +2,34 @@ package controllers
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"io"
+	"path/filepath"
+	goruntime "runtime"
 	"strings"
 	"sync"
+	"testing"
 	"time"
 
 	netattdefv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
 	dynclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util"
 )
 
@@ -390,3 +400,94 @@ func generateExpectedNetConfig(cr *sriovnetworkv1.SriovNetwork) string {
 	}
 	return configStr
 }
+
+func TestSriovNetworkStatus(t *testing.T) {
+	// the envtest suite may change the working directory, use the path of this file instead
+	_, filename, _, _ := goruntime.Caller(0)
+	manifestsPath := sriovnetworkv1.ManifestsPath
+	sriovnetworkv1.ManifestsPath = filepath.Join(filepath.Dir(filename), "..", "bindata", "manifests", "cni-config")
+	defer func() { sriovnetworkv1.ManifestsPath = manifestsPath }()
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(sriovnetworkv1.AddToScheme(scheme))
+	utilruntime.Must(netattdefv1.AddToScheme(scheme))
+	utilruntime.Must(corev1.AddToScheme(scheme))
+
+	cr := &sriovnetworkv1.SriovNetwork{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-status", Namespace: vars.Namespace, Generation: 2},
+		Spec: sriovnetworkv1.SriovNetworkSpec{
+			ResourceName:     "resource_1",
+			IPAM:             `{"type":"host-local"}`,
+			NetworkNamespace: "target",
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithStatusSubresource(&sriovnetworkv1.SriovNetwork{}).
+		WithObjects(cr).Build()
+	r := &SriovNetworkReconciler{Client: c, Scheme: scheme}
+	r.genericReconciler = newGenericNetworkReconciler(c, scheme, r)
+
+	reconcileAndGetStatus := func() sriovnetworkv1.NetworkStatus {
+		_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}})
+		if err != nil {
+			t.Fatalf("reconcile failed: %v", err)
+		}
+		updated := &sriovnetworkv1.SriovNetwork{}
+		if err := c.Get(context.TODO(), types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, updated); err != nil {
+			t.Fatalf("failed to get network: %v", err)
+		}
+		return updated.Status.NetworkStatus
+	}
+
+	status := reconcileAndGetStatus()
+	ready := meta.FindStatusCondition(status.Conditions, sriovnetworkv1.ConditionReady)
+	if ready == nil || ready.Status != metav1.ConditionFalse || ready.Reason != sriovnetworkv1.NetworkReasonNamespaceNotFound {
+		t.Errorf("unexpected Ready condition %v", ready)
+	}
+	if status.NetAttDefRef == nil || status.NetAttDefRef.Namespace != "target" || status.NetAttDefRef.Name != cr.Name {
+		t.Errorf("unexpected NetworkAttachmentDefinition reference %v", status.NetAttDefRef)
+	}
+
+	if err := c.Create(context.TODO(), &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "target"}}); err != nil {
+		t.Fatalf("failed to create namespace: %v", err)
+	}
+	status = reconcileAndGetStatus()
+	ready = meta.FindStatusCondition(status.Conditions, sriovnetworkv1.ConditionReady)
+	if ready == nil || ready.Status != metav1.ConditionTrue || ready.ObservedGeneration != 2 {
+		t.Errorf("unexpected Ready condition %v", ready)
+	}
+	netAttDef := &netattdefv1.NetworkAttachmentDefinition{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: cr.Name, Namespace: "target"}, netAttDef); err != nil {
+		t.Fatalf("failed to get NetworkAttachmentDefinition: %v", err)
+	}
+	if status.ConfigHash != fmt.Sprintf("%x", sha256.Sum256([]byte(netAttDef.Spec.Config))) {
+		t.Errorf("unexpected config hash %s", status.ConfigHash)
+	}
+	if status.LastError != "" {
+		t.Errorf("unexpected last error %s", status.LastError)
+	}
+
+	// the reference to the NetworkAttachmentDefinition is dropped when the network can't be rendered
+	updated := &sriovnetworkv1.SriovNetwork{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, updated); err != nil {
+		t.Fatalf("failed to get network: %v", err)
+	}
+	updated.Spec.IPAM = `{"type":`
+	if err := c.Update(context.TODO(), updated); err != nil {
+		t.Fatalf("failed to update network: %v", err)
+	}
+	if _, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}}); err == nil {
+		t.Fatalf("expected the reconcile to fail")
+	}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, updated); err != nil {
+		t.Fatalf("failed to get network: %v", err)
+	}
+	status = updated.Status.NetworkStatus
+	ready = meta.FindStatusCondition(status.Conditions, sriovnetworkv1.ConditionReady)
+	if ready == nil || ready.Status != metav1.ConditionFalse || ready.Reason != sriovnetworkv1.NetworkReasonRenderFailed {
+		t.Errorf("unexpected Ready condition %v", ready)
+	}
+	if status.NetAttDefRef != nil || status.ConfigHash != "" {
+		t.Errorf("unexpected NetworkAttachmentDefinition reference %v and config hash %q", status.NetAttDefRef, status.ConfigHash)
+	}
+}
@@ -28,16 +28,19 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
@@ -46,6 +49,7 @@ import (
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	constants "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/featuregate"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/impact"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 )
@@ -126,12 +130,19 @@ func (r *SriovNetworkNodePolicyReconciler) Reconcile(ctx context.Context, req ct
 	// That is needed so when we create the node Affinity for the sriov-device plugin
 	// it will remain in the same order and not trigger a pod recreation
 	sort.Sort(sriovnetworkv1.ByPriority(policyList.Items))
+	// Policies in dry-run mode are not applied on the nodes
+	activePolicyList := activePolicies(policyList)
 	// Sync SriovNetworkNodeState objects
-	if err = r.syncAllSriovNetworkNodeStates(ctx, defaultOpConf, policyList, nodeList); err != nil {
+	macAllocator, err := r.syncAllSriovNetworkNodeStates(ctx, defaultOpConf, activePolicyList, nodeList)
+	if err != nil {
 		return reconcile.Result{}, err
 	}
 	// Sync Sriov device plugin ConfigMap object
-	if err = r.syncDevicePluginConfigMap(ctx, defaultOpConf, policyList, nodeList); err != nil {
+	if err = r.syncDevicePluginConfigMap(ctx, defaultOpConf, activePolicyList, nodeList); err != nil {
+		return reconcile.Result{}, err
+	}
+	// Sync SriovNetworkNodePolicy status
+	if err = r.syncAllPolicyStatuses(ctx, policyList, nodeList, macAllocator); err != nil {
 		return reconcile.Result{}, err
 	}
 
@@ -201,12 +212,33 @@ func (r *SriovNetworkNodePolicyReconciler) SetupWithManager(mgr ctrl.Manager) er
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&sriovnetworkv1.SriovNetworkNodePolicy{}).
 		Watches(&corev1.Node{}, nodeEvenHandler).
-		Watches(&sriovnetworkv1.SriovNetworkNodePolicy{}, delayedEventHandler).
+		// status updates done by the reconciler don't change the generation of the policy
+		Watches(&sriovnetworkv1.SriovNetworkNodePolicy{}, delayedEventHandler, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Watches(&sriovnetworkv1.SriovNetworkPoolConfig{}, delayedEventHandler).
+		Watches(&sriovnetworkv1.SriovNetworkNodeState{}, delayedEventHandler, builder.WithPredicates(nodeStateSyncStatusChanged)).
 		WatchesRawSource(&source.Channel{Source: eventChan}, delayedEventHandler).
 		Complete(r)
 }
 
+// nodeStateSyncStatusChanged triggers the policy reconcile only when the sync status reported by the config-daemon changes,
+// node state creation and removal are already covered by the node events
+var nodeStateSyncStatusChanged = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool { return false },
+	DeleteFunc: func(e event.DeleteEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldState, ok := e.ObjectOld.(*sriovnetworkv1.SriovNetworkNodeState)
+		if !ok {
+			return false
+		}
+		newState, ok := e.ObjectNew.(*sriovnetworkv1.SriovNetworkNodeState)
+		if !ok {
+			return false
+		}
+		return oldState.Status.SyncStatus != newState.Status.SyncStatus
+	},
+	GenericFunc: func(e event.GenericEvent) bool { return false },
+}
+
 func (r *SriovNetworkNodePolicyReconciler) syncDevicePluginConfigMap(ctx context.Context, dc *sriovnetworkv1.SriovOperatorConfig,
 	pl *sriovnetworkv1.SriovNetworkNodePolicyList, nl *corev1.NodeList) error {
 	logger := log.Log.WithName("syncDevicePluginConfigMap")
@@ -235,8 +267,9 @@ func (r *SriovNetworkNodePolicyReconciler) syncDevicePluginConfigMap(ctx context
 					constants.SriovDevicePluginLabelDisabled)
 				return err
 			}
-		} else {
-			// if we have policies we should add the enabled label for the device plugin
+		} else if node.Labels[constants.SriovDevicePluginLabel] != constants.SriovDevicePluginLabelPaused {
+			// if we have policies we should add the enabled label for the device plugin, unless the config daemon
+			// paused it to reconfigure VFs without a drain
 			err = utils.LabelNode(ctx, node.Name, constants.SriovDevicePluginLabel, constants.SriovDevicePluginLabelEnabled, r.Client)
 			if err != nil {
 				logger.Error(err, "failed to label node for device plugin label",
@@ -287,13 +320,20 @@ func (r *SriovNetworkNodePolicyReconciler) syncDevicePluginConfigMap(ctx context
 	return nil
 }
 
-func (r *SriovNetworkNodePolicyReconciler) syncAllSriovNetworkNodeStates(ctx context.Context, dc *sriovnetworkv1.SriovOperatorConfig, npl *sriovnetworkv1.SriovNetworkNodePolicyList, nl *corev1.NodeList) error {
+func (r *SriovNetworkNodePolicyReconciler) syncAllSriovNetworkNodeStates(ctx context.Context, dc *sriovnetworkv1.SriovOperatorConfig, npl *sriovnetworkv1.SriovNetworkNodePolicyList, nl *corev1.NodeList) (*vfMacAllocator, error) {
 	logger := log.Log.WithName("syncAllSriovNetworkNodeStates")
 	logger.V(1).Info("Start to sync all SriovNetworkNodeState custom resource")
 	found := &corev1.ConfigMap{}
 	if err := r.Get(ctx, types.NamespacedName{Namespace: vars.Namespace, Name: constants.ConfigMapName}, found); err != nil {
 		logger.V(1).Info("Fail to get", "ConfigMap", constants.ConfigMapName)
 	}
+	nsList := &sriovnetworkv1.SriovNetworkNodeStateList{}
+	if err := r.List(ctx, nsList, &client.ListOptions{Namespace: vars.Namespace}); err != nil {
+		logger.Error(err, "Fail to list SriovNetworkNodeState CRs")
+		return nil, err
+	}
+	// the allocator keeps the VF MAC addresses already assigned in the cluster
+	macAllocator := newVfMacAllocator(nsList.Items)
 	for _, node := range nl.Items {
 		logger.V(1).Info("Sync SriovNetworkNodeState CR", "name", node.Name)
 		ns := &sriovnetworkv1.SriovNetworkNodeState{}
@@ -308,18 +348,17 @@ func (r *SriovNetworkNodePolicyReconciler) syncAllSriovNetworkNodeStates(ctx con
 		}
 		j, _ := json.Marshal(ns)
 		logger.V(2).Info("SriovNetworkNodeState CR", "content", j)
-		if err := r.syncSriovNetworkNodeState(ctx, dc, npl, ns, &node); err != nil {
+		if err := r.syncSriovNetworkNodeState(ctx, dc, npl, ns, &node, macAllocator); err != nil {
 			logger.Error(err, "Fail to sync", "SriovNetworkNodeState", ns.Name)
-			return err
+			return nil, err
 		}
 	}
 	logger.V(1).Info("Remove SriovNetworkNodeState custom resource for unselected node")
-	nsList := &sriovnetworkv1.SriovNetworkNodeStateList{}
 	err := r.List(ctx, nsList, &client.ListOptions{})
 	if err != nil {
 		if !errors.IsNotFound(err) {
 			logger.Error(err, "Fail to list SriovNetworkNodeState CRs")
-			return err
+			return nil, err
 		}
 	} else {
 		for _, ns := range nsList.Items {
@@ -336,25 +375,26 @@ func (r *SriovNetworkNodePolicyReconciler) syncAllSriovNetworkNodeStates(ctx con
 				err = utils.RemoveLabelFromNode(ctx, ns.Name, constants.SriovDevicePluginLabel, r.Client)
 				if err != nil {
 					logger.Error(err, "Fail to remove device plugin label from node", "node", ns.Name)
-					return err
+					return nil, err
 				}
 				logger.Info("Deleting SriovNetworkNodeState as node with that name doesn't exist", "nodeStateName", ns.Name)
 				err = r.Delete(ctx, &ns, &client.DeleteOptions{})
 				if err != nil {
 					logger.Error(err, "Fail to Delete", "SriovNetworkNodeState CR:", ns.GetName())
-					return err
+					return nil, err
 				}
 			}
 		}
 	}
-	return nil
+	return macAllocator, nil
 }
 
 func (r *SriovNetworkNodePolicyReconciler) syncSriovNetworkNodeState(ctx context.Context,
 	dc *sriovnetworkv1.SriovOperatorConfig,
 	npl *sriovnetworkv1.SriovNetworkNodePolicyList,
 	ns *sriovnetworkv1.SriovNetworkNodeState,
-	node *corev1.Node) error {
+	node *corev1.Node,
+	macAllocator *vfMacAllocator) error {
 	logger := log.Log.WithName("syncSriovNetworkNodeState")
 	logger.V(1).Info("Start to sync SriovNetworkNodeState", "Name", ns.Name)
 
@@ -386,36 +426,10 @@ func (r *SriovNetworkNodePolicyReconciler) syncSriovNetworkNodeState(ctx context
 		newVersion.Spec = ns.Spec
 		newVersion.OwnerReferences = ns.OwnerReferences
 
-		// Previous Policy Priority(ppp) records the priority of previous evaluated policy in node policy list.
-		// Since node policy list is already sorted with priority number, comparing current priority with ppp shall
-		// be sufficient.
-		// ppp is set to 100 as initial value to avoid matching with the first policy in policy list, although
-		// it should not matter since the flag used in p.Apply() will only be applied when VF partition is detected.
-		ppp := 100
-		for _, p := range npl.Items {
-			// Note(adrianc): default policy is deprecated and ignored.
-			if p.Name == constants.DefaultPolicyName {
-				continue
-			}
-			if p.Selected(node) {
-				logger.Info("apply", "policy", p.Name, "node", node.Name)
-				// Merging only for policies with the same priority (ppp == p.Spec.Priority)
-				// This boolean flag controls merging of PF configuration (e.g. mtu, numvfs etc)
-				// when VF partition is configured.
-				err = p.Apply(newVersion, ppp == p.Spec.Priority)
-				if err != nil {
-					return err
-				}
-				if r.FeatureGate.IsEnabled(constants.ManageSoftwareBridgesFeatureGate) {
-					err = p.ApplyBridgeConfig(newVersion)
-					if err != nil {
-						return err
-					}
-				}
-				// record the evaluated policy priority for next loop
-				ppp = p.Spec.Priority
-			}
+		if err := r.applyPolicies(newVersion, npl, node); err != nil {
+			return err
 		}
+		macAllocator.assign(newVersion, npl)
 
 		// Note(adrianc): we check same ownerReferences since SriovNetworkNodeState
 		// was owned by a default SriovNetworkNodePolicy. if we encounter a descripancy
@@ -433,6 +447,277 @@ func (r *SriovNetworkNodePolicyReconciler) syncSriovNetworkNodeState(ctx context
 	return nil
 }
 
+// applyPolicies merges the configuration of the policies selecting the node into the SriovNetworkNodeState spec
+func (r *SriovNetworkNodePolicyReconciler) applyPolicies(state *sriovnetworkv1.SriovNetworkNodeState,
+	npl *sriovnetworkv1.SriovNetworkNodePolicyList, node *corev1.Node) error {
+	return state.ApplyPolicies(npl.Items, node, r.FeatureGate.IsEnabled(constants.ManageSoftwareBridgesFeatureGate))
+}
+
+// activePolicies returns the policies which are not in dry-run mode
+func activePolicies(npl *sriovnetworkv1.SriovNetworkNodePolicyList) *sriovnetworkv1.SriovNetworkNodePolicyList {
+	active := &sriovnetworkv1.SriovNetworkNodePolicyList{}
+	for _, p := range npl.Items {
+		if !p.IsDryRun() {
+			active.Items = append(active.Items, p)
+		}
+	}
+	return active
+}
+
+func (r *SriovNetworkNodePolicyReconciler) syncAllPolicyStatuses(ctx context.Context, npl *sriovnetworkv1.SriovNetworkNodePolicyList, nl *corev1.NodeList,
+	macAllocator *vfMacAllocator) error {
+	logger := log.Log.WithName("syncAllPolicyStatuses")
+	logger.V(1).Info("Start to sync SriovNetworkNodePolicy status")
+
+	nsList := &sriovnetworkv1.SriovNetworkNodeStateList{}
+	if err := r.List(ctx, nsList, &client.ListOptions{Namespace: vars.Namespace}); err != nil {
+		logger.Error(err, "Fail to list SriovNetworkNodeState CRs")
+		return err
+	}
+	nodeStates := make(map[string]*sriovnetworkv1.SriovNetworkNodeState, len(nsList.Items))
+	for i := range nsList.Items {
+		nodeStates[nsList.Items[i].Name] = &nsList.Items[i]
+	}
+
+	for i := range npl.Items {
+		p := &npl.Items[i]
+		// Note(adrianc): default policy is deprecated and ignored.
+		if p.Name == constants.DefaultPolicyName {
+			continue
+		}
+		var newStatus sriovnetworkv1.SriovNetworkNodePolicyStatus
+		if p.IsDryRun() {
+			previews := r.previewPolicy(ctx, p, activePolicies(npl), nl, nsList)
+			newStatus = renderPolicyPreviewStatus(p, previews)
+		} else {
+			newStatus = renderPolicyStatus(p, nl, nodeStates, macAllocator.policyErrors(p.Name))
+		}
+		if equality.Semantic.DeepEqual(p.Status, newStatus) {
+			continue
+		}
+		newVersion := p.DeepCopy()
+		newVersion.Status = newStatus
+		logger.V(1).Info("Update SriovNetworkNodePolicy status", "policy", p.Name,
+			"matched", newStatus.MatchedNodeCount, "applied", newStatus.AppliedNodeCount,
+			"pending", newStatus.PendingNodeCount, "failed", newStatus.FailedNodeCount)
+		if err := r.Status().Update(ctx, newVersion); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("couldn't update SriovNetworkNodePolicy %s status: %v", p.Name, err)
+		}
+	}
+	return nil
+}
+
+// renderPolicyStatus computes the status of the policy from the specs and the sync status
+// of the SriovNetworkNodeState objects of the nodes selected by the policy
+func renderPolicyStatus(p *sriovnetworkv1.SriovNetworkNodePolicy, nl *corev1.NodeList,
+	nodeStates map[string]*sriovnetworkv1.SriovNetworkNodeState, macErrors []string) sriovnetworkv1.SriovNetworkNodePolicyStatus {
+	status := sriovnetworkv1.SriovNetworkNodePolicyStatus{}
+	// keep the existing conditions to preserve the transition time
+	status.Conditions = append(status.Conditions, p.Status.Conditions...)
+
+	for i := range nl.Items {
+		node := &nl.Items[i]
+		if !p.Selected(node) {
+			continue
+		}
+		status.MatchedNodeCount++
+		nodeStatus := sriovnetworkv1.PolicyNodeStatus{Name: node.Name}
+		ns, ok := nodeStates[node.Name]
+		if ok {
+			nodeStatus.SyncStatus = ns.Status.SyncStatus
+			for _, iface := range ns.Spec.Interfaces {
+				for _, group := range iface.VfGroups {
+					if group.PolicyName == p.Name {
+						nodeStatus.PciAddresses = append(nodeStatus.PciAddresses, iface.PciAddress)
+						break
+					}
+				}
+			}
+		}
+		switch nodeStatus.SyncStatus {
+		case constants.SyncStatusSucceeded:
+			status.AppliedNodeCount++
+		case constants.SyncStatusFailed:
+			status.FailedNodeCount++
+		default:
+			status.PendingNodeCount++
+		}
+		status.Nodes = append(status.Nodes, nodeStatus)
+	}
+	sort.Slice(status.Nodes, func(i, j int) bool { return status.Nodes[i].Name < status.Nodes[j].Name })
+
+	readyCond := metav1.Condition{Type: sriovnetworkv1.ConditionReady, ObservedGeneration: p.Generation}
+	progressingCond := metav1.Condition{Type: sriovnetworkv1.ConditionProgressing, ObservedGeneration: p.Generation}
+	degradedCond := metav1.Condition{Type: sriovnetworkv1.ConditionDegraded, ObservedGeneration: p.Generation}
+
+	switch {
+	case status.MatchedNodeCount == 0:
+		readyCond.Status = metav1.ConditionFalse
+		readyCond.Reason = sriovnetworkv1.PolicyReasonNoMatchingNodes
+		readyCond.Message = "policy doesn't select any node"
+	case status.AppliedNodeCount == status.MatchedNodeCount:
+		readyCond.Status = metav1.ConditionTrue
+		readyCond.Reason = sriovnetworkv1.PolicyReasonAllNodesApplied
+		readyCond.Message = fmt.Sprintf("policy applied on %d nodes", status.AppliedNodeCount)
+	case status.FailedNodeCount > 0:
+		readyCond.Status = metav1.ConditionFalse
+		readyCond.Reason = sriovnetworkv1.PolicyReasonNodesFailed
+		readyCond.Message = fmt.Sprintf("policy applied on %d of %d nodes", status.AppliedNodeCount, status.MatchedNodeCount)
+	default:
+		readyCond.Status = metav1.ConditionFalse
+		readyCond.Reason = sriovnetworkv1.PolicyReasonNodesPending
+		readyCond.Message = fmt.Sprintf("policy applied on %d of %d nodes", status.AppliedNodeCount, status.MatchedNodeCount)
+	}
+
+	if status.PendingNodeCount > 0 {
+		progressingCond.Status = metav1.ConditionTrue
+		progressingCond.Reason = sriovnetworkv1.PolicyReasonNodesPending
+		progressingCond.Message = fmt.Sprintf("%d nodes are applying the policy", status.PendingNodeCount)
+	} else {
+		progressingCond.Status = metav1.ConditionFalse
+		progressingCond.Reason = sriovnetworkv1.PolicyReasonNoPendingNodes
+	}
+
+	if status.FailedNodeCount > 0 {
+		degradedCond.Status = metav1.ConditionTrue
+		degradedCond.Reason = sriovnetworkv1.PolicyReasonNodesFailed
+		degradedCond.Message = fmt.Sprintf("%d nodes failed to apply the policy", status.FailedNodeCount)
+	} else if len(macErrors) > 0 {
+		degradedCond.Status = metav1.ConditionTrue
+		degradedCond.Reason = sriovnetworkv1.PolicyReasonMacAllocationFailed
+		degradedCond.Message = fmt.Sprintf("%d VFs have no MAC address assigned: %s", len(macErrors), macErrors[0])
+	} else {
+		degradedCond.Status = metav1.ConditionFalse
+		degradedCond.Reason = sriovnetworkv1.PolicyReasonNoFailures
+	}
+
+	meta.SetStatusCondition(&status.Conditions, readyCond)
+	meta.SetStatusCondition(&status.Conditions, progressingCond)
+	meta.SetStatusCondition(&status.Conditions, degradedCond)
+	return status
+}
+
+// previewPolicy computes the changes the policy would cause on each selected node if it was applied
+// together with the active policies, without updating the SriovNetworkNodeState objects
+func (r *SriovNetworkNodePolicyReconciler) previewPolicy(ctx context.Context, p *sriovnetworkv1.SriovNetworkNodePolicy,
+	active *sriovnetworkv1.SriovNetworkNodePolicyList, nl *corev1.NodeList,
+	nsList *sriovnetworkv1.SriovNetworkNodeStateList) []sriovnetworkv1.PolicyNodePreview {
+	npl := &sriovnetworkv1.SriovNetworkNodePolicyList{Items: append([]sriovnetworkv1.SriovNetworkNodePolicy{*p}, active.Items...)}
+	sort.Sort(sriovnetworkv1.ByPriority(npl.Items))
+
+	previews := []sriovnetworkv1.PolicyNodePreview{}
+	for i := range nl.Items {
+		node := &nl.Items[i]
+		if !p.Selected(node) {
+			continue
+		}
+		preview := sriovnetworkv1.PolicyNodePreview{Name: node.Name}
+		if err := r.previewNode(ctx, &preview, npl, node, nsList); err != nil {
+			preview.Error = err.Error()
+		}
+		previews = append(previews, preview)
+	}
+	sort.Slice(previews, func(i, j int) bool { return previews[i].Name < previews[j].Name })
+	return previews
+}
+
+func (r *SriovNetworkNodePolicyReconciler) previewNode(ctx context.Context, preview *sriovnetworkv1.PolicyNodePreview,
+	npl *sriovnetworkv1.SriovNetworkNodePolicyList, node *corev1.Node, nsList *sriovnetworkv1.SriovNetworkNodeStateList) error {
+	var ns *sriovnetworkv1.SriovNetworkNodeState
+	for i := range nsList.Items {
+		if nsList.Items[i].Name == node.Name {
+			ns = &nsList.Items[i]
+			break
+		}
+	}
+	if ns == nil || len(ns.Status.Interfaces) == 0 {
+		return fmt.Errorf("the SriovNetworkNodeState of the node didn't report any interface yet")
+	}
+
+	newVersion := ns.DeepCopy()
+	newVersion.Spec = sriovnetworkv1.SriovNetworkNodeStateSpec{System: ns.Spec.System}
+	if err := r.applyPolicies(newVersion, npl, node); err != nil {
+		return err
+	}
+	// use a dedicated allocator to not reserve MAC addresses for the preview
+	newVfMacAllocator(nsList.Items).assign(newVersion, npl)
+
+	for _, iface := range newVersion.Spec.Interfaces {
+		current, err := sriovnetworkv1.FindInterfaceByPciAddress(ns.Spec.Interfaces, iface.PciAddress)
+		if err != nil || !equality.Semantic.DeepEqual(current, iface) {
+			preview.UpdatedInterfaces = append(preview.UpdatedInterfaces, iface)
+		}
+	}
+	for _, iface := range ns.Spec.Interfaces {
+		if _, err := sriovnetworkv1.FindInterfaceByPciAddress(newVersion.Spec.Interfaces, iface.PciAddress); err != nil {
+			preview.RemovedInterfaces = append(preview.RemovedInterfaces, iface.PciAddress)
+		}
+	}
+	if !equality.Semantic.DeepEqual(newVersion.Spec.Bridges, ns.Spec.Bridges) {
+		preview.Bridges = newVersion.Spec.Bridges.DeepCopy()
+	}
+
+	nodeImpact := impact.Estimate(ns, &newVersion.Spec)
+	preview.DrainRequired = nodeImpact.Drain
+	preview.RebootRequired = nodeImpact.RebootRequired()
+
+	rcl, err := r.renderDevicePluginConfigData(ctx, npl, node)
+	if err != nil {
+		return err
+	}
+	config, err := json.Marshal(rcl)
+	if err != nil {
+		return err
+	}
+	preview.DevicePluginConfig = string(config)
+	return nil
+}
+
+// renderPolicyPreviewStatus computes the status of a policy in dry-run mode from the preview of the selected nodes
+func renderPolicyPreviewStatus(p *sriovnetworkv1.SriovNetworkNodePolicy, previews []sriovnetworkv1.PolicyNodePreview) sriovnetworkv1.SriovNetworkNodePolicyStatus {
+	status := sriovnetworkv1.SriovNetworkNodePolicyStatus{
+		MatchedNodeCount: len(previews),
+		Preview:          previews,
+	}
+	// keep the existing conditions to preserve the transition time
+	status.Conditions = append(status.Conditions, p.Status.Conditions...)
+
+	failed := []string{}
+	for _, preview := range previews {
+		if preview.Error != "" {
+			failed = append(failed, fmt.Sprintf("%s: %s", preview.Name, preview.Error))
+		}
+	}
+
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               sriovnetworkv1.ConditionReady,
+		Status:             metav1.ConditionFalse,
+		Reason:             sriovnetworkv1.PolicyReasonDryRun,
+		Message:            "policy is in dry-run mode, it is not applied on the nodes",
+		ObservedGeneration: p.Generation,
+	})
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               sriovnetworkv1.ConditionProgressing,
+		Status:             metav1.ConditionFalse,
+		Reason:             sriovnetworkv1.PolicyReasonDryRun,
+		ObservedGeneration: p.Generation,
+	})
+	degradedCond := metav1.Condition{Type: sriovnetworkv1.ConditionDegraded, ObservedGeneration: p.Generation}
+	if len(failed) > 0 {
+		degradedCond.Status = metav1.ConditionTrue
+		degradedCond.Reason = sriovnetworkv1.PolicyReasonPreviewFailed
+		degradedCond.Message = fmt.Sprintf("can't compute the preview of %d nodes: %s", len(failed), failed[0])
+	} else {
+		degradedCond.Status = metav1.ConditionFalse
+		degradedCond.Reason = sriovnetworkv1.PolicyReasonNoFailures
+	}
+	meta.SetStatusCondition(&status.Conditions, degradedCond)
+	return status
+}
+
 func (r *SriovNetworkNodePolicyReconciler) renderDevicePluginConfigData(ctx context.Context, pl *sriovnetworkv1.SriovNetworkNodePolicyList, node *corev1.Node) (dptypes.ResourceConfList, error) {
 	logger := log.Log.WithName("renderDevicePluginConfigData")
 	logger.V(1).Info("Start to render device plugin config data", "node", node.Name)
@@ -454,6 +739,12 @@ func (r *SriovNetworkNodePolicyReconciler) renderDevicePluginConfigData(ctx cont
 			return rcl, err
 		}
 
+		if p.Spec.NicSelector.HasNodeSpecificFilters() && len(resolveRootDevices(&p, nodeState)) == 0 {
+			// an empty rootDevices selector would expose all the VFs matching the other selectors
+			logger.V(1).Info("no PF selected by the policy on the node, skipping", "policy", p.Name)
+			continue
+		}
+
 		found, i := resourceNameInList(p.Spec.ResourceName, &rcl)
 
 		if found {
@@ -523,7 +814,10 @@ func createDevicePluginResource(
 			netDeviceSelectors.LinkTypes = sriovnetworkv1.UniqueAppend(netDeviceSelectors.LinkTypes, linkType)
 		}
 	}
-	if len(p.Spec.NicSelector.RootDevices) > 0 {
+	if p.Spec.NicSelector.HasNodeSpecificFilters() {
+		// the device plugin can't evaluate all the nic selector filters, use the PFs selected on the node instead
+		netDeviceSelectors.RootDevices = sriovnetworkv1.UniqueAppend(netDeviceSelectors.RootDevices, resolveRootDevices(p, nodeState)...)
+	} else if len(p.Spec.NicSelector.RootDevices) > 0 {
 		netDeviceSelectors.RootDevices = append(netDeviceSelectors.RootDevices, p.Spec.NicSelector.RootDevices...)
 	}
 	// Removed driver constraint for "netdevice" DeviceType
@@ -556,6 +850,17 @@ func createDevicePluginResource(
 	return rc, nil
 }
 
+// resolveRootDevices returns the PCI addresses of the PFs on the node selected by the policy
+func resolveRootDevices(p *sriovnetworkv1.SriovNetworkNodePolicy, nodeState *sriovnetworkv1.SriovNetworkNodeState) []string {
+	rootDevices := []string{}
+	for _, iface := range nodeState.Status.Interfaces {
+		if p.Spec.NicSelector.Selected(&iface) {
+			rootDevices = append(rootDevices, iface.PciAddress)
+		}
+	}
+	return rootDevices
+}
+
 func updateDevicePluginResource(
 	rc *dptypes.ResourceConfig,
 	p *sriovnetworkv1.SriovNetworkNodePolicy,
@@ -596,7 +901,10 @@ func updateDevicePluginResource(
 			}
 		}
 	}
-	if len(p.Spec.NicSelector.RootDevices) > 0 {
+	if p.Spec.NicSelector.HasNodeSpecificFilters() {
+		// the device plugin can't evaluate all the nic selector filters, use the PFs selected on the node instead
+		netDeviceSelectors.RootDevices = sriovnetworkv1.UniqueAppend(netDeviceSelectors.RootDevices, resolveRootDevices(p, nodeState)...)
+	} else if len(p.Spec.NicSelector.RootDevices) > 0 {
 		netDeviceSelectors.RootDevices = sriovnetworkv1.UniqueAppend(netDeviceSelectors.RootDevices, p.Spec.NicSelector.RootDevices...)
 	}
 	// Removed driver constraint for "netdevice" DeviceType
@@ -3,6 +3,7 @@ package controllers
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -84,6 +86,44 @@ func TestRenderDevicePluginConfigData(t *testing.T) {
 				},
 			},
 		},
+		{
+			tname: "testNumaNodeFilter",
+			policy: sriovnetworkv1.SriovNetworkNodePolicy{
+				Spec: v1.SriovNetworkNodePolicySpec{
+					ResourceName: "resourceName",
+					NicSelector: v1.SriovNetworkNicSelector{
+						Vendor:    "8086",
+						NumaNodes: []int{1},
+					},
+				},
+			},
+			expResource: dptypes.ResourceConfList{
+				ResourceList: []dptypes.ResourceConfig{
+					{
+						ResourceName: "resourceName",
+						Selectors: mustMarshallSelector(t, &dptypes.NetDeviceSelectors{
+							DeviceSelectors: dptypes.DeviceSelectors{
+								Vendors: []string{"8086"},
+							},
+							RootDevices: []string{"0000:af:00.0"},
+						}),
+					},
+				},
+			},
+		},
+		{
+			tname: "testNoPfSelected",
+			policy: sriovnetworkv1.SriovNetworkNodePolicy{
+				Spec: v1.SriovNetworkNodePolicySpec{
+					ResourceName: "resourceName",
+					NicSelector: v1.SriovNetworkNicSelector{
+						RootDevices: []string{"0000:3b-af:00.*"},
+						PfDrivers:   []string{"mlx5_core"},
+					},
+				},
+			},
+			expResource: dptypes.ResourceConfList{},
+		},
 		{
 			tname: "testExcludeTopology",
 			policy: sriovnetworkv1.SriovNetworkNodePolicy{
@@ -108,8 +148,17 @@ func TestRenderDevicePluginConfigData(t *testing.T) {
 		FeatureGate: featuregate.New(),
 	}
 
+	numaNode0, numaNode1 := 0, 1
 	node := corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
-	nodeState := sriovnetworkv1.SriovNetworkNodeState{ObjectMeta: metav1.ObjectMeta{Name: node.Name, Namespace: vars.Namespace}}
+	nodeState := sriovnetworkv1.SriovNetworkNodeState{
+		ObjectMeta: metav1.ObjectMeta{Name: node.Name, Namespace: vars.Namespace},
+		Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+			Interfaces: sriovnetworkv1.InterfaceExts{
+				{Name: "ens1f0", PciAddress: "0000:3b:00.0", Vendor: "8086", Driver: "ice", NumaNode: &numaNode0},
+				{Name: "ens2f0", PciAddress: "0000:af:00.0", Vendor: "8086", Driver: "ice", NumaNode: &numaNode1},
+			},
+		},
+	}
 
 	scheme := runtime.NewScheme()
 	utilruntime.Must(sriovnetworkv1.AddToScheme(scheme))
@@ -133,6 +182,248 @@ func TestRenderDevicePluginConfigData(t *testing.T) {
 	}
 }
 
+func TestRenderPolicyStatus(t *testing.T) {
+	policy := &sriovnetworkv1.SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy1", Generation: 2},
+		Spec: v1.SriovNetworkNodePolicySpec{
+			NodeSelector: map[string]string{"sriov": "true"},
+		},
+	}
+	nodeList := &corev1.NodeList{Items: []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{"sriov": "true"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node2", Labels: map[string]string{"sriov": "true"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node3", Labels: map[string]string{"sriov": "true"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node4"}},
+	}}
+	nodeStateWithPolicy := func(name, syncStatus string) *sriovnetworkv1.SriovNetworkNodeState {
+		return &sriovnetworkv1.SriovNetworkNodeState{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{Interfaces: sriovnetworkv1.Interfaces{
+				{PciAddress: "0000:86:00.0", VfGroups: []sriovnetworkv1.VfGroup{{PolicyName: "policy1"}}},
+				{PciAddress: "0000:86:00.1", VfGroups: []sriovnetworkv1.VfGroup{{PolicyName: "other"}}},
+			}},
+			Status: sriovnetworkv1.SriovNetworkNodeStateStatus{SyncStatus: syncStatus},
+		}
+	}
+	nodeStates := map[string]*sriovnetworkv1.SriovNetworkNodeState{
+		"node1": nodeStateWithPolicy("node1", consts.SyncStatusSucceeded),
+		"node2": nodeStateWithPolicy("node2", consts.SyncStatusInProgress),
+		"node4": nodeStateWithPolicy("node4", consts.SyncStatusFailed),
+	}
+
+	status := renderPolicyStatus(policy, nodeList, nodeStates, nil)
+	if status.MatchedNodeCount != 3 || status.AppliedNodeCount != 1 || status.PendingNodeCount != 2 || status.FailedNodeCount != 0 {
+		t.Errorf("unexpected node counters: %+v", status)
+	}
+	expNodes := []sriovnetworkv1.PolicyNodeStatus{
+		{Name: "node1", PciAddresses: []string{"0000:86:00.0"}, SyncStatus: consts.SyncStatusSucceeded},
+		{Name: "node2", PciAddresses: []string{"0000:86:00.0"}, SyncStatus: consts.SyncStatusInProgress},
+		{Name: "node3"},
+	}
+	if !cmp.Equal(status.Nodes, expNodes) {
+		t.Error("unexpected nodes status", cmp.Diff(status.Nodes, expNodes))
+	}
+	ready := meta.FindStatusCondition(status.Conditions, sriovnetworkv1.ConditionReady)
+	if ready == nil || ready.Status != metav1.ConditionFalse || ready.Reason != sriovnetworkv1.PolicyReasonNodesPending || ready.ObservedGeneration != 2 {
+		t.Errorf("unexpected Ready condition: %+v", ready)
+	}
+	if !meta.IsStatusConditionTrue(status.Conditions, sriovnetworkv1.ConditionProgressing) {
+		t.Error("expected Progressing condition to be true")
+	}
+	if !meta.IsStatusConditionFalse(status.Conditions, sriovnetworkv1.ConditionDegraded) {
+		t.Error("expected Degraded condition to be false")
+	}
+
+	nodeStates["node2"].Status.SyncStatus = consts.SyncStatusFailed
+	nodeStates["node3"] = nodeStateWithPolicy("node3", consts.SyncStatusSucceeded)
+	policy.Status = status
+	status = renderPolicyStatus(policy, nodeList, nodeStates, nil)
+	if status.AppliedNodeCount != 2 || status.PendingNodeCount != 0 || status.FailedNodeCount != 1 {
+		t.Errorf("unexpected node counters: %+v", status)
+	}
+	if !meta.IsStatusConditionTrue(status.Conditions, sriovnetworkv1.ConditionDegraded) {
+		t.Error("expected Degraded condition to be true")
+	}
+	if !meta.IsStatusConditionFalse(status.Conditions, sriovnetworkv1.ConditionProgressing) {
+		t.Error("expected Progressing condition to be false")
+	}
+	if len(status.Conditions) != 3 {
+		t.Errorf("expected 3 conditions, got %d", len(status.Conditions))
+	}
+}
+
+func TestPreviewPolicy(t *testing.T) {
+	active := sriovnetworkv1.SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "active", Namespace: vars.Namespace},
+		Spec: v1.SriovNetworkNodePolicySpec{
+			ResourceName: "active",
+			NodeSelector: map[string]string{"sriov": "true"},
+			NicSelector:  v1.SriovNetworkNicSelector{PfNames: []string{"ens1f0"}},
+			NumVfs:       2,
+			DeviceType:   consts.DeviceTypeNetDevice,
+		},
+	}
+	dryRun := sriovnetworkv1.SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "dry-run",
+			Namespace:   vars.Namespace,
+			Generation:  3,
+			Annotations: map[string]string{consts.PolicyDryRunAnnotation: "true"},
+		},
+		Spec: v1.SriovNetworkNodePolicySpec{
+			ResourceName: "preview",
+			NodeSelector: map[string]string{"sriov": "true"},
+			NicSelector:  v1.SriovNetworkNicSelector{PfNames: []string{"ens2f0"}},
+			NumVfs:       4,
+			DeviceType:   consts.DeviceTypeVfioPci,
+		},
+	}
+	npl := &sriovnetworkv1.SriovNetworkNodePolicyList{Items: []sriovnetworkv1.SriovNetworkNodePolicy{active, dryRun}}
+	if activeList := activePolicies(npl); len(activeList.Items) != 1 || activeList.Items[0].Name != "active" {
+		t.Fatalf("unexpected active policies %v", activeList.Items)
+	}
+
+	nodeList := &corev1.NodeList{Items: []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{"sriov": "true"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node2", Labels: map[string]string{"sriov": "true"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node3"}},
+	}}
+	nodeState := sriovnetworkv1.SriovNetworkNodeState{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1", Namespace: vars.Namespace},
+		Status: sriovnetworkv1.SriovNetworkNodeStateStatus{
+			Interfaces: sriovnetworkv1.InterfaceExts{
+				{Name: "ens1f0", PciAddress: "0000:3b:00.0", Vendor: "8086", Driver: "ice", NumVfs: 2, TotalVfs: 64,
+					VFs: []sriovnetworkv1.VirtualFunction{{VfID: 0, Driver: "iavf"}, {VfID: 1, Driver: "iavf"}}},
+				{Name: "ens2f0", PciAddress: "0000:af:00.0", Vendor: "8086", Driver: "ice", TotalVfs: 64},
+			},
+		},
+	}
+	reconciler := SriovNetworkNodePolicyReconciler{FeatureGate: featuregate.New()}
+	if err := reconciler.applyPolicies(&nodeState, activePolicies(npl), &nodeList.Items[0]); err != nil {
+		t.Fatalf("failed to apply the active policies: %v", err)
+	}
+	nsList := &sriovnetworkv1.SriovNetworkNodeStateList{Items: []sriovnetworkv1.SriovNetworkNodeState{nodeState}}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(sriovnetworkv1.AddToScheme(scheme))
+	reconciler.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(&nodeState).Build()
+
+	previews := reconciler.previewPolicy(context.TODO(), &dryRun, activePolicies(npl), nodeList, nsList)
+	if len(previews) != 2 || previews[0].Name != "node1" || previews[1].Name != "node2" {
+		t.Fatalf("unexpected previews %+v", previews)
+	}
+	node1 := previews[0]
+	if node1.Error != "" {
+		t.Fatalf("unexpected preview error %s", node1.Error)
+	}
+	if len(node1.UpdatedInterfaces) != 1 || node1.UpdatedInterfaces[0].PciAddress != "0000:af:00.0" ||
+		node1.UpdatedInterfaces[0].NumVfs != 4 || node1.UpdatedInterfaces[0].VfGroups[0].PolicyName != "dry-run" {
+		t.Errorf("unexpected updated interfaces %+v", node1.UpdatedInterfaces)
+	}
+	if len(node1.RemovedInterfaces) != 0 || node1.Bridges != nil {
+		t.Errorf("unexpected removed interfaces or bridges %+v", node1)
+	}
+	if !strings.Contains(node1.DevicePluginConfig, `"resourceName":"active"`) || !strings.Contains(node1.DevicePluginConfig, `"resourceName":"preview"`) {
+		t.Errorf("unexpected device plugin config %s", node1.DevicePluginConfig)
+	}
+	// the vfio-pci driver requires the IOMMU kernel args
+	if !node1.RebootRequired || !node1.DrainRequired {
+		t.Errorf("expected drain and reboot on node1 %+v", node1)
+	}
+	if previews[1].Error == "" {
+		t.Error("expected an error for the node without SriovNetworkNodeState")
+	}
+
+	status := renderPolicyPreviewStatus(&dryRun, previews)
+	if status.MatchedNodeCount != 2 || len(status.Preview) != 2 || status.AppliedNodeCount != 0 {
+		t.Errorf("unexpected status %+v", status)
+	}
+	ready := meta.FindStatusCondition(status.Conditions, sriovnetworkv1.ConditionReady)
+	if ready == nil || ready.Status != metav1.ConditionFalse || ready.Reason != sriovnetworkv1.PolicyReasonDryRun || ready.ObservedGeneration != 3 {
+		t.Errorf("unexpected Ready condition: %+v", ready)
+	}
+	degraded := meta.FindStatusCondition(status.Conditions, sriovnetworkv1.ConditionDegraded)
+	if degraded == nil || degraded.Status != metav1.ConditionTrue || degraded.Reason != sriovnetworkv1.PolicyReasonPreviewFailed ||
+		!strings.Contains(degraded.Message, "node2") {
+		t.Errorf("unexpected Degraded condition: %+v", degraded)
+	}
+}
+
+func TestVfMacAllocator(t *testing.T) {
+	npl := &sriovnetworkv1.SriovNetworkNodePolicyList{Items: []sriovnetworkv1.SriovNetworkNodePolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "pool"},
+			Spec: v1.SriovNetworkNodePolicySpec{VfMacAllocation: &v1.VfMacAllocation{
+				Pool: []string{"02:00:00:00:00:01-02:00:00:00:00:03"},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "hash"},
+			Spec:       v1.SriovNetworkNodePolicySpec{VfMacAllocation: &v1.VfMacAllocation{Prefix: "02:5a"}},
+		},
+		{ObjectMeta: metav1.ObjectMeta{Name: "none"}},
+	}}
+	nodeState := func(name string, poolMacs []v1.VfMac) *sriovnetworkv1.SriovNetworkNodeState {
+		return &sriovnetworkv1.SriovNetworkNodeState{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{Interfaces: sriovnetworkv1.Interfaces{
+				{PciAddress: "0000:86:00.0", VfGroups: []sriovnetworkv1.VfGroup{
+					{PolicyName: "pool", VfRange: "0-1", VfMacs: poolMacs},
+					{PolicyName: "none", VfRange: "2-3"},
+				}},
+				{PciAddress: "0000:86:00.1", VfGroups: []sriovnetworkv1.VfGroup{{PolicyName: "hash", VfRange: "0-0"}}},
+			}},
+		}
+	}
+
+	// node2 already has a MAC address from the pool, it must be kept
+	node1 := nodeState("node1", nil)
+	node2 := nodeState("node2", []v1.VfMac{{VfID: 1, Mac: "02:00:00:00:00:01"}})
+	allocator := newVfMacAllocator([]sriovnetworkv1.SriovNetworkNodeState{*node1, *node2})
+	allocator.assign(node1, npl)
+	allocator.assign(node2, npl)
+
+	expNode1 := []v1.VfMac{{VfID: 0, Mac: "02:00:00:00:00:02"}, {VfID: 1, Mac: "02:00:00:00:00:03"}}
+	if !cmp.Equal(node1.Spec.Interfaces[0].VfGroups[0].VfMacs, expNode1) {
+		t.Error("unexpected node1 MACs", cmp.Diff(node1.Spec.Interfaces[0].VfGroups[0].VfMacs, expNode1))
+	}
+	expNode2 := []v1.VfMac{{VfID: 1, Mac: "02:00:00:00:00:01"}}
+	if !cmp.Equal(node2.Spec.Interfaces[0].VfGroups[0].VfMacs, expNode2) {
+		t.Error("unexpected node2 MACs", cmp.Diff(node2.Spec.Interfaces[0].VfGroups[0].VfMacs, expNode2))
+	}
+	if errs := allocator.policyErrors("pool"); len(errs) != 1 || !strings.Contains(errs[0], "no free MAC address") {
+		t.Errorf("expected the pool to be exhausted, got %v", errs)
+	}
+	if node1.Spec.Interfaces[0].VfGroups[1].VfMacs != nil {
+		t.Error("expected no MAC for the policy without vfMacAllocation")
+	}
+	hashMac, _ := v1.HashVfMac("02:5a", "node1", "0000:86:00.1", 0)
+	if node1.Spec.Interfaces[1].VfGroups[0].MacForVf(0) != hashMac {
+		t.Errorf("unexpected hash MAC %s", node1.Spec.Interfaces[1].VfGroups[0].MacForVf(0))
+	}
+	if errs := allocator.policyErrors("hash"); len(errs) != 0 {
+		t.Errorf("unexpected hash errors %v", errs)
+	}
+
+	// a MAC address assigned to another VF in the cluster is a collision
+	node3 := nodeState("node3", nil)
+	node3.Spec.Interfaces[0].VfGroups[0].VfMacs = []v1.VfMac{{VfID: 0, Mac: hashMac}}
+	allocator = newVfMacAllocator([]sriovnetworkv1.SriovNetworkNodeState{*node3})
+	allocator.assign(node1, npl)
+	if errs := allocator.policyErrors("hash"); len(errs) != 1 || !strings.Contains(errs[0], "collides with VF node3/0000:86:00.0/0") {
+		t.Errorf("expected a MAC collision, got %v", errs)
+	}
+	if node1.Spec.Interfaces[1].VfGroups[0].VfMacs != nil {
+		t.Error("expected no MAC for the colliding VF")
+	}
+
+	status := renderPolicyStatus(&npl.Items[1], &corev1.NodeList{}, nil, allocator.policyErrors("hash"))
+	degraded := meta.FindStatusCondition(status.Conditions, sriovnetworkv1.ConditionDegraded)
+	if degraded == nil || degraded.Status != metav1.ConditionTrue || degraded.Reason != sriovnetworkv1.PolicyReasonMacAllocationFailed {
+		t.Errorf("unexpected Degraded condition: %+v", degraded)
+	}
+}
+
 var _ = Describe("SriovnetworkNodePolicy controller", Ordered, func() {
 	var cancel context.CancelFunc
 	var ctx context.Context
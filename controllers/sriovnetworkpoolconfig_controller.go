@@ -5,15 +5,26 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"strings"
+	"time"
 
 	mcfgv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
@@ -75,6 +86,16 @@ func (r *SriovNetworkPoolConfigReconciler) Reconcile(ctx context.Context, req ct
 
 	// we don't need a finalizer for pools that doesn't use the ovs hardware offload feature
 	if instance.Spec.OvsHardwareOffloadConfig.Name == "" {
+		if !instance.ObjectMeta.DeletionTimestamp.IsZero() {
+			return ctrl.Result{}, nil
+		}
+		if err = r.syncPoolConfigStatus(ctx, instance); err != nil {
+			return reconcile.Result{}, err
+		}
+		// keep the next maintenance window of the status up to date
+		if len(instance.Spec.MaintenanceWindows) > 0 {
+			return ctrl.Result{RequeueAfter: constants.ResyncPeriod}, nil
+		}
 		return ctrl.Result{}, nil
 	}
 
@@ -127,11 +148,191 @@ func (r *SriovNetworkPoolConfigReconciler) Reconcile(ctx context.Context, req ct
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *SriovNetworkPoolConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// pool membership depends on the node labels
+	nodeLabelsChanged := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+		},
+	}
+	// the drain progress is tracked with annotations on the node state
+	nodeStateDrainChanged := predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldAnno, newAnno := e.ObjectOld.GetAnnotations(), e.ObjectNew.GetAnnotations()
+			return oldAnno[constants.NodeStateDrainAnnotation] != newAnno[constants.NodeStateDrainAnnotation] ||
+				oldAnno[constants.NodeStateDrainAnnotationCurrent] != newAnno[constants.NodeStateDrainAnnotationCurrent]
+		},
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&sriovnetworkv1.SriovNetworkPoolConfig{}).
+		For(&sriovnetworkv1.SriovNetworkPoolConfig{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(r.enqueueAllPools), builder.WithPredicates(nodeLabelsChanged)).
+		Watches(&sriovnetworkv1.SriovNetworkNodeState{}, handler.EnqueueRequestsFromMapFunc(r.enqueueAllPools), builder.WithPredicates(nodeStateDrainChanged)).
 		Complete(r)
 }
 
+// enqueueAllPools returns a reconcile request for every pool that is used for the drain configuration
+func (r *SriovNetworkPoolConfigReconciler) enqueueAllPools(ctx context.Context, _ client.Object) []reconcile.Request {
+	npcl := &sriovnetworkv1.SriovNetworkPoolConfigList{}
+	if err := r.List(ctx, npcl); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list sriovNetworkPoolConfig")
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, npc := range npcl.Items {
+		if npc.Spec.OvsHardwareOffloadConfig.Name != "" {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: npc.Namespace, Name: npc.Name}})
+	}
+	return requests
+}
+
+// syncPoolConfigStatus publishes the nodes selected by the pool together with their drain state
+func (r *SriovNetworkPoolConfigReconciler) syncPoolConfigStatus(ctx context.Context, instance *sriovnetworkv1.SriovNetworkPoolConfig) error {
+	logger := log.FromContext(ctx)
+
+	npcl := &sriovnetworkv1.SriovNetworkPoolConfigList{}
+	if err := r.List(ctx, npcl); err != nil {
+		logger.Error(err, "failed to list sriovNetworkPoolConfig")
+		return err
+	}
+	nodeList := &corev1.NodeList{}
+	if err := r.List(ctx, nodeList); err != nil {
+		logger.Error(err, "failed to list nodes")
+		return err
+	}
+
+	status := sriovnetworkv1.SriovNetworkPoolConfigStatus{}
+	// keep the existing conditions to preserve the transition time
+	status.Conditions = append(status.Conditions, instance.Status.Conditions...)
+	degradedCond := metav1.Condition{Type: sriovnetworkv1.ConditionDegraded, ObservedGeneration: instance.Generation,
+		Status: metav1.ConditionFalse, Reason: sriovnetworkv1.PoolReasonValid}
+
+	selectors := map[string]labels.Selector{}
+	var selectorErr error
+	for _, npc := range npcl.Items {
+		// we skip hw offload objects
+		if npc.Spec.OvsHardwareOffloadConfig.Name != "" {
+			continue
+		}
+		nodeSelector := npc.Spec.NodeSelector
+		if nodeSelector == nil {
+			nodeSelector = &metav1.LabelSelector{}
+		}
+		selector, err := metav1.LabelSelectorAsSelector(nodeSelector)
+		if err != nil {
+			logger.Error(err, "failed to create label selector from nodeSelector", "pool", npc.Name, "nodeSelector", nodeSelector)
+			if npc.Name == instance.Name {
+				selectorErr = err
+			}
+			continue
+		}
+		selectors[npc.Name] = selector
+	}
+
+	// a pool with an invalid node selector doesn't select any node, retrying won't fix it
+	selector, ok := selectors[instance.Name]
+	if !ok {
+		selector = labels.Nothing()
+	}
+	for _, node := range nodeList.Items {
+		if !selector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		status.Nodes = append(status.Nodes, node.Name)
+		for name, otherSelector := range selectors {
+			if name != instance.Name && otherSelector.Matches(labels.Set(node.Labels)) {
+				status.ConflictingNodes = append(status.ConflictingNodes, node.Name)
+				break
+			}
+		}
+
+		nodeState := &sriovnetworkv1.SriovNetworkNodeState{}
+		err := r.Get(ctx, types.NamespacedName{Namespace: vars.Namespace, Name: node.Name}, nodeState)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		switch nodeState.GetAnnotations()[constants.NodeStateDrainAnnotationCurrent] {
+		case constants.Draining:
+			status.DrainingNodes = append(status.DrainingNodes, node.Name)
+		case constants.DrainComplete:
+			status.DrainCompleteNodes = append(status.DrainCompleteNodes, node.Name)
+		case constants.DrainPending:
+			status.DrainPendingNodes = append(status.DrainPendingNodes, node.Name)
+		case constants.DrainApprovalPending:
+			status.DrainApprovalPendingNodes = append(status.DrainApprovalPendingNodes, node.Name)
+		case constants.DrainFailed:
+			status.DrainFailedNodes = append(status.DrainFailedNodes, node.Name)
+		default:
+			desired := nodeState.GetAnnotations()[constants.NodeStateDrainAnnotation]
+			if desired == constants.DrainRequired || desired == constants.RebootRequired {
+				status.DrainRequestedNodes = append(status.DrainRequestedNodes, node.Name)
+			}
+		}
+	}
+	status.NodeCount = len(status.Nodes)
+
+	maxUnv, err := instance.MaxUnavailable(status.NodeCount)
+	if err != nil {
+		degradedCond.Status = metav1.ConditionTrue
+		degradedCond.Reason = sriovnetworkv1.PoolReasonInvalidMaxUnavailable
+		degradedCond.Message = err.Error()
+	} else {
+		status.MaxUnavailable = &maxUnv
+	}
+	open, nextWindow, err := instance.MaintenanceWindowOpen(time.Now())
+	if err != nil {
+		degradedCond.Status = metav1.ConditionTrue
+		degradedCond.Reason = sriovnetworkv1.PoolReasonInvalidMaintenanceWindows
+		degradedCond.Message = err.Error()
+	} else if !open && !nextWindow.IsZero() {
+		status.NextMaintenanceWindow = &metav1.Time{Time: nextWindow}
+	}
+	if len(status.ConflictingNodes) > 0 {
+		degradedCond.Status = metav1.ConditionTrue
+		degradedCond.Reason = sriovnetworkv1.PoolReasonConflictingNodes
+		degradedCond.Message = fmt.Sprintf("nodes %s are selected by more than one pool", strings.Join(status.ConflictingNodes, ","))
+	}
+	if len(status.DrainFailedNodes) > 0 {
+		degradedCond.Status = metav1.ConditionTrue
+		degradedCond.Reason = sriovnetworkv1.PoolReasonDrainFailed
+		degradedCond.Message = fmt.Sprintf("the drain of nodes %s failed", strings.Join(status.DrainFailedNodes, ","))
+	}
+	if selectorErr != nil {
+		degradedCond.Status = metav1.ConditionTrue
+		degradedCond.Reason = sriovnetworkv1.PoolReasonInvalidNodeSelector
+		degradedCond.Message = fmt.Sprintf("invalid nodeSelector: %v", selectorErr)
+	}
+
+	progressingCond := metav1.Condition{Type: sriovnetworkv1.ConditionProgressing, ObservedGeneration: instance.Generation,
+		Status: metav1.ConditionFalse, Reason: sriovnetworkv1.PoolReasonNoDrainInProgress}
+	if inProgress := len(status.DrainingNodes) + len(status.DrainCompleteNodes); inProgress > 0 || len(status.DrainRequestedNodes) > 0 {
+		progressingCond.Status = metav1.ConditionTrue
+		progressingCond.Reason = sriovnetworkv1.PoolReasonNodesDraining
+		progressingCond.Message = fmt.Sprintf("%d nodes are draining or being configured, %d nodes wait for drain",
+			inProgress, len(status.DrainRequestedNodes))
+	} else if len(status.DrainPendingNodes) > 0 || len(status.DrainApprovalPendingNodes) > 0 {
+		progressingCond.Reason = sriovnetworkv1.PoolReasonDrainPending
+		progressingCond.Message = fmt.Sprintf("%d nodes wait for the next maintenance window, %d nodes wait for a drain approval",
+			len(status.DrainPendingNodes), len(status.DrainApprovalPendingNodes))
+	}
+	meta.SetStatusCondition(&status.Conditions, degradedCond)
+	meta.SetStatusCondition(&status.Conditions, progressingCond)
+
+	if equality.Semantic.DeepEqual(instance.Status, status) {
+		return nil
+	}
+	instance.Status = status
+	if err := r.Status().Update(ctx, instance); err != nil {
+		return fmt.Errorf("couldn't update SriovNetworkPoolConfig %s status: %v", instance.Name, err)
+	}
+	return nil
+}
+
 func (r *SriovNetworkPoolConfigReconciler) syncOvsHardwareOffloadMachineConfigs(ctx context.Context, nc *sriovnetworkv1.SriovNetworkPoolConfig, deletion bool) error {
 	logger := log.Log.WithName("syncOvsHardwareOffloadMachineConfigs")
 
@@ -2,11 +2,19 @@ package controllers
 
 import (
 	"context"
+	"reflect"
 	"sync"
+	"testing"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
@@ -135,3 +143,121 @@ var _ = Describe("SriovNetworkPoolConfig controller", Ordered, func() {
 		})
 	})
 })
+
+func TestSyncPoolConfigStatus(t *testing.T) {
+	maxUnavailable := intstr.FromString("50%")
+	pool := &sriovnetworkv1.SriovNetworkPoolConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool1", Namespace: vars.Namespace, Generation: 1},
+		Spec: sriovnetworkv1.SriovNetworkPoolConfigSpec{
+			NodeSelector:   &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "one"}},
+			MaxUnavailable: &maxUnavailable,
+		},
+	}
+	otherPool := &sriovnetworkv1.SriovNetworkPoolConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool2", Namespace: vars.Namespace, Generation: 1},
+		Spec: sriovnetworkv1.SriovNetworkPoolConfigSpec{
+			NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"zone": "b"}},
+		},
+	}
+
+	newNode := func(name string, nodeLabels map[string]string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nodeLabels}}
+	}
+	newNodeState := func(name, desired, current string) *sriovnetworkv1.SriovNetworkNodeState {
+		return &sriovnetworkv1.SriovNetworkNodeState{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: vars.Namespace,
+			Annotations: map[string]string{constants.NodeStateDrainAnnotation: desired, constants.NodeStateDrainAnnotationCurrent: current}}}
+	}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(sriovnetworkv1.AddToScheme(scheme))
+	utilruntime.Must(corev1.AddToScheme(scheme))
+	reconciler := &SriovNetworkPoolConfigReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithStatusSubresource(&sriovnetworkv1.SriovNetworkPoolConfig{}).
+			WithObjects(pool, otherPool,
+				newNode("node1", map[string]string{"pool": "one", "zone": "a"}),
+				newNode("node2", map[string]string{"pool": "one", "zone": "a"}),
+				newNode("node3", map[string]string{"pool": "one", "zone": "b"}),
+				newNode("node4", map[string]string{"zone": "a"}),
+				newNodeState("node1", constants.DrainRequired, constants.DrainIdle),
+				newNodeState("node2", constants.RebootRequired, constants.Draining),
+				newNodeState("node4", constants.DrainRequired, constants.DrainComplete)).
+			Build(),
+	}
+
+	if err := reconciler.syncPoolConfigStatus(context.TODO(), pool); err != nil {
+		t.Fatalf("syncPoolConfigStatus failed: %v", err)
+	}
+
+	updated := &sriovnetworkv1.SriovNetworkPoolConfig{}
+	if err := reconciler.Get(context.TODO(), types.NamespacedName{Name: pool.Name, Namespace: pool.Namespace}, updated); err != nil {
+		t.Fatalf("failed to get pool: %v", err)
+	}
+	status := updated.Status
+
+	if status.NodeCount != 3 || !reflect.DeepEqual(status.Nodes, []string{"node1", "node2", "node3"}) {
+		t.Errorf("unexpected pool nodes %d %v", status.NodeCount, status.Nodes)
+	}
+	if status.MaxUnavailable == nil {
+		t.Errorf("expected maxUnavailable to be resolved")
+	} else if *status.MaxUnavailable != 1 {
+		t.Errorf("unexpected maxUnavailable %d", *status.MaxUnavailable)
+	}
+	if !reflect.DeepEqual(status.DrainRequestedNodes, []string{"node1"}) {
+		t.Errorf("unexpected drain requested nodes %v", status.DrainRequestedNodes)
+	}
+	if !reflect.DeepEqual(status.DrainingNodes, []string{"node2"}) {
+		t.Errorf("unexpected draining nodes %v", status.DrainingNodes)
+	}
+	if len(status.DrainCompleteNodes) != 0 {
+		t.Errorf("unexpected drain complete nodes %v", status.DrainCompleteNodes)
+	}
+	if !reflect.DeepEqual(status.ConflictingNodes, []string{"node3"}) {
+		t.Errorf("unexpected conflicting nodes %v", status.ConflictingNodes)
+	}
+
+	degraded := meta.FindStatusCondition(status.Conditions, sriovnetworkv1.ConditionDegraded)
+	if degraded == nil || degraded.Status != metav1.ConditionTrue || degraded.Reason != sriovnetworkv1.PoolReasonConflictingNodes {
+		t.Errorf("unexpected degraded condition %v", degraded)
+	}
+	progressing := meta.FindStatusCondition(status.Conditions, sriovnetworkv1.ConditionProgressing)
+	if progressing == nil || progressing.Status != metav1.ConditionTrue || progressing.Reason != sriovnetworkv1.PoolReasonNodesDraining {
+		t.Errorf("unexpected progressing condition %v", progressing)
+	}
+}
+
+func TestSyncPoolConfigStatusInvalidNodeSelector(t *testing.T) {
+	pool := &sriovnetworkv1.SriovNetworkPoolConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool1", Namespace: vars.Namespace, Generation: 1},
+		Spec: sriovnetworkv1.SriovNetworkPoolConfigSpec{
+			NodeSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "pool", Operator: "Matches", Values: []string{"one"}}}},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(sriovnetworkv1.AddToScheme(scheme))
+	utilruntime.Must(corev1.AddToScheme(scheme))
+	reconciler := &SriovNetworkPoolConfigReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).
+			WithStatusSubresource(&sriovnetworkv1.SriovNetworkPoolConfig{}).
+			WithObjects(pool, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{"pool": "one"}}}).
+			Build(),
+	}
+
+	if err := reconciler.syncPoolConfigStatus(context.TODO(), pool); err != nil {
+		t.Fatalf("syncPoolConfigStatus failed: %v", err)
+	}
+
+	updated := &sriovnetworkv1.SriovNetworkPoolConfig{}
+	if err := reconciler.Get(context.TODO(), types.NamespacedName{Name: pool.Name, Namespace: pool.Namespace}, updated); err != nil {
+		t.Fatalf("failed to get pool: %v", err)
+	}
+	if updated.Status.NodeCount != 0 {
+		t.Errorf("unexpected pool nodes %v", updated.Status.Nodes)
+	}
+	degraded := meta.FindStatusCondition(updated.Status.Conditions, sriovnetworkv1.ConditionDegraded)
+	if degraded == nil || degraded.Status != metav1.ConditionTrue || degraded.Reason != sriovnetworkv1.PoolReasonInvalidNodeSelector {
+		t.Errorf("unexpected degraded condition %v", degraded)
+	}
+}
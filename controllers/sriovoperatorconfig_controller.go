@@ -18,15 +18,20 @@ package controllers
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"os"
 	"sort"
 	"strings"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -45,6 +50,7 @@ import (
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/apply"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/certs"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/featuregate"
 	snolog "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/log"
@@ -148,6 +154,10 @@ func (r *SriovOperatorConfigReconciler) Reconcile(ctx context.Context, req ctrl.
 		return reconcile.Result{}, err
 	}
 
+	if err = r.syncConfigDaemonMetrics(ctx, defaultConfig); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	// For Openshift we need to create the systemd files using a machine config
 	if vars.ClusterType == consts.ClusterTypeOpenshift {
 		// TODO: add support for hypershift as today there is no MCO on hypershift clusters
@@ -160,6 +170,10 @@ func (r *SriovOperatorConfigReconciler) Reconcile(ctx context.Context, req ctrl.
 		}
 	}
 
+	if err = r.syncOperatorConfigStatus(ctx, defaultConfig); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	logger.Info("Reconcile SriovOperatorConfig completed successfully")
 	return reconcile.Result{RequeueAfter: consts.ResyncPeriod}, nil
 }
@@ -181,9 +195,201 @@ func (r *SriovOperatorConfigReconciler) SetupWithManager(mgr ctrl.Manager) error
 		For(&sriovnetworkv1.SriovOperatorConfig{}, ctrl_builder.WithPredicates(defaultConfigPredicate())).
 		Owns(&appsv1.DaemonSet{}).
 		Owns(&corev1.ConfigMap{}).
+		Owns(&corev1.Secret{}).
 		Complete(r)
 }
 
+// knownFeatureGates lists the feature gates reported in the SriovOperatorConfig status
+var knownFeatureGates = []string{
+	consts.ParallelNicConfigFeatureGate,
+	consts.ResourceInjectorMatchConditionFeatureGate,
+	consts.MetricsExporterFeatureGate,
+	consts.ManageSoftwareBridgesFeatureGate,
+	consts.MellanoxFirmwareResetFeatureGate,
+	consts.ConfigDaemonMetricsFeatureGate,
+	consts.SkipUnusedVfsDrainFeatureGate,
+}
+
+// syncOperatorConfigStatus reports the rollout state of the components deployed by the operator
+func (r *SriovOperatorConfigReconciler) syncOperatorConfigStatus(ctx context.Context, dc *sriovnetworkv1.SriovOperatorConfig) error {
+	logger := log.Log.WithName("syncOperatorConfigStatus")
+
+	components := []struct {
+		name      string
+		dsName    string
+		isEnabled bool
+	}{
+		{sriovnetworkv1.ComponentConfigDaemon, consts.ConfigDaemonDaemonSetName, true},
+		{sriovnetworkv1.ComponentDevicePlugin, consts.DevicePluginDaemonSetName, true},
+		{sriovnetworkv1.ComponentMetricsExporter, consts.MetricsExporterDaemonSetName, r.FeatureGate.IsEnabled(consts.MetricsExporterFeatureGate)},
+		{sriovnetworkv1.ComponentInjector, consts.InjectorDaemonSetName, dc.Spec.EnableInjector},
+		{sriovnetworkv1.ComponentOperatorWebhook, consts.OperatorWebhookDaemonSetName, dc.Spec.EnableOperatorWebhook},
+	}
+
+	status := sriovnetworkv1.SriovOperatorConfigStatus{
+		ObservedGeneration: dc.Generation,
+		FeatureGates:       map[string]bool{},
+		DisabledPlugins:    dc.Spec.DisablePlugins.ToStringSlice(),
+	}
+	// keep the existing conditions to preserve the transition time
+	status.Conditions = append(status.Conditions, dc.Status.Conditions...)
+	for _, fg := range knownFeatureGates {
+		status.FeatureGates[fg] = r.FeatureGate.IsEnabled(fg)
+	}
+	if len(status.DisabledPlugins) == 0 {
+		status.DisabledPlugins = nil
+	}
+
+	for _, c := range components {
+		var ds *appsv1.DaemonSet
+		if c.isEnabled {
+			ds = &appsv1.DaemonSet{}
+			err := r.Get(ctx, types.NamespacedName{Namespace: vars.Namespace, Name: c.dsName}, ds)
+			if err != nil {
+				if !apierrors.IsNotFound(err) {
+					logger.Error(err, "Failed to get DaemonSet", "name", c.dsName)
+					return err
+				}
+				ds = nil
+			}
+		}
+		var previous []metav1.Condition
+		for _, p := range dc.Status.Components {
+			if p.Name == c.name {
+				previous = p.Conditions
+			}
+		}
+		status.Components = append(status.Components, renderComponentStatus(c.name, c.isEnabled, ds, previous))
+	}
+	status.Injector = componentSummary(status.Components, sriovnetworkv1.ComponentInjector)
+	status.OperatorWebhook = componentSummary(status.Components, sriovnetworkv1.ComponentOperatorWebhook)
+	setOperatorConfigConditions(&status, dc.Generation)
+
+	if equality.Semantic.DeepEqual(dc.Status, status) {
+		return nil
+	}
+	dc.Status = status
+	if err := r.Status().Update(ctx, dc); err != nil {
+		logger.Error(err, "Failed to update SriovOperatorConfig status")
+		return err
+	}
+	return nil
+}
+
+// renderComponentStatus computes the status of a component from its DaemonSet,
+// ds is nil when the component is disabled or the DaemonSet doesn't exist
+func renderComponentStatus(name string, enabled bool, ds *appsv1.DaemonSet, conditions []metav1.Condition) sriovnetworkv1.ComponentStatus {
+	cs := sriovnetworkv1.ComponentStatus{Name: name, Enabled: enabled}
+	cs.Conditions = append(cs.Conditions, conditions...)
+
+	setConditions := func(ready, progressing, degraded bool, reason, message string) {
+		toStatus := func(b bool) metav1.ConditionStatus {
+			if b {
+				return metav1.ConditionTrue
+			}
+			return metav1.ConditionFalse
+		}
+		meta.SetStatusCondition(&cs.Conditions, metav1.Condition{Type: sriovnetworkv1.ConditionReady,
+			Status: toStatus(ready), Reason: reason, Message: message})
+		meta.SetStatusCondition(&cs.Conditions, metav1.Condition{Type: sriovnetworkv1.ConditionProgressing,
+			Status: toStatus(progressing), Reason: reason, Message: message})
+		meta.SetStatusCondition(&cs.Conditions, metav1.Condition{Type: sriovnetworkv1.ConditionDegraded,
+			Status: toStatus(degraded), Reason: reason, Message: message})
+	}
+
+	switch {
+	case !enabled:
+		setConditions(false, false, false, sriovnetworkv1.ComponentReasonDisabled, fmt.Sprintf("%s is disabled", name))
+		return cs
+	case ds == nil:
+		setConditions(false, true, false, sriovnetworkv1.ComponentReasonNotFound, fmt.Sprintf("DaemonSet for %s doesn't exist yet", name))
+		return cs
+	}
+
+	cs.DesiredNumberScheduled = ds.Status.DesiredNumberScheduled
+	cs.NumberReady = ds.Status.NumberReady
+	cs.UpdatedNumberScheduled = ds.Status.UpdatedNumberScheduled
+	message := fmt.Sprintf("%d/%d pods ready, %d/%d pods updated",
+		cs.NumberReady, cs.DesiredNumberScheduled, cs.UpdatedNumberScheduled, cs.DesiredNumberScheduled)
+
+	rollingOut := ds.Status.ObservedGeneration < ds.Generation || cs.UpdatedNumberScheduled < cs.DesiredNumberScheduled
+	notReady := cs.NumberReady < cs.DesiredNumberScheduled
+	switch {
+	case rollingOut:
+		setConditions(false, true, false, sriovnetworkv1.ComponentReasonRollingOut, message)
+	case notReady:
+		// all the pods run the latest version but some of them don't become ready, e.g. they are crash-looping
+		setConditions(false, false, true, sriovnetworkv1.ComponentReasonPodsNotReady, message)
+	default:
+		setConditions(true, false, false, sriovnetworkv1.ComponentReasonRolloutComplete, message)
+	}
+	return cs
+}
+
+// componentSummary returns a short description of the component state
+func componentSummary(components []sriovnetworkv1.ComponentStatus, name string) string {
+	for _, cs := range components {
+		if cs.Name != name {
+			continue
+		}
+		switch {
+		case !cs.Enabled:
+			return sriovnetworkv1.ComponentReasonDisabled
+		case meta.IsStatusConditionTrue(cs.Conditions, sriovnetworkv1.ConditionReady):
+			return sriovnetworkv1.ConditionReady
+		case meta.IsStatusConditionTrue(cs.Conditions, sriovnetworkv1.ConditionDegraded):
+			return sriovnetworkv1.ConditionDegraded
+		default:
+			return sriovnetworkv1.ConditionProgressing
+		}
+	}
+	return ""
+}
+
+// setOperatorConfigConditions aggregates the conditions of the enabled components
+func setOperatorConfigConditions(status *sriovnetworkv1.SriovOperatorConfigStatus, generation int64) {
+	notReady, progressing, degraded := []string{}, []string{}, []string{}
+	for _, cs := range status.Components {
+		if !cs.Enabled {
+			continue
+		}
+		if !meta.IsStatusConditionTrue(cs.Conditions, sriovnetworkv1.ConditionReady) {
+			notReady = append(notReady, cs.Name)
+		}
+		if meta.IsStatusConditionTrue(cs.Conditions, sriovnetworkv1.ConditionProgressing) {
+			progressing = append(progressing, cs.Name)
+		}
+		if meta.IsStatusConditionTrue(cs.Conditions, sriovnetworkv1.ConditionDegraded) {
+			degraded = append(degraded, cs.Name)
+		}
+	}
+
+	readyCond := metav1.Condition{Type: sriovnetworkv1.ConditionReady, ObservedGeneration: generation,
+		Status: metav1.ConditionTrue, Reason: sriovnetworkv1.ComponentReasonAllReady}
+	if len(notReady) > 0 {
+		readyCond.Status = metav1.ConditionFalse
+		readyCond.Reason = sriovnetworkv1.ComponentReasonNotReady
+		readyCond.Message = fmt.Sprintf("components not ready: %s", strings.Join(notReady, ","))
+	}
+	progressingCond := metav1.Condition{Type: sriovnetworkv1.ConditionProgressing, ObservedGeneration: generation,
+		Status: metav1.ConditionFalse, Reason: sriovnetworkv1.ComponentReasonRolloutComplete}
+	if len(progressing) > 0 {
+		progressingCond.Status = metav1.ConditionTrue
+		progressingCond.Reason = sriovnetworkv1.ComponentReasonProgressing
+		progressingCond.Message = fmt.Sprintf("components rolling out: %s", strings.Join(progressing, ","))
+	}
+	degradedCond := metav1.Condition{Type: sriovnetworkv1.ConditionDegraded, ObservedGeneration: generation,
+		Status: metav1.ConditionFalse, Reason: sriovnetworkv1.ComponentReasonNoFailures}
+	if len(degraded) > 0 {
+		degradedCond.Status = metav1.ConditionTrue
+		degradedCond.Reason = sriovnetworkv1.ComponentReasonDegraded
+		degradedCond.Message = fmt.Sprintf("components degraded: %s", strings.Join(degraded, ","))
+	}
+	meta.SetStatusCondition(&status.Conditions, readyCond)
+	meta.SetStatusCondition(&status.Conditions, progressingCond)
+	meta.SetStatusCondition(&status.Conditions, degradedCond)
+}
+
 func (r *SriovOperatorConfigReconciler) syncConfigDaemonSet(ctx context.Context, dc *sriovnetworkv1.SriovOperatorConfig) error {
 	logger := log.Log.WithName("syncConfigDaemonset")
 	logger.V(1).Info("Start to sync config daemonset")
@@ -206,6 +412,10 @@ func (r *SriovOperatorConfigReconciler) syncConfigDaemonSet(ctx context.Context,
 	}
 	data.Data["ParallelNicConfig"] = r.FeatureGate.IsEnabled(consts.ParallelNicConfigFeatureGate)
 	data.Data["ManageSoftwareBridges"] = r.FeatureGate.IsEnabled(consts.ManageSoftwareBridgesFeatureGate)
+	data.Data["MetricsEnabled"] = r.FeatureGate.IsEnabled(consts.ConfigDaemonMetricsFeatureGate)
+	data.Data["MetricsPort"] = os.Getenv("CONFIG_DAEMON_METRICS_PORT")
+	data.Data["MetricsSecretName"] = os.Getenv("CONFIG_DAEMON_METRICS_SECRET_NAME")
+	data.Data["MetricsKubeRbacProxyImage"] = os.Getenv("METRICS_EXPORTER_KUBE_RBAC_PROXY_IMAGE")
 
 	envCniBinPath := os.Getenv("SRIOV_CNI_BIN_PATH")
 	if envCniBinPath == "" {
@@ -292,10 +502,66 @@ func (r *SriovOperatorConfigReconciler) syncMetricsExporter(ctx context.Context,
 	return nil
 }
 
+// syncConfigDaemonMetrics deploys the service and the prometheus objects to scrape the config-daemon metrics
+func (r *SriovOperatorConfigReconciler) syncConfigDaemonMetrics(ctx context.Context, dc *sriovnetworkv1.SriovOperatorConfig) error {
+	logger := log.Log.WithName("syncConfigDaemonMetrics")
+	logger.V(1).Info("Start to sync config daemon metrics")
+
+	data := render.MakeRenderData()
+	data.Data["Namespace"] = vars.Namespace
+	data.Data["MetricsPort"] = os.Getenv("CONFIG_DAEMON_METRICS_PORT")
+	data.Data["MetricsSecretName"] = os.Getenv("CONFIG_DAEMON_METRICS_SECRET_NAME")
+	data.Data["IsOpenshift"] = r.PlatformHelper.IsOpenshiftCluster()
+
+	data.Data["IsPrometheusOperatorInstalled"] = strings.ToLower(os.Getenv("METRICS_EXPORTER_PROMETHEUS_OPERATOR_ENABLED")) == trueString
+	data.Data["PrometheusOperatorDeployRules"] = strings.ToLower(os.Getenv("METRICS_EXPORTER_PROMETHEUS_DEPLOY_RULES")) == trueString
+	data.Data["PrometheusOperatorServiceAccount"] = os.Getenv("METRICS_EXPORTER_PROMETHEUS_OPERATOR_SERVICE_ACCOUNT")
+	data.Data["PrometheusOperatorNamespace"] = os.Getenv("METRICS_EXPORTER_PROMETHEUS_OPERATOR_NAMESPACE")
+
+	objs, err := render.RenderDir(consts.ConfigDaemonMetricsPath, &data)
+	if err != nil {
+		logger.Error(err, "Fail to render config daemon metrics manifests")
+		return err
+	}
+
+	if r.FeatureGate.IsEnabled(consts.ConfigDaemonMetricsFeatureGate) {
+		for _, obj := range objs {
+			err = r.syncK8sResource(ctx, dc, obj)
+			if err != nil {
+				logger.Error(err, "Couldn't sync config daemon metrics objects")
+				return err
+			}
+		}
+		if !r.PlatformHelper.IsOpenshiftCluster() {
+			err = r.syncConfigDaemonMetricsCert(ctx, dc, os.Getenv("CONFIG_DAEMON_METRICS_SECRET_NAME"),
+				"sriov-network-config-daemon-metrics-service", time.Now())
+			if err != nil {
+				logger.Error(err, "Couldn't sync config daemon metrics certificate")
+				return err
+			}
+		}
+		return nil
+	}
+
+	return r.deleteK8sResources(ctx, objs)
+}
+
 func (r *SriovOperatorConfigReconciler) syncWebhookObjs(ctx context.Context, dc *sriovnetworkv1.SriovOperatorConfig) error {
 	logger := log.Log.WithName("syncWebhookObjs")
 	logger.V(1).Info("Start to sync webhook objects")
 
+	var ca *certs.KeyPair
+	var caBundle []byte
+	now := time.Now()
+	if selfManagedWebhookCertificates() && (dc.Spec.EnableInjector || dc.Spec.EnableOperatorWebhook) {
+		var err error
+		ca, caBundle, err = r.syncWebhookCA(ctx, dc, now)
+		if err != nil {
+			logger.Error(err, "Fail to sync the webhook CA")
+			return err
+		}
+	}
+
 	for name, path := range webhooks {
 		// Render Webhook manifests
 		data := render.MakeRenderData()
@@ -313,6 +579,21 @@ func (r *SriovOperatorConfigReconciler) syncWebhookObjs(ctx context.Context, dc
 		data.Data["InjectorWebhookSecretName"] = os.Getenv("ADMISSION_CONTROLLERS_CERTIFICATES_INJECTOR_SECRET_NAME")
 		data.Data["InjectorWebhookCA"] = os.Getenv("ADMISSION_CONTROLLERS_CERTIFICATES_INJECTOR_CA_CRT")
 
+		if ca != nil {
+			enabled, secretName := dc.Spec.EnableOperatorWebhook, data.Data["OperatorWebhookSecretName"].(string)
+			if path == consts.InjectorWebHookPath {
+				enabled, secretName = dc.Spec.EnableInjector, data.Data["InjectorWebhookSecretName"].(string)
+			}
+			if enabled {
+				if err := r.syncWebhookServingCert(ctx, dc, ca, caBundle, secretName, webhookServices[path], now); err != nil {
+					logger.Error(err, "Fail to sync the webhook serving certificate", "secret", secretName)
+					return err
+				}
+			}
+			data.Data["OperatorWebhookCA"] = base64.StdEncoding.EncodeToString(caBundle)
+			data.Data["InjectorWebhookCA"] = base64.StdEncoding.EncodeToString(caBundle)
+		}
+
 		data.Data["ExternalControlPlane"] = false
 		if r.PlatformHelper.IsOpenshiftCluster() {
 			external := r.PlatformHelper.IsHypershift()
@@ -5,6 +5,7 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"testing"
 	"time"
 
 	admv1 "k8s.io/api/admissionregistration/v1"
@@ -12,6 +13,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -611,3 +613,71 @@ func updateConfigDaemonNodeSelector(newValue map[string]string) func() {
 
 	return ret
 }
+
+func TestRenderComponentStatus(t *testing.T) {
+	newDaemonSet := func(generation, observedGeneration int64, desired, ready, updated int32) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Generation: generation},
+			Status: appsv1.DaemonSetStatus{
+				ObservedGeneration:     observedGeneration,
+				DesiredNumberScheduled: desired,
+				NumberReady:            ready,
+				UpdatedNumberScheduled: updated,
+			},
+		}
+	}
+
+	table := []struct {
+		tname       string
+		enabled     bool
+		ds          *appsv1.DaemonSet
+		ready       bool
+		progressing bool
+		degraded    bool
+		reason      string
+	}{
+		{"disabled", false, nil, false, false, false, sriovnetworkv1.ComponentReasonDisabled},
+		{"missing daemonset", true, nil, false, true, false, sriovnetworkv1.ComponentReasonNotFound},
+		{"rollout complete", true, newDaemonSet(2, 2, 3, 3, 3), true, false, false, sriovnetworkv1.ComponentReasonRolloutComplete},
+		{"generation not observed", true, newDaemonSet(3, 2, 3, 3, 3), false, true, false, sriovnetworkv1.ComponentReasonRollingOut},
+		{"pods being updated", true, newDaemonSet(2, 2, 3, 2, 1), false, true, false, sriovnetworkv1.ComponentReasonRollingOut},
+		{"pods crash-looping", true, newDaemonSet(2, 2, 3, 1, 3), false, false, true, sriovnetworkv1.ComponentReasonPodsNotReady},
+	}
+
+	for _, tc := range table {
+		t.Run(tc.tname, func(t *testing.T) {
+			cs := renderComponentStatus(sriovnetworkv1.ComponentConfigDaemon, tc.enabled, tc.ds, nil)
+			for condType, expected := range map[string]bool{
+				sriovnetworkv1.ConditionReady:       tc.ready,
+				sriovnetworkv1.ConditionProgressing: tc.progressing,
+				sriovnetworkv1.ConditionDegraded:    tc.degraded,
+			} {
+				cond := meta.FindStatusCondition(cs.Conditions, condType)
+				if cond == nil {
+					t.Fatalf("condition %s not found", condType)
+				}
+				if (cond.Status == metav1.ConditionTrue) != expected || cond.Reason != tc.reason {
+					t.Errorf("unexpected %s condition %v", condType, cond)
+				}
+			}
+		})
+	}
+
+	status := &sriovnetworkv1.SriovOperatorConfigStatus{Components: []sriovnetworkv1.ComponentStatus{
+		renderComponentStatus(sriovnetworkv1.ComponentConfigDaemon, true, newDaemonSet(1, 1, 2, 1, 2), nil),
+		renderComponentStatus(sriovnetworkv1.ComponentDevicePlugin, true, newDaemonSet(1, 1, 2, 2, 2), nil),
+		renderComponentStatus(sriovnetworkv1.ComponentInjector, false, nil, nil),
+	}}
+	setOperatorConfigConditions(status, 4)
+	if !meta.IsStatusConditionFalse(status.Conditions, sriovnetworkv1.ConditionReady) {
+		t.Errorf("expected the config not to be ready")
+	}
+	degraded := meta.FindStatusCondition(status.Conditions, sriovnetworkv1.ConditionDegraded)
+	if degraded == nil || degraded.Status != metav1.ConditionTrue || degraded.ObservedGeneration != 4 ||
+		!strings.Contains(degraded.Message, sriovnetworkv1.ComponentConfigDaemon) {
+		t.Errorf("unexpected degraded condition %v", degraded)
+	}
+	if componentSummary(status.Components, sriovnetworkv1.ComponentInjector) != sriovnetworkv1.ComponentReasonDisabled {
+		t.Errorf("expected the injector to be reported as disabled")
+	}
+}
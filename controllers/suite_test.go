@@ -136,6 +136,10 @@ var _ = BeforeSuite(func() {
 	Expect(err).NotTo(HaveOccurred())
 	err = os.Setenv("METRICS_EXPORTER_PORT", "9110")
 	Expect(err).NotTo(HaveOccurred())
+	err = os.Setenv("CONFIG_DAEMON_METRICS_SECRET_NAME", "config-daemon-metrics-cert")
+	Expect(err).NotTo(HaveOccurred())
+	err = os.Setenv("CONFIG_DAEMON_METRICS_PORT", "9112")
+	Expect(err).NotTo(HaveOccurred())
 	err = os.Setenv("METRICS_EXPORTER_KUBE_RBAC_PROXY_IMAGE", "mock-image")
 	Expect(err).NotTo(HaveOccurred())
 	err = os.Setenv("METRICS_EXPORTER_PROMETHEUS_OPERATOR_SERVICE_ACCOUNT", "k8s-prometheus")
@@ -0,0 +1,144 @@
+package controllers
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+)
+
+// vfMacAllocator assigns the admin MAC addresses of the VFs selected by policies with a
+// vfMacAllocation and makes sure that the same MAC address is not assigned twice in the cluster
+type vfMacAllocator struct {
+	// owner of each MAC address, the owner of a MAC is identified by "<node>/<pf pci address>/<vf index>"
+	owners map[string]string
+	// MAC address currently assigned to each owner in the SriovNetworkNodeState specs
+	assigned map[string]string
+	// allocation errors per policy
+	errors map[string][]string
+}
+
+// newVfMacAllocator creates an allocator which keeps the MAC addresses already assigned in the node states
+func newVfMacAllocator(nodeStates []sriovnetworkv1.SriovNetworkNodeState) *vfMacAllocator {
+	a := &vfMacAllocator{
+		owners:   map[string]string{},
+		assigned: map[string]string{},
+		errors:   map[string][]string{},
+	}
+	for _, ns := range nodeStates {
+		for _, iface := range ns.Spec.Interfaces {
+			for _, group := range iface.VfGroups {
+				for _, vfMac := range group.VfMacs {
+					owner := vfMacOwner(ns.Name, iface.PciAddress, vfMac.VfID)
+					mac := strings.ToLower(vfMac.Mac)
+					a.assigned[owner] = mac
+					if _, taken := a.owners[mac]; !taken {
+						a.owners[mac] = owner
+					}
+				}
+			}
+		}
+	}
+	return a
+}
+
+func vfMacOwner(nodeName, pciAddress string, vfID int) string {
+	return fmt.Sprintf("%s/%s/%d", nodeName, pciAddress, vfID)
+}
+
+// assign fills the VfMacs of the VF groups in the node state spec
+// for the policies which define a vfMacAllocation
+func (a *vfMacAllocator) assign(ns *sriovnetworkv1.SriovNetworkNodeState, npl *sriovnetworkv1.SriovNetworkNodePolicyList) {
+	policies := make(map[string]*sriovnetworkv1.SriovNetworkNodePolicy, len(npl.Items))
+	for i := range npl.Items {
+		policies[npl.Items[i].Name] = &npl.Items[i]
+	}
+	for i := range ns.Spec.Interfaces {
+		iface := &ns.Spec.Interfaces[i]
+		for j := range iface.VfGroups {
+			group := &iface.VfGroups[j]
+			group.VfMacs = nil
+			p, ok := policies[group.PolicyName]
+			if !ok || p.Spec.VfMacAllocation == nil {
+				continue
+			}
+			for _, vfID := range group.VfIDs() {
+				mac, err := a.allocate(p.Spec.VfMacAllocation, ns.Name, iface.PciAddress, vfID)
+				if err != nil {
+					a.errors[p.Name] = append(a.errors[p.Name], err.Error())
+					continue
+				}
+				group.VfMacs = append(group.VfMacs, sriovnetworkv1.VfMac{VfID: vfID, Mac: mac})
+			}
+		}
+	}
+}
+
+func (a *vfMacAllocator) allocate(allocation *sriovnetworkv1.VfMacAllocation, nodeName, pciAddress string, vfID int) (string, error) {
+	owner := vfMacOwner(nodeName, pciAddress, vfID)
+	if allocation.Prefix != "" {
+		mac, err := sriovnetworkv1.HashVfMac(allocation.Prefix, nodeName, pciAddress, vfID)
+		if err != nil {
+			return "", err
+		}
+		if !a.take(owner, mac) {
+			return "", fmt.Errorf("MAC address %s of VF %s collides with VF %s", mac, owner, a.owners[mac])
+		}
+		return mac, nil
+	}
+
+	ranges, err := sriovnetworkv1.ParseMacPool(allocation.Pool)
+	if err != nil {
+		return "", err
+	}
+	// keep the current MAC address if it still belongs to the pool
+	if current, ok := a.assigned[owner]; ok && a.owners[current] == owner {
+		if hwAddr, err := net.ParseMAC(current); err == nil && macInRanges(sriovnetworkv1.MacToUint64(hwAddr), ranges) {
+			return current, nil
+		}
+	}
+	for _, rng := range ranges {
+		for v := rng.Start; v <= rng.End; v++ {
+			mac := sriovnetworkv1.Uint64ToMac(v).String()
+			if a.take(owner, mac) {
+				return mac, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no free MAC address left in the pool for VF %s", owner)
+}
+
+// take assigns the MAC address to the owner, it returns false if the MAC address belongs to another owner
+func (a *vfMacAllocator) take(owner, mac string) bool {
+	if current, taken := a.owners[mac]; taken && current != owner {
+		return false
+	}
+	// release the previous MAC address of the owner
+	if previous, ok := a.assigned[owner]; ok && previous != mac && a.owners[previous] == owner {
+		delete(a.owners, previous)
+	}
+	a.owners[mac] = owner
+	a.assigned[owner] = mac
+	return true
+}
+
+func macInRanges(v uint64, ranges []sriovnetworkv1.MacRange) bool {
+	for _, rng := range ranges {
+		if v >= rng.Start && v <= rng.End {
+			return true
+		}
+	}
+	return false
+}
+
+// policyErrors returns the sorted allocation errors of the policy
+func (a *vfMacAllocator) policyErrors(policyName string) []string {
+	if a == nil {
+		return nil
+	}
+	errs := append([]string{}, a.errors[policyName]...)
+	sort.Strings(errs)
+	return errs
+}
@@ -0,0 +1,189 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/certs"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+const (
+	// key of the CA bundle in the serving certificate secrets
+	caCertKey = "ca.crt"
+	// key of the previous CA certificate in the CA secret, it stays in the CA bundle until it expires
+	previousCACertKey = "previous-ca.crt"
+)
+
+// webhookServices maps the webhook manifests to the service which exposes the webhook server
+var webhookServices = map[string]string{
+	consts.InjectorWebHookPath: "network-resources-injector-service",
+	consts.OperatorWebHookPath: "operator-webhook-service",
+}
+
+// selfManagedWebhookCertificates returns true if the operator issues the webhook certificates itself
+func selfManagedWebhookCertificates() bool {
+	return vars.ClusterType == consts.ClusterTypeKubernetes &&
+		strings.ToLower(os.Getenv("ADMISSION_CONTROLLERS_CERTIFICATES_SELF_MANAGED_ENABLED")) == trueString &&
+		strings.ToLower(os.Getenv("ADMISSION_CONTROLLERS_CERTIFICATES_CERT_MANAGER_ENABLED")) != trueString
+}
+
+// syncWebhookCA makes sure the CA secret holds a CA which doesn't need to be rotated yet,
+// it returns the CA and the bundle of the CA certificates the webhook configurations must trust
+func (r *SriovOperatorConfigReconciler) syncWebhookCA(ctx context.Context, dc *sriovnetworkv1.SriovOperatorConfig, now time.Time) (*certs.KeyPair, []byte, error) {
+	logger := log.Log.WithName("syncWebhookCA")
+
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: vars.Namespace, Name: consts.WebhookCASecretName}, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, nil, err
+	}
+	exists := err == nil
+
+	if exists {
+		ca, err := certs.ParseKeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+		if err == nil && !certs.NeedsRotation(ca.Cert, now) {
+			return ca, certs.Bundle(now, ca.CertPEM, secret.Data[previousCACertKey]), nil
+		}
+		if err != nil {
+			logger.Error(err, "invalid webhook CA, generating a new one")
+		} else {
+			logger.Info("rotating the webhook CA", "notAfter", ca.Cert.NotAfter)
+		}
+	}
+
+	ca, err := certs.NewCA(fmt.Sprintf("%s-webhook-ca@%d", vars.Namespace, now.Unix()), now)
+	if err != nil {
+		return nil, nil, err
+	}
+	data := map[string][]byte{
+		corev1.TLSCertKey:       ca.CertPEM,
+		corev1.TLSPrivateKeyKey: ca.KeyPEM,
+	}
+	// keep trusting the previous CA until the serving certificates it signed are replaced
+	if previous := secret.Data[corev1.TLSCertKey]; len(certs.Bundle(now, previous)) > 0 {
+		data[previousCACertKey] = previous
+	}
+	if err := r.applyWebhookSecret(ctx, dc, secret, exists, consts.WebhookCASecretName, data); err != nil {
+		return nil, nil, err
+	}
+	return ca, certs.Bundle(now, ca.CertPEM, data[previousCACertKey]), nil
+}
+
+// syncWebhookServingCert makes sure the serving certificate secret of a webhook is signed by the CA
+// and contains the CA bundle
+func (r *SriovOperatorConfigReconciler) syncWebhookServingCert(ctx context.Context, dc *sriovnetworkv1.SriovOperatorConfig,
+	ca *certs.KeyPair, caBundle []byte, secretName, serviceName string, now time.Time) error {
+	logger := log.Log.WithName("syncWebhookServingCert")
+	if secretName == "" {
+		return fmt.Errorf("no secret name configured for the certificate of service %s", serviceName)
+	}
+	dnsNames := []string{
+		serviceName,
+		fmt.Sprintf("%s.%s", serviceName, vars.Namespace),
+		fmt.Sprintf("%s.%s.svc", serviceName, vars.Namespace),
+	}
+
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: vars.Namespace, Name: secretName}, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+
+	data := map[string][]byte{
+		corev1.TLSCertKey:       secret.Data[corev1.TLSCertKey],
+		corev1.TLSPrivateKeyKey: secret.Data[corev1.TLSPrivateKeyKey],
+		caCertKey:               caBundle,
+	}
+	current, err := certs.ParseKeyPair(data[corev1.TLSCertKey], data[corev1.TLSPrivateKeyKey])
+	if err != nil || !certs.IsValidServingCert(ca.Cert, current.Cert, dnsNames, now) {
+		logger.Info("issuing a new serving certificate", "secret", secretName)
+		servingCert, err := certs.NewServingCert(ca, dnsNames, now)
+		if err != nil {
+			return err
+		}
+		data[corev1.TLSCertKey] = servingCert.CertPEM
+		data[corev1.TLSPrivateKeyKey] = servingCert.KeyPEM
+	} else if bytes.Equal(secret.Data[caCertKey], caBundle) {
+		return nil
+	}
+	return r.applyWebhookSecret(ctx, dc, secret, exists, secretName, data)
+}
+
+func (r *SriovOperatorConfigReconciler) applyWebhookSecret(ctx context.Context, dc *sriovnetworkv1.SriovOperatorConfig,
+	secret *corev1.Secret, exists bool, name string, data map[string][]byte) error {
+	if !exists {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: vars.Namespace},
+			Type:       corev1.SecretTypeTLS,
+		}
+	}
+	secret.Data = data
+	if err := controllerutil.SetControllerReference(dc, secret, r.Scheme); err != nil {
+		return err
+	}
+	if !exists {
+		return r.Create(ctx, secret)
+	}
+	return r.Update(ctx, secret)
+}
+
+// syncConfigDaemonMetricsCert issues the serving certificate of the config-daemon metrics when no one else does,
+// on OpenShift the service CA operator issues it from the annotation of the metrics service
+func (r *SriovOperatorConfigReconciler) syncConfigDaemonMetricsCert(ctx context.Context, dc *sriovnetworkv1.SriovOperatorConfig,
+	secretName, serviceName string, now time.Time) error {
+	logger := log.Log.WithName("syncConfigDaemonMetricsCert")
+	if secretName == "" {
+		return fmt.Errorf("no secret name configured for the certificate of service %s", serviceName)
+	}
+
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: vars.Namespace, Name: secretName}, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+	if exists {
+		// a secret provided by the user or by cert-manager is left alone
+		if !metav1.IsControlledBy(secret, dc) {
+			return nil
+		}
+		current, err := certs.ParseKeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+		if err == nil && !certs.NeedsRotation(current.Cert, now) {
+			return nil
+		}
+	}
+
+	logger.Info("issuing a new serving certificate", "secret", secretName)
+	ca, err := certs.NewCA(fmt.Sprintf("%s-config-daemon-metrics-ca@%d", vars.Namespace, now.Unix()), now)
+	if err != nil {
+		return err
+	}
+	servingCert, err := certs.NewServingCert(ca, []string{
+		serviceName,
+		fmt.Sprintf("%s.%s", serviceName, vars.Namespace),
+		fmt.Sprintf("%s.%s.svc", serviceName, vars.Namespace),
+	}, now)
+	if err != nil {
+		return err
+	}
+	return r.applyWebhookSecret(ctx, dc, secret, exists, secretName, map[string][]byte{
+		corev1.TLSCertKey:       servingCert.CertPEM,
+		corev1.TLSPrivateKeyKey: servingCert.KeyPEM,
+		caCertKey:               ca.CertPEM,
+	})
+}
@@ -0,0 +1,174 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/certs"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+func TestSyncWebhookCertificates(t *testing.T) {
+	ctx := context.TODO()
+	dc := &sriovnetworkv1.SriovOperatorConfig{ObjectMeta: metav1.ObjectMeta{
+		Name: consts.DefaultConfigName, Namespace: vars.Namespace, UID: "config-uid"}}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(sriovnetworkv1.AddToScheme(scheme))
+	utilruntime.Must(corev1.AddToScheme(scheme))
+	r := &SriovOperatorConfigReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(dc).Build(),
+		Scheme: scheme,
+	}
+	serviceName := webhookServices[consts.OperatorWebHookPath]
+	dnsName := serviceName + "." + vars.Namespace + ".svc"
+	getSecret := func(name string) *corev1.Secret {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: vars.Namespace, Name: name}, secret); err != nil {
+			t.Fatalf("failed to get secret %s: %v", name, err)
+		}
+		return secret
+	}
+	sync := func(now time.Time) (*certs.KeyPair, []byte) {
+		ca, caBundle, err := r.syncWebhookCA(ctx, dc, now)
+		if err != nil {
+			t.Fatalf("syncWebhookCA failed: %v", err)
+		}
+		if err := r.syncWebhookServingCert(ctx, dc, ca, caBundle, "operator-webhook-cert", serviceName, now); err != nil {
+			t.Fatalf("syncWebhookServingCert failed: %v", err)
+		}
+		return ca, caBundle
+	}
+
+	// the CA and the serving certificate are generated
+	now := time.Now()
+	ca, caBundle := sync(now)
+	if !bytes.Equal(caBundle, ca.CertPEM) {
+		t.Errorf("unexpected CA bundle %s", caBundle)
+	}
+	servingSecret := getSecret("operator-webhook-cert")
+	if len(servingSecret.OwnerReferences) != 1 || servingSecret.OwnerReferences[0].UID != dc.UID {
+		t.Errorf("unexpected owner references %v", servingSecret.OwnerReferences)
+	}
+	if !bytes.Equal(servingSecret.Data[caCertKey], ca.CertPEM) {
+		t.Errorf("unexpected %s in the serving secret", caCertKey)
+	}
+	servingCert, err := certs.ParseKeyPair(servingSecret.Data[corev1.TLSCertKey], servingSecret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil || !certs.IsValidServingCert(ca.Cert, servingCert.Cert, []string{dnsName}, now) {
+		t.Fatalf("invalid serving certificate: %v", err)
+	}
+
+	// nothing changes while the certificates are valid
+	sameCA, _ := sync(now.Add(time.Hour))
+	if !sameCA.Cert.Equal(ca.Cert) || !bytes.Equal(getSecret("operator-webhook-cert").Data[corev1.TLSCertKey], servingCert.CertPEM) {
+		t.Errorf("certificates rotated before expiry")
+	}
+
+	// the serving certificate is rotated before it expires
+	later := now.Add(certs.ServingCertValidity * 9 / 10)
+	sameCA, _ = sync(later)
+	rotated, err := certs.ParseCert(getSecret("operator-webhook-cert").Data[corev1.TLSCertKey])
+	if err != nil || rotated.Equal(servingCert.Cert) || !certs.IsValidServingCert(ca.Cert, rotated, []string{dnsName}, later) {
+		t.Errorf("serving certificate not rotated: %v", err)
+	}
+	if !sameCA.Cert.Equal(ca.Cert) {
+		t.Errorf("CA rotated before expiry")
+	}
+
+	// the CA is rotated before it expires, the previous one stays in the bundle
+	later = now.Add(certs.CAValidity * 9 / 10)
+	newCA, caBundle := sync(later)
+	if newCA.Cert.Equal(ca.Cert) {
+		t.Fatalf("CA not rotated")
+	}
+	if !bytes.Equal(caBundle, append(bytes.Clone(newCA.CertPEM), ca.CertPEM...)) {
+		t.Errorf("unexpected CA bundle after the CA rotation %s", caBundle)
+	}
+	servingSecret = getSecret("operator-webhook-cert")
+	rotated, err = certs.ParseCert(servingSecret.Data[corev1.TLSCertKey])
+	if err != nil || !certs.IsValidServingCert(newCA.Cert, rotated, []string{dnsName}, later) {
+		t.Errorf("serving certificate not signed by the new CA: %v", err)
+	}
+	if !bytes.Equal(servingSecret.Data[caCertKey], caBundle) {
+		t.Errorf("CA bundle not updated in the serving secret")
+	}
+
+	// the previous CA leaves the bundle once it expired
+	_, caBundle = sync(now.Add(certs.CAValidity + time.Hour))
+	if !bytes.Equal(caBundle, newCA.CertPEM) {
+		t.Errorf("expired CA still in the bundle")
+	}
+}
+
+func TestSyncConfigDaemonMetricsCert(t *testing.T) {
+	ctx := context.TODO()
+	dc := &sriovnetworkv1.SriovOperatorConfig{ObjectMeta: metav1.ObjectMeta{
+		Name: consts.DefaultConfigName, Namespace: vars.Namespace, UID: "config-uid"}}
+	userSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "user-metrics-cert", Namespace: vars.Namespace},
+		Data: map[string][]byte{corev1.TLSCertKey: []byte("user-cert")}}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(sriovnetworkv1.AddToScheme(scheme))
+	utilruntime.Must(corev1.AddToScheme(scheme))
+	r := &SriovOperatorConfigReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(dc, userSecret).Build(),
+		Scheme: scheme,
+	}
+	serviceName := "sriov-network-config-daemon-metrics-service"
+	dnsName := serviceName + "." + vars.Namespace + ".svc"
+	getSecret := func(name string) *corev1.Secret {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: vars.Namespace, Name: name}, secret); err != nil {
+			t.Fatalf("failed to get secret %s: %v", name, err)
+		}
+		return secret
+	}
+	sync := func(name string, now time.Time) {
+		if err := r.syncConfigDaemonMetricsCert(ctx, dc, name, serviceName, now); err != nil {
+			t.Fatalf("syncConfigDaemonMetricsCert failed: %v", err)
+		}
+	}
+
+	// the serving certificate is issued with the CA which signed it
+	now := time.Now()
+	sync("config-daemon-metrics-cert", now)
+	secret := getSecret("config-daemon-metrics-cert")
+	if len(secret.OwnerReferences) != 1 || secret.OwnerReferences[0].UID != dc.UID {
+		t.Errorf("unexpected owner references %v", secret.OwnerReferences)
+	}
+	ca, err := certs.ParseCert(secret.Data[caCertKey])
+	if err != nil {
+		t.Fatalf("invalid CA certificate: %v", err)
+	}
+	servingCert, err := certs.ParseKeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil || !certs.IsValidServingCert(ca, servingCert.Cert, []string{dnsName}, now) {
+		t.Fatalf("invalid serving certificate: %v", err)
+	}
+
+	// nothing changes while the certificate is valid
+	sync("config-daemon-metrics-cert", now.Add(time.Hour))
+	if !bytes.Equal(getSecret("config-daemon-metrics-cert").Data[corev1.TLSCertKey], servingCert.CertPEM) {
+		t.Errorf("certificate rotated before expiry")
+	}
+
+	// the certificate is rotated before it expires
+	sync("config-daemon-metrics-cert", now.Add(certs.ServingCertValidity*9/10))
+	if bytes.Equal(getSecret("config-daemon-metrics-cert").Data[corev1.TLSCertKey], servingCert.CertPEM) {
+		t.Errorf("certificate not rotated")
+	}
+
+	// a secret the operator doesn't own is left alone
+	sync("user-metrics-cert", now)
+	if string(getSecret("user-metrics-cert").Data[corev1.TLSCertKey]) != "user-cert" {
+		t.Errorf("user secret overwritten")
+	}
+}
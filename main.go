@@ -138,6 +138,9 @@ func main() {
 		os.Exit(1)
 	}
 
+	// expose the policies, node states and drain metrics next to the controller-runtime ones
+	controllers.RegisterMetrics(mgr.GetClient())
+
 	mgrGlobal, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme:  scheme,
 		Metrics: server.Options{BindAddress: "0"},
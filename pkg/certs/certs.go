@@ -0,0 +1,171 @@
+package certs
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const (
+	// CAValidity is the lifetime of the generated certificate authorities
+	CAValidity = 10 * 365 * 24 * time.Hour
+	// ServingCertValidity is the lifetime of the generated serving certificates
+	ServingCertValidity = 365 * 24 * time.Hour
+
+	// the certificates are rotated when less than 1/rotationFraction of their lifetime is left
+	rotationFraction = 5
+	// tolerate a clock skew between the operator and the API server
+	clockSkew = 5 * time.Minute
+)
+
+// KeyPair is a certificate with its private key, both in the parsed and the PEM form
+type KeyPair struct {
+	Cert    *x509.Certificate
+	Key     *ecdsa.PrivateKey
+	CertPEM []byte
+	KeyPEM  []byte
+}
+
+// NewCA generates a self-signed certificate authority
+func NewCA(commonName string, now time.Time) (*KeyPair, error) {
+	template := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             now.Add(-clockSkew),
+		NotAfter:              now.Add(CAValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	return newKeyPair(template, nil)
+}
+
+// NewServingCert generates a serving certificate for the DNS names signed by the CA
+func NewServingCert(ca *KeyPair, dnsNames []string, now time.Time) (*KeyPair, error) {
+	if len(dnsNames) == 0 {
+		return nil, fmt.Errorf("at least one DNS name is required")
+	}
+	template := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:    dnsNames,
+		NotBefore:   now.Add(-clockSkew),
+		NotAfter:    now.Add(ServingCertValidity),
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	return newKeyPair(template, ca)
+}
+
+func newKeyPair(template *x509.Certificate, parent *KeyPair) (*KeyPair, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the private key: %v", err)
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the serial number: %v", err)
+	}
+	template.SerialNumber = serial
+
+	parentCert, parentKey := template, key
+	if parent != nil {
+		parentCert, parentKey = parent.Cert, parent.Key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parentCert, &key.PublicKey, parentKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the private key: %v", err)
+	}
+	return &KeyPair{
+		Cert:    cert,
+		Key:     key,
+		CertPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		KeyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}),
+	}, nil
+}
+
+// ParseKeyPair parses a PEM encoded certificate and EC private key
+func ParseKeyPair(certPEM, keyPEM []byte) (*KeyPair, error) {
+	cert, err := ParseCert(certPEM)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode the private key PEM")
+	}
+	key, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the private key: %v", err)
+	}
+	if !key.PublicKey.Equal(cert.PublicKey) {
+		return nil, fmt.Errorf("the private key doesn't match the certificate")
+	}
+	return &KeyPair{Cert: cert, Key: key, CertPEM: certPEM, KeyPEM: keyPEM}, nil
+}
+
+// ParseCert parses the first certificate of a PEM bundle
+func ParseCert(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("failed to decode the certificate PEM")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the certificate: %v", err)
+	}
+	return cert, nil
+}
+
+// NeedsRotation returns true if less than a fifth of the lifetime of the certificate is left
+func NeedsRotation(cert *x509.Certificate, now time.Time) bool {
+	lifetime := cert.NotAfter.Sub(cert.NotBefore)
+	return now.After(cert.NotAfter.Add(-lifetime / rotationFraction))
+}
+
+// IsValidServingCert returns true if the serving certificate is signed by the CA, covers
+// all the DNS names and doesn't need to be rotated yet
+func IsValidServingCert(ca *x509.Certificate, cert *x509.Certificate, dnsNames []string, now time.Time) bool {
+	if NeedsRotation(cert, now) {
+		return false
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	for _, name := range dnsNames {
+		if _, err := cert.Verify(x509.VerifyOptions{
+			DNSName:     name,
+			Roots:       roots,
+			CurrentTime: now,
+			KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		}); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Bundle concatenates the PEM encoded certificates which are not expired yet
+func Bundle(now time.Time, certPEMs ...[]byte) []byte {
+	var bundle bytes.Buffer
+	for _, certPEM := range certPEMs {
+		cert, err := ParseCert(certPEM)
+		if err != nil || now.After(cert.NotAfter) {
+			continue
+		}
+		bundle.Write(certPEM)
+	}
+	return bundle.Bytes()
+}
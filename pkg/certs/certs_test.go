@@ -0,0 +1,74 @@
+package certs
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestServingCert(t *testing.T) {
+	g := NewGomegaWithT(t)
+	now := time.Now()
+	dnsNames := []string{"operator-webhook-service", "operator-webhook-service.sriov.svc"}
+
+	ca, err := NewCA("test-ca", now)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ca.Cert.IsCA).To(BeTrue())
+
+	servingCert, err := NewServingCert(ca, dnsNames, now)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(IsValidServingCert(ca.Cert, servingCert.Cert, dnsNames, now)).To(BeTrue())
+
+	// the key pair survives a round trip through a secret
+	parsed, err := ParseKeyPair(servingCert.CertPEM, servingCert.KeyPEM)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(parsed.Cert.Equal(servingCert.Cert)).To(BeTrue())
+	_, err = ParseKeyPair(servingCert.CertPEM, ca.KeyPEM)
+	g.Expect(err).To(HaveOccurred())
+
+	// a new DNS name requires a new certificate
+	g.Expect(IsValidServingCert(ca.Cert, servingCert.Cert, append(dnsNames, "other-service"), now)).To(BeFalse())
+
+	// a certificate signed by another CA is not valid
+	otherCA, err := NewCA("other-ca", now)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(IsValidServingCert(otherCA.Cert, servingCert.Cert, dnsNames, now)).To(BeFalse())
+
+	_, err = NewServingCert(ca, nil, now)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestNeedsRotation(t *testing.T) {
+	g := NewGomegaWithT(t)
+	now := time.Now()
+
+	ca, err := NewCA("test-ca", now)
+	g.Expect(err).NotTo(HaveOccurred())
+	servingCert, err := NewServingCert(ca, []string{"svc"}, now)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(NeedsRotation(servingCert.Cert, now)).To(BeFalse())
+	g.Expect(NeedsRotation(servingCert.Cert, now.Add(ServingCertValidity*3/4))).To(BeFalse())
+	g.Expect(NeedsRotation(servingCert.Cert, now.Add(ServingCertValidity*9/10))).To(BeTrue())
+	g.Expect(IsValidServingCert(ca.Cert, servingCert.Cert, []string{"svc"}, now.Add(ServingCertValidity*9/10))).To(BeFalse())
+
+	g.Expect(NeedsRotation(ca.Cert, now.Add(ServingCertValidity))).To(BeFalse())
+	g.Expect(NeedsRotation(ca.Cert, now.Add(CAValidity*9/10))).To(BeTrue())
+}
+
+func TestBundle(t *testing.T) {
+	g := NewGomegaWithT(t)
+	now := time.Now()
+
+	ca1, err := NewCA("ca-1", now)
+	g.Expect(err).NotTo(HaveOccurred())
+	ca2, err := NewCA("ca-2", now)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	g.Expect(Bundle(now, ca1.CertPEM, ca2.CertPEM)).To(Equal(append(bytes.Clone(ca1.CertPEM), ca2.CertPEM...)))
+	// invalid and expired certificates are skipped
+	g.Expect(Bundle(now, ca1.CertPEM, nil, []byte("garbage"))).To(Equal(ca1.CertPEM))
+	g.Expect(Bundle(now.Add(CAValidity*2), ca1.CertPEM)).To(BeEmpty())
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeOVSNetworks implements OVSNetworkInterface
+type FakeOVSNetworks struct {
+	Fake *FakeSriovnetworkV1
+	ns   string
+}
+
+var ovsnetworksResource = v1.SchemeGroupVersion.WithResource("ovsnetworks")
+
+var ovsnetworksKind = v1.SchemeGroupVersion.WithKind("OVSNetwork")
+
+// Get takes name of the oVSNetwork, and returns the corresponding oVSNetwork object, and an error if there is any.
+func (c *FakeOVSNetworks) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.OVSNetwork, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(ovsnetworksResource, c.ns, name), &v1.OVSNetwork{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.OVSNetwork), err
+}
+
+// List takes label and field selectors, and returns the list of OVSNetworks that match those selectors.
+func (c *FakeOVSNetworks) List(ctx context.Context, opts metav1.ListOptions) (result *v1.OVSNetworkList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(ovsnetworksResource, ovsnetworksKind, c.ns, opts), &v1.OVSNetworkList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.OVSNetworkList{ListMeta: obj.(*v1.OVSNetworkList).ListMeta}
+	for _, item := range obj.(*v1.OVSNetworkList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested oVSNetworks.
+func (c *FakeOVSNetworks) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(ovsnetworksResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a oVSNetwork and creates it.  Returns the server's representation of the oVSNetwork, and an error, if there is any.
+func (c *FakeOVSNetworks) Create(ctx context.Context, oVSNetwork *v1.OVSNetwork, opts metav1.CreateOptions) (result *v1.OVSNetwork, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(ovsnetworksResource, c.ns, oVSNetwork), &v1.OVSNetwork{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.OVSNetwork), err
+}
+
+// Update takes the representation of a oVSNetwork and updates it. Returns the server's representation of the oVSNetwork, and an error, if there is any.
+func (c *FakeOVSNetworks) Update(ctx context.Context, oVSNetwork *v1.OVSNetwork, opts metav1.UpdateOptions) (result *v1.OVSNetwork, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(ovsnetworksResource, c.ns, oVSNetwork), &v1.OVSNetwork{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.OVSNetwork), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeOVSNetworks) UpdateStatus(ctx context.Context, oVSNetwork *v1.OVSNetwork, opts metav1.UpdateOptions) (*v1.OVSNetwork, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(ovsnetworksResource, "status", c.ns, oVSNetwork), &v1.OVSNetwork{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.OVSNetwork), err
+}
+
+// Delete takes name of the oVSNetwork and deletes it. Returns an error if one occurs.
+func (c *FakeOVSNetworks) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(ovsnetworksResource, c.ns, name, opts), &v1.OVSNetwork{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeOVSNetworks) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(ovsnetworksResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1.OVSNetworkList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched oVSNetwork.
+func (c *FakeOVSNetworks) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.OVSNetwork, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(ovsnetworksResource, c.ns, name, pt, data, subresources...), &v1.OVSNetwork{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.OVSNetwork), err
+}
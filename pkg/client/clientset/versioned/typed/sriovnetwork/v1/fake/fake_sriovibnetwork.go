@@ -0,0 +1,140 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeSriovIBNetworks implements SriovIBNetworkInterface
+type FakeSriovIBNetworks struct {
+	Fake *FakeSriovnetworkV1
+	ns   string
+}
+
+var sriovibnetworksResource = v1.SchemeGroupVersion.WithResource("sriovibnetworks")
+
+var sriovibnetworksKind = v1.SchemeGroupVersion.WithKind("SriovIBNetwork")
+
+// Get takes name of the sriovIBNetwork, and returns the corresponding sriovIBNetwork object, and an error if there is any.
+func (c *FakeSriovIBNetworks) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.SriovIBNetwork, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(sriovibnetworksResource, c.ns, name), &v1.SriovIBNetwork{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.SriovIBNetwork), err
+}
+
+// List takes label and field selectors, and returns the list of SriovIBNetworks that match those selectors.
+func (c *FakeSriovIBNetworks) List(ctx context.Context, opts metav1.ListOptions) (result *v1.SriovIBNetworkList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(sriovibnetworksResource, sriovibnetworksKind, c.ns, opts), &v1.SriovIBNetworkList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.SriovIBNetworkList{ListMeta: obj.(*v1.SriovIBNetworkList).ListMeta}
+	for _, item := range obj.(*v1.SriovIBNetworkList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested sriovIBNetworks.
+func (c *FakeSriovIBNetworks) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(sriovibnetworksResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a sriovIBNetwork and creates it.  Returns the server's representation of the sriovIBNetwork, and an error, if there is any.
+func (c *FakeSriovIBNetworks) Create(ctx context.Context, sriovIBNetwork *v1.SriovIBNetwork, opts metav1.CreateOptions) (result *v1.SriovIBNetwork, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(sriovibnetworksResource, c.ns, sriovIBNetwork), &v1.SriovIBNetwork{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.SriovIBNetwork), err
+}
+
+// Update takes the representation of a sriovIBNetwork and updates it. Returns the server's representation of the sriovIBNetwork, and an error, if there is any.
+func (c *FakeSriovIBNetworks) Update(ctx context.Context, sriovIBNetwork *v1.SriovIBNetwork, opts metav1.UpdateOptions) (result *v1.SriovIBNetwork, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(sriovibnetworksResource, c.ns, sriovIBNetwork), &v1.SriovIBNetwork{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.SriovIBNetwork), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeSriovIBNetworks) UpdateStatus(ctx context.Context, sriovIBNetwork *v1.SriovIBNetwork, opts metav1.UpdateOptions) (*v1.SriovIBNetwork, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(sriovibnetworksResource, "status", c.ns, sriovIBNetwork), &v1.SriovIBNetwork{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.SriovIBNetwork), err
+}
+
+// Delete takes name of the sriovIBNetwork and deletes it. Returns an error if one occurs.
+func (c *FakeSriovIBNetworks) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(sriovibnetworksResource, c.ns, name, opts), &v1.SriovIBNetwork{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeSriovIBNetworks) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(sriovibnetworksResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1.SriovIBNetworkList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched sriovIBNetwork.
+func (c *FakeSriovIBNetworks) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.SriovIBNetwork, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(sriovibnetworksResource, c.ns, name, pt, data, subresources...), &v1.SriovIBNetwork{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.SriovIBNetwork), err
+}
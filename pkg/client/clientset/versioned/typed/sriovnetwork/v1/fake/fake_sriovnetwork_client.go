@@ -1,3 +1,18 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
 // Code generated by client-gen. DO NOT EDIT.
 
 package fake
@@ -12,6 +27,14 @@ type FakeSriovnetworkV1 struct {
 	*testing.Fake
 }
 
+func (c *FakeSriovnetworkV1) OVSNetworks(namespace string) v1.OVSNetworkInterface {
+	return &FakeOVSNetworks{c, namespace}
+}
+
+func (c *FakeSriovnetworkV1) SriovIBNetworks(namespace string) v1.SriovIBNetworkInterface {
+	return &FakeSriovIBNetworks{c, namespace}
+}
+
 func (c *FakeSriovnetworkV1) SriovNetworks(namespace string) v1.SriovNetworkInterface {
 	return &FakeSriovNetworks{c, namespace}
 }
@@ -24,6 +47,10 @@ func (c *FakeSriovnetworkV1) SriovNetworkNodeStates(namespace string) v1.SriovNe
 	return &FakeSriovNetworkNodeStates{c, namespace}
 }
 
+func (c *FakeSriovnetworkV1) SriovNetworkPoolConfigs(namespace string) v1.SriovNetworkPoolConfigInterface {
+	return &FakeSriovNetworkPoolConfigs{c, namespace}
+}
+
 func (c *FakeSriovnetworkV1) SriovOperatorConfigs(namespace string) v1.SriovOperatorConfigInterface {
 	return &FakeSriovOperatorConfigs{c, namespace}
 }
@@ -1,3 +1,18 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
 // Code generated by client-gen. DO NOT EDIT.
 
 package fake
@@ -5,10 +20,9 @@ package fake
 import (
 	"context"
 
-	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
-	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	types "k8s.io/apimachinery/pkg/types"
 	watch "k8s.io/apimachinery/pkg/watch"
 	testing "k8s.io/client-go/testing"
@@ -20,25 +34,25 @@ type FakeSriovNetworkNodePolicies struct {
 	ns   string
 }
 
-var sriovnetworknodepoliciesResource = schema.GroupVersionResource{Group: "sriovnetwork.openshift.io", Version: "v1", Resource: "sriovnetworknodepolicies"}
+var sriovnetworknodepoliciesResource = v1.SchemeGroupVersion.WithResource("sriovnetworknodepolicies")
 
-var sriovnetworknodepoliciesKind = schema.GroupVersionKind{Group: "sriovnetwork.openshift.io", Version: "v1", Kind: "SriovNetworkNodePolicy"}
+var sriovnetworknodepoliciesKind = v1.SchemeGroupVersion.WithKind("SriovNetworkNodePolicy")
 
 // Get takes name of the sriovNetworkNodePolicy, and returns the corresponding sriovNetworkNodePolicy object, and an error if there is any.
-func (c *FakeSriovNetworkNodePolicies) Get(ctx context.Context, name string, options v1.GetOptions) (result *sriovnetworkv1.SriovNetworkNodePolicy, err error) {
+func (c *FakeSriovNetworkNodePolicies) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.SriovNetworkNodePolicy, err error) {
 	obj, err := c.Fake.
-		Invokes(testing.NewGetAction(sriovnetworknodepoliciesResource, c.ns, name), &sriovnetworkv1.SriovNetworkNodePolicy{})
+		Invokes(testing.NewGetAction(sriovnetworknodepoliciesResource, c.ns, name), &v1.SriovNetworkNodePolicy{})
 
 	if obj == nil {
 		return nil, err
 	}
-	return obj.(*sriovnetworkv1.SriovNetworkNodePolicy), err
+	return obj.(*v1.SriovNetworkNodePolicy), err
 }
 
 // List takes label and field selectors, and returns the list of SriovNetworkNodePolicies that match those selectors.
-func (c *FakeSriovNetworkNodePolicies) List(ctx context.Context, opts v1.ListOptions) (result *sriovnetworkv1.SriovNetworkNodePolicyList, err error) {
+func (c *FakeSriovNetworkNodePolicies) List(ctx context.Context, opts metav1.ListOptions) (result *v1.SriovNetworkNodePolicyList, err error) {
 	obj, err := c.Fake.
-		Invokes(testing.NewListAction(sriovnetworknodepoliciesResource, sriovnetworknodepoliciesKind, c.ns, opts), &sriovnetworkv1.SriovNetworkNodePolicyList{})
+		Invokes(testing.NewListAction(sriovnetworknodepoliciesResource, sriovnetworknodepoliciesKind, c.ns, opts), &v1.SriovNetworkNodePolicyList{})
 
 	if obj == nil {
 		return nil, err
@@ -48,8 +62,8 @@ func (c *FakeSriovNetworkNodePolicies) List(ctx context.Context, opts v1.ListOpt
 	if label == nil {
 		label = labels.Everything()
 	}
-	list := &sriovnetworkv1.SriovNetworkNodePolicyList{ListMeta: obj.(*sriovnetworkv1.SriovNetworkNodePolicyList).ListMeta}
-	for _, item := range obj.(*sriovnetworkv1.SriovNetworkNodePolicyList).Items {
+	list := &v1.SriovNetworkNodePolicyList{ListMeta: obj.(*v1.SriovNetworkNodePolicyList).ListMeta}
+	for _, item := range obj.(*v1.SriovNetworkNodePolicyList).Items {
 		if label.Matches(labels.Set(item.Labels)) {
 			list.Items = append(list.Items, item)
 		}
@@ -58,69 +72,69 @@ func (c *FakeSriovNetworkNodePolicies) List(ctx context.Context, opts v1.ListOpt
 }
 
 // Watch returns a watch.Interface that watches the requested sriovNetworkNodePolicies.
-func (c *FakeSriovNetworkNodePolicies) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+func (c *FakeSriovNetworkNodePolicies) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
 	return c.Fake.
 		InvokesWatch(testing.NewWatchAction(sriovnetworknodepoliciesResource, c.ns, opts))
 
 }
 
 // Create takes the representation of a sriovNetworkNodePolicy and creates it.  Returns the server's representation of the sriovNetworkNodePolicy, and an error, if there is any.
-func (c *FakeSriovNetworkNodePolicies) Create(ctx context.Context, sriovNetworkNodePolicy *sriovnetworkv1.SriovNetworkNodePolicy, opts v1.CreateOptions) (result *sriovnetworkv1.SriovNetworkNodePolicy, err error) {
+func (c *FakeSriovNetworkNodePolicies) Create(ctx context.Context, sriovNetworkNodePolicy *v1.SriovNetworkNodePolicy, opts metav1.CreateOptions) (result *v1.SriovNetworkNodePolicy, err error) {
 	obj, err := c.Fake.
-		Invokes(testing.NewCreateAction(sriovnetworknodepoliciesResource, c.ns, sriovNetworkNodePolicy), &sriovnetworkv1.SriovNetworkNodePolicy{})
+		Invokes(testing.NewCreateAction(sriovnetworknodepoliciesResource, c.ns, sriovNetworkNodePolicy), &v1.SriovNetworkNodePolicy{})
 
 	if obj == nil {
 		return nil, err
 	}
-	return obj.(*sriovnetworkv1.SriovNetworkNodePolicy), err
+	return obj.(*v1.SriovNetworkNodePolicy), err
 }
 
 // Update takes the representation of a sriovNetworkNodePolicy and updates it. Returns the server's representation of the sriovNetworkNodePolicy, and an error, if there is any.
-func (c *FakeSriovNetworkNodePolicies) Update(ctx context.Context, sriovNetworkNodePolicy *sriovnetworkv1.SriovNetworkNodePolicy, opts v1.UpdateOptions) (result *sriovnetworkv1.SriovNetworkNodePolicy, err error) {
+func (c *FakeSriovNetworkNodePolicies) Update(ctx context.Context, sriovNetworkNodePolicy *v1.SriovNetworkNodePolicy, opts metav1.UpdateOptions) (result *v1.SriovNetworkNodePolicy, err error) {
 	obj, err := c.Fake.
-		Invokes(testing.NewUpdateAction(sriovnetworknodepoliciesResource, c.ns, sriovNetworkNodePolicy), &sriovnetworkv1.SriovNetworkNodePolicy{})
+		Invokes(testing.NewUpdateAction(sriovnetworknodepoliciesResource, c.ns, sriovNetworkNodePolicy), &v1.SriovNetworkNodePolicy{})
 
 	if obj == nil {
 		return nil, err
 	}
-	return obj.(*sriovnetworkv1.SriovNetworkNodePolicy), err
+	return obj.(*v1.SriovNetworkNodePolicy), err
 }
 
 // UpdateStatus was generated because the type contains a Status member.
 // Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
-func (c *FakeSriovNetworkNodePolicies) UpdateStatus(ctx context.Context, sriovNetworkNodePolicy *sriovnetworkv1.SriovNetworkNodePolicy, opts v1.UpdateOptions) (*sriovnetworkv1.SriovNetworkNodePolicy, error) {
+func (c *FakeSriovNetworkNodePolicies) UpdateStatus(ctx context.Context, sriovNetworkNodePolicy *v1.SriovNetworkNodePolicy, opts metav1.UpdateOptions) (*v1.SriovNetworkNodePolicy, error) {
 	obj, err := c.Fake.
-		Invokes(testing.NewUpdateSubresourceAction(sriovnetworknodepoliciesResource, "status", c.ns, sriovNetworkNodePolicy), &sriovnetworkv1.SriovNetworkNodePolicy{})
+		Invokes(testing.NewUpdateSubresourceAction(sriovnetworknodepoliciesResource, "status", c.ns, sriovNetworkNodePolicy), &v1.SriovNetworkNodePolicy{})
 
 	if obj == nil {
 		return nil, err
 	}
-	return obj.(*sriovnetworkv1.SriovNetworkNodePolicy), err
+	return obj.(*v1.SriovNetworkNodePolicy), err
 }
 
 // Delete takes name of the sriovNetworkNodePolicy and deletes it. Returns an error if one occurs.
-func (c *FakeSriovNetworkNodePolicies) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+func (c *FakeSriovNetworkNodePolicies) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
 	_, err := c.Fake.
-		Invokes(testing.NewDeleteAction(sriovnetworknodepoliciesResource, c.ns, name), &sriovnetworkv1.SriovNetworkNodePolicy{})
+		Invokes(testing.NewDeleteActionWithOptions(sriovnetworknodepoliciesResource, c.ns, name, opts), &v1.SriovNetworkNodePolicy{})
 
 	return err
 }
 
 // DeleteCollection deletes a collection of objects.
-func (c *FakeSriovNetworkNodePolicies) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+func (c *FakeSriovNetworkNodePolicies) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
 	action := testing.NewDeleteCollectionAction(sriovnetworknodepoliciesResource, c.ns, listOpts)
 
-	_, err := c.Fake.Invokes(action, &sriovnetworkv1.SriovNetworkNodePolicyList{})
+	_, err := c.Fake.Invokes(action, &v1.SriovNetworkNodePolicyList{})
 	return err
 }
 
 // Patch applies the patch and returns the patched sriovNetworkNodePolicy.
-func (c *FakeSriovNetworkNodePolicies) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *sriovnetworkv1.SriovNetworkNodePolicy, err error) {
+func (c *FakeSriovNetworkNodePolicies) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.SriovNetworkNodePolicy, err error) {
 	obj, err := c.Fake.
-		Invokes(testing.NewPatchSubresourceAction(sriovnetworknodepoliciesResource, c.ns, name, pt, data, subresources...), &sriovnetworkv1.SriovNetworkNodePolicy{})
+		Invokes(testing.NewPatchSubresourceAction(sriovnetworknodepoliciesResource, c.ns, name, pt, data, subresources...), &v1.SriovNetworkNodePolicy{})
 
 	if obj == nil {
 		return nil, err
 	}
-	return obj.(*sriovnetworkv1.SriovNetworkNodePolicy), err
+	return obj.(*v1.SriovNetworkNodePolicy), err
 }
@@ -1,3 +1,18 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
 // Code generated by client-gen. DO NOT EDIT.
 
 package fake
@@ -5,10 +20,9 @@ package fake
 import (
 	"context"
 
-	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
-	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	types "k8s.io/apimachinery/pkg/types"
 	watch "k8s.io/apimachinery/pkg/watch"
 	testing "k8s.io/client-go/testing"
@@ -20,25 +34,25 @@ type FakeSriovNetworkNodeStates struct {
 	ns   string
 }
 
-var sriovnetworknodestatesResource = schema.GroupVersionResource{Group: "sriovnetwork.openshift.io", Version: "v1", Resource: "sriovnetworknodestates"}
+var sriovnetworknodestatesResource = v1.SchemeGroupVersion.WithResource("sriovnetworknodestates")
 
-var sriovnetworknodestatesKind = schema.GroupVersionKind{Group: "sriovnetwork.openshift.io", Version: "v1", Kind: "SriovNetworkNodeState"}
+var sriovnetworknodestatesKind = v1.SchemeGroupVersion.WithKind("SriovNetworkNodeState")
 
 // Get takes name of the sriovNetworkNodeState, and returns the corresponding sriovNetworkNodeState object, and an error if there is any.
-func (c *FakeSriovNetworkNodeStates) Get(ctx context.Context, name string, options v1.GetOptions) (result *sriovnetworkv1.SriovNetworkNodeState, err error) {
+func (c *FakeSriovNetworkNodeStates) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.SriovNetworkNodeState, err error) {
 	obj, err := c.Fake.
-		Invokes(testing.NewGetAction(sriovnetworknodestatesResource, c.ns, name), &sriovnetworkv1.SriovNetworkNodeState{})
+		Invokes(testing.NewGetAction(sriovnetworknodestatesResource, c.ns, name), &v1.SriovNetworkNodeState{})
 
 	if obj == nil {
 		return nil, err
 	}
-	return obj.(*sriovnetworkv1.SriovNetworkNodeState), err
+	return obj.(*v1.SriovNetworkNodeState), err
 }
 
 // List takes label and field selectors, and returns the list of SriovNetworkNodeStates that match those selectors.
-func (c *FakeSriovNetworkNodeStates) List(ctx context.Context, opts v1.ListOptions) (result *sriovnetworkv1.SriovNetworkNodeStateList, err error) {
+func (c *FakeSriovNetworkNodeStates) List(ctx context.Context, opts metav1.ListOptions) (result *v1.SriovNetworkNodeStateList, err error) {
 	obj, err := c.Fake.
-		Invokes(testing.NewListAction(sriovnetworknodestatesResource, sriovnetworknodestatesKind, c.ns, opts), &sriovnetworkv1.SriovNetworkNodeStateList{})
+		Invokes(testing.NewListAction(sriovnetworknodestatesResource, sriovnetworknodestatesKind, c.ns, opts), &v1.SriovNetworkNodeStateList{})
 
 	if obj == nil {
 		return nil, err
@@ -48,8 +62,8 @@ func (c *FakeSriovNetworkNodeStates) List(ctx context.Context, opts v1.ListOptio
 	if label == nil {
 		label = labels.Everything()
 	}
-	list := &sriovnetworkv1.SriovNetworkNodeStateList{ListMeta: obj.(*sriovnetworkv1.SriovNetworkNodeStateList).ListMeta}
-	for _, item := range obj.(*sriovnetworkv1.SriovNetworkNodeStateList).Items {
+	list := &v1.SriovNetworkNodeStateList{ListMeta: obj.(*v1.SriovNetworkNodeStateList).ListMeta}
+	for _, item := range obj.(*v1.SriovNetworkNodeStateList).Items {
 		if label.Matches(labels.Set(item.Labels)) {
 			list.Items = append(list.Items, item)
 		}
@@ -58,69 +72,69 @@ func (c *FakeSriovNetworkNodeStates) List(ctx context.Context, opts v1.ListOptio
 }
 
 // Watch returns a watch.Interface that watches the requested sriovNetworkNodeStates.
-func (c *FakeSriovNetworkNodeStates) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+func (c *FakeSriovNetworkNodeStates) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
 	return c.Fake.
 		InvokesWatch(testing.NewWatchAction(sriovnetworknodestatesResource, c.ns, opts))
 
 }
 
 // Create takes the representation of a sriovNetworkNodeState and creates it.  Returns the server's representation of the sriovNetworkNodeState, and an error, if there is any.
-func (c *FakeSriovNetworkNodeStates) Create(ctx context.Context, sriovNetworkNodeState *sriovnetworkv1.SriovNetworkNodeState, opts v1.CreateOptions) (result *sriovnetworkv1.SriovNetworkNodeState, err error) {
+func (c *FakeSriovNetworkNodeStates) Create(ctx context.Context, sriovNetworkNodeState *v1.SriovNetworkNodeState, opts metav1.CreateOptions) (result *v1.SriovNetworkNodeState, err error) {
 	obj, err := c.Fake.
-		Invokes(testing.NewCreateAction(sriovnetworknodestatesResource, c.ns, sriovNetworkNodeState), &sriovnetworkv1.SriovNetworkNodeState{})
+		Invokes(testing.NewCreateAction(sriovnetworknodestatesResource, c.ns, sriovNetworkNodeState), &v1.SriovNetworkNodeState{})
 
 	if obj == nil {
 		return nil, err
 	}
-	return obj.(*sriovnetworkv1.SriovNetworkNodeState), err
+	return obj.(*v1.SriovNetworkNodeState), err
 }
 
 // Update takes the representation of a sriovNetworkNodeState and updates it. Returns the server's representation of the sriovNetworkNodeState, and an error, if there is any.
-func (c *FakeSriovNetworkNodeStates) Update(ctx context.Context, sriovNetworkNodeState *sriovnetworkv1.SriovNetworkNodeState, opts v1.UpdateOptions) (result *sriovnetworkv1.SriovNetworkNodeState, err error) {
+func (c *FakeSriovNetworkNodeStates) Update(ctx context.Context, sriovNetworkNodeState *v1.SriovNetworkNodeState, opts metav1.UpdateOptions) (result *v1.SriovNetworkNodeState, err error) {
 	obj, err := c.Fake.
-		Invokes(testing.NewUpdateAction(sriovnetworknodestatesResource, c.ns, sriovNetworkNodeState), &sriovnetworkv1.SriovNetworkNodeState{})
+		Invokes(testing.NewUpdateAction(sriovnetworknodestatesResource, c.ns, sriovNetworkNodeState), &v1.SriovNetworkNodeState{})
 
 	if obj == nil {
 		return nil, err
 	}
-	return obj.(*sriovnetworkv1.SriovNetworkNodeState), err
+	return obj.(*v1.SriovNetworkNodeState), err
 }
 
 // UpdateStatus was generated because the type contains a Status member.
 // Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
-func (c *FakeSriovNetworkNodeStates) UpdateStatus(ctx context.Context, sriovNetworkNodeState *sriovnetworkv1.SriovNetworkNodeState, opts v1.UpdateOptions) (*sriovnetworkv1.SriovNetworkNodeState, error) {
+func (c *FakeSriovNetworkNodeStates) UpdateStatus(ctx context.Context, sriovNetworkNodeState *v1.SriovNetworkNodeState, opts metav1.UpdateOptions) (*v1.SriovNetworkNodeState, error) {
 	obj, err := c.Fake.
-		Invokes(testing.NewUpdateSubresourceAction(sriovnetworknodestatesResource, "status", c.ns, sriovNetworkNodeState), &sriovnetworkv1.SriovNetworkNodeState{})
+		Invokes(testing.NewUpdateSubresourceAction(sriovnetworknodestatesResource, "status", c.ns, sriovNetworkNodeState), &v1.SriovNetworkNodeState{})
 
 	if obj == nil {
 		return nil, err
 	}
-	return obj.(*sriovnetworkv1.SriovNetworkNodeState), err
+	return obj.(*v1.SriovNetworkNodeState), err
 }
 
 // Delete takes name of the sriovNetworkNodeState and deletes it. Returns an error if one occurs.
-func (c *FakeSriovNetworkNodeStates) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+func (c *FakeSriovNetworkNodeStates) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
 	_, err := c.Fake.
-		Invokes(testing.NewDeleteAction(sriovnetworknodestatesResource, c.ns, name), &sriovnetworkv1.SriovNetworkNodeState{})
+		Invokes(testing.NewDeleteActionWithOptions(sriovnetworknodestatesResource, c.ns, name, opts), &v1.SriovNetworkNodeState{})
 
 	return err
 }
 
 // DeleteCollection deletes a collection of objects.
-func (c *FakeSriovNetworkNodeStates) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+func (c *FakeSriovNetworkNodeStates) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
 	action := testing.NewDeleteCollectionAction(sriovnetworknodestatesResource, c.ns, listOpts)
 
-	_, err := c.Fake.Invokes(action, &sriovnetworkv1.SriovNetworkNodeStateList{})
+	_, err := c.Fake.Invokes(action, &v1.SriovNetworkNodeStateList{})
 	return err
 }
 
 // Patch applies the patch and returns the patched sriovNetworkNodeState.
-func (c *FakeSriovNetworkNodeStates) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *sriovnetworkv1.SriovNetworkNodeState, err error) {
+func (c *FakeSriovNetworkNodeStates) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.SriovNetworkNodeState, err error) {
 	obj, err := c.Fake.
-		Invokes(testing.NewPatchSubresourceAction(sriovnetworknodestatesResource, c.ns, name, pt, data, subresources...), &sriovnetworkv1.SriovNetworkNodeState{})
+		Invokes(testing.NewPatchSubresourceAction(sriovnetworknodestatesResource, c.ns, name, pt, data, subresources...), &v1.SriovNetworkNodeState{})
 
 	if obj == nil {
 		return nil, err
 	}
-	return obj.(*sriovnetworkv1.SriovNetworkNodeState), err
+	return obj.(*v1.SriovNetworkNodeState), err
 }
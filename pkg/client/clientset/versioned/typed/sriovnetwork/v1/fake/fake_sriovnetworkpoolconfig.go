@@ -0,0 +1,140 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeSriovNetworkPoolConfigs implements SriovNetworkPoolConfigInterface
+type FakeSriovNetworkPoolConfigs struct {
+	Fake *FakeSriovnetworkV1
+	ns   string
+}
+
+var sriovnetworkpoolconfigsResource = v1.SchemeGroupVersion.WithResource("sriovnetworkpoolconfigs")
+
+var sriovnetworkpoolconfigsKind = v1.SchemeGroupVersion.WithKind("SriovNetworkPoolConfig")
+
+// Get takes name of the sriovNetworkPoolConfig, and returns the corresponding sriovNetworkPoolConfig object, and an error if there is any.
+func (c *FakeSriovNetworkPoolConfigs) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.SriovNetworkPoolConfig, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(sriovnetworkpoolconfigsResource, c.ns, name), &v1.SriovNetworkPoolConfig{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.SriovNetworkPoolConfig), err
+}
+
+// List takes label and field selectors, and returns the list of SriovNetworkPoolConfigs that match those selectors.
+func (c *FakeSriovNetworkPoolConfigs) List(ctx context.Context, opts metav1.ListOptions) (result *v1.SriovNetworkPoolConfigList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(sriovnetworkpoolconfigsResource, sriovnetworkpoolconfigsKind, c.ns, opts), &v1.SriovNetworkPoolConfigList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1.SriovNetworkPoolConfigList{ListMeta: obj.(*v1.SriovNetworkPoolConfigList).ListMeta}
+	for _, item := range obj.(*v1.SriovNetworkPoolConfigList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested sriovNetworkPoolConfigs.
+func (c *FakeSriovNetworkPoolConfigs) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(sriovnetworkpoolconfigsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a sriovNetworkPoolConfig and creates it.  Returns the server's representation of the sriovNetworkPoolConfig, and an error, if there is any.
+func (c *FakeSriovNetworkPoolConfigs) Create(ctx context.Context, sriovNetworkPoolConfig *v1.SriovNetworkPoolConfig, opts metav1.CreateOptions) (result *v1.SriovNetworkPoolConfig, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(sriovnetworkpoolconfigsResource, c.ns, sriovNetworkPoolConfig), &v1.SriovNetworkPoolConfig{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.SriovNetworkPoolConfig), err
+}
+
+// Update takes the representation of a sriovNetworkPoolConfig and updates it. Returns the server's representation of the sriovNetworkPoolConfig, and an error, if there is any.
+func (c *FakeSriovNetworkPoolConfigs) Update(ctx context.Context, sriovNetworkPoolConfig *v1.SriovNetworkPoolConfig, opts metav1.UpdateOptions) (result *v1.SriovNetworkPoolConfig, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(sriovnetworkpoolconfigsResource, c.ns, sriovNetworkPoolConfig), &v1.SriovNetworkPoolConfig{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.SriovNetworkPoolConfig), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeSriovNetworkPoolConfigs) UpdateStatus(ctx context.Context, sriovNetworkPoolConfig *v1.SriovNetworkPoolConfig, opts metav1.UpdateOptions) (*v1.SriovNetworkPoolConfig, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(sriovnetworkpoolconfigsResource, "status", c.ns, sriovNetworkPoolConfig), &v1.SriovNetworkPoolConfig{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.SriovNetworkPoolConfig), err
+}
+
+// Delete takes name of the sriovNetworkPoolConfig and deletes it. Returns an error if one occurs.
+func (c *FakeSriovNetworkPoolConfigs) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(sriovnetworkpoolconfigsResource, c.ns, name, opts), &v1.SriovNetworkPoolConfig{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeSriovNetworkPoolConfigs) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(sriovnetworkpoolconfigsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1.SriovNetworkPoolConfigList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched sriovNetworkPoolConfig.
+func (c *FakeSriovNetworkPoolConfigs) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.SriovNetworkPoolConfig, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(sriovnetworkpoolconfigsResource, c.ns, name, pt, data, subresources...), &v1.SriovNetworkPoolConfig{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1.SriovNetworkPoolConfig), err
+}
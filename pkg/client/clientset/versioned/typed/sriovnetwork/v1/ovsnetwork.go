@@ -0,0 +1,194 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	scheme "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// OVSNetworksGetter has a method to return a OVSNetworkInterface.
+// A group's client should implement this interface.
+type OVSNetworksGetter interface {
+	OVSNetworks(namespace string) OVSNetworkInterface
+}
+
+// OVSNetworkInterface has methods to work with OVSNetwork resources.
+type OVSNetworkInterface interface {
+	Create(ctx context.Context, oVSNetwork *v1.OVSNetwork, opts metav1.CreateOptions) (*v1.OVSNetwork, error)
+	Update(ctx context.Context, oVSNetwork *v1.OVSNetwork, opts metav1.UpdateOptions) (*v1.OVSNetwork, error)
+	UpdateStatus(ctx context.Context, oVSNetwork *v1.OVSNetwork, opts metav1.UpdateOptions) (*v1.OVSNetwork, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.OVSNetwork, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.OVSNetworkList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.OVSNetwork, err error)
+	OVSNetworkExpansion
+}
+
+// oVSNetworks implements OVSNetworkInterface
+type oVSNetworks struct {
+	client rest.Interface
+	ns     string
+}
+
+// newOVSNetworks returns a OVSNetworks
+func newOVSNetworks(c *SriovnetworkV1Client, namespace string) *oVSNetworks {
+	return &oVSNetworks{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the oVSNetwork, and returns the corresponding oVSNetwork object, and an error if there is any.
+func (c *oVSNetworks) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.OVSNetwork, err error) {
+	result = &v1.OVSNetwork{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("ovsnetworks").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of OVSNetworks that match those selectors.
+func (c *oVSNetworks) List(ctx context.Context, opts metav1.ListOptions) (result *v1.OVSNetworkList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.OVSNetworkList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("ovsnetworks").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested oVSNetworks.
+func (c *oVSNetworks) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("ovsnetworks").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a oVSNetwork and creates it.  Returns the server's representation of the oVSNetwork, and an error, if there is any.
+func (c *oVSNetworks) Create(ctx context.Context, oVSNetwork *v1.OVSNetwork, opts metav1.CreateOptions) (result *v1.OVSNetwork, err error) {
+	result = &v1.OVSNetwork{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("ovsnetworks").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(oVSNetwork).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a oVSNetwork and updates it. Returns the server's representation of the oVSNetwork, and an error, if there is any.
+func (c *oVSNetworks) Update(ctx context.Context, oVSNetwork *v1.OVSNetwork, opts metav1.UpdateOptions) (result *v1.OVSNetwork, err error) {
+	result = &v1.OVSNetwork{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("ovsnetworks").
+		Name(oVSNetwork.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(oVSNetwork).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *oVSNetworks) UpdateStatus(ctx context.Context, oVSNetwork *v1.OVSNetwork, opts metav1.UpdateOptions) (result *v1.OVSNetwork, err error) {
+	result = &v1.OVSNetwork{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("ovsnetworks").
+		Name(oVSNetwork.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(oVSNetwork).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the oVSNetwork and deletes it. Returns an error if one occurs.
+func (c *oVSNetworks) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("ovsnetworks").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *oVSNetworks) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("ovsnetworks").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched oVSNetwork.
+func (c *oVSNetworks) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.OVSNetwork, err error) {
+	result = &v1.OVSNetwork{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("ovsnetworks").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -1,8 +1,25 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
 // Code generated by client-gen. DO NOT EDIT.
 
 package v1
 
 import (
+	"net/http"
+
 	v1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/client/clientset/versioned/scheme"
 	rest "k8s.io/client-go/rest"
@@ -10,9 +27,12 @@ import (
 
 type SriovnetworkV1Interface interface {
 	RESTClient() rest.Interface
+	OVSNetworksGetter
+	SriovIBNetworksGetter
 	SriovNetworksGetter
 	SriovNetworkNodePoliciesGetter
 	SriovNetworkNodeStatesGetter
+	SriovNetworkPoolConfigsGetter
 	SriovOperatorConfigsGetter
 }
 
@@ -21,6 +41,14 @@ type SriovnetworkV1Client struct {
 	restClient rest.Interface
 }
 
+func (c *SriovnetworkV1Client) OVSNetworks(namespace string) OVSNetworkInterface {
+	return newOVSNetworks(c, namespace)
+}
+
+func (c *SriovnetworkV1Client) SriovIBNetworks(namespace string) SriovIBNetworkInterface {
+	return newSriovIBNetworks(c, namespace)
+}
+
 func (c *SriovnetworkV1Client) SriovNetworks(namespace string) SriovNetworkInterface {
 	return newSriovNetworks(c, namespace)
 }
@@ -33,17 +61,37 @@ func (c *SriovnetworkV1Client) SriovNetworkNodeStates(namespace string) SriovNet
 	return newSriovNetworkNodeStates(c, namespace)
 }
 
+func (c *SriovnetworkV1Client) SriovNetworkPoolConfigs(namespace string) SriovNetworkPoolConfigInterface {
+	return newSriovNetworkPoolConfigs(c, namespace)
+}
+
 func (c *SriovnetworkV1Client) SriovOperatorConfigs(namespace string) SriovOperatorConfigInterface {
 	return newSriovOperatorConfigs(c, namespace)
 }
 
 // NewForConfig creates a new SriovnetworkV1Client for the given config.
+// NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
+// where httpClient was generated with rest.HTTPClientFor(c).
 func NewForConfig(c *rest.Config) (*SriovnetworkV1Client, error) {
 	config := *c
 	if err := setConfigDefaults(&config); err != nil {
 		return nil, err
 	}
-	client, err := rest.RESTClientFor(&config)
+	httpClient, err := rest.HTTPClientFor(&config)
+	if err != nil {
+		return nil, err
+	}
+	return NewForConfigAndClient(&config, httpClient)
+}
+
+// NewForConfigAndClient creates a new SriovnetworkV1Client for the given config and http client.
+// Note the http client provided takes precedence over the configured transport values.
+func NewForConfigAndClient(c *rest.Config, h *http.Client) (*SriovnetworkV1Client, error) {
+	config := *c
+	if err := setConfigDefaults(&config); err != nil {
+		return nil, err
+	}
+	client, err := rest.RESTClientForConfigAndClient(&config, h)
 	if err != nil {
 		return nil, err
 	}
@@ -1,3 +1,18 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
 // Code generated by informer-gen. DO NOT EDIT.
 
 package externalversions
@@ -5,10 +20,9 @@ package externalversions
 import (
 	"fmt"
 
+	v1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	cache "k8s.io/client-go/tools/cache"
-
-	v1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 )
 
 // GenericInformer is type of SharedIndexInformer which will locate and delegate to other
@@ -38,12 +52,18 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=sriovnetwork, Version=v1
+	case v1.SchemeGroupVersion.WithResource("ovsnetworks"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Sriovnetwork().V1().OVSNetworks().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("sriovibnetworks"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Sriovnetwork().V1().SriovIBNetworks().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("sriovnetworks"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Sriovnetwork().V1().SriovNetworks().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("sriovnetworknodepolicies"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Sriovnetwork().V1().SriovNetworkNodePolicies().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("sriovnetworknodestates"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Sriovnetwork().V1().SriovNetworkNodeStates().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("sriovnetworkpoolconfigs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Sriovnetwork().V1().SriovNetworkPoolConfigs().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("sriovoperatorconfigs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Sriovnetwork().V1().SriovOperatorConfigs().Informer()}, nil
 
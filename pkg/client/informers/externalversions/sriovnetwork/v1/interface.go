@@ -1,3 +1,18 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
 // Code generated by informer-gen. DO NOT EDIT.
 
 package v1
@@ -8,12 +23,18 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// OVSNetworks returns a OVSNetworkInformer.
+	OVSNetworks() OVSNetworkInformer
+	// SriovIBNetworks returns a SriovIBNetworkInformer.
+	SriovIBNetworks() SriovIBNetworkInformer
 	// SriovNetworks returns a SriovNetworkInformer.
 	SriovNetworks() SriovNetworkInformer
 	// SriovNetworkNodePolicies returns a SriovNetworkNodePolicyInformer.
 	SriovNetworkNodePolicies() SriovNetworkNodePolicyInformer
 	// SriovNetworkNodeStates returns a SriovNetworkNodeStateInformer.
 	SriovNetworkNodeStates() SriovNetworkNodeStateInformer
+	// SriovNetworkPoolConfigs returns a SriovNetworkPoolConfigInformer.
+	SriovNetworkPoolConfigs() SriovNetworkPoolConfigInformer
 	// SriovOperatorConfigs returns a SriovOperatorConfigInformer.
 	SriovOperatorConfigs() SriovOperatorConfigInformer
 }
@@ -29,6 +50,16 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// OVSNetworks returns a OVSNetworkInformer.
+func (v *version) OVSNetworks() OVSNetworkInformer {
+	return &oVSNetworkInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// SriovIBNetworks returns a SriovIBNetworkInformer.
+func (v *version) SriovIBNetworks() SriovIBNetworkInformer {
+	return &sriovIBNetworkInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // SriovNetworks returns a SriovNetworkInformer.
 func (v *version) SriovNetworks() SriovNetworkInformer {
 	return &sriovNetworkInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -44,6 +75,11 @@ func (v *version) SriovNetworkNodeStates() SriovNetworkNodeStateInformer {
 	return &sriovNetworkNodeStateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// SriovNetworkPoolConfigs returns a SriovNetworkPoolConfigInformer.
+func (v *version) SriovNetworkPoolConfigs() SriovNetworkPoolConfigInformer {
+	return &sriovNetworkPoolConfigInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // SriovOperatorConfigs returns a SriovOperatorConfigInformer.
 func (v *version) SriovOperatorConfigs() SriovOperatorConfigInformer {
 	return &sriovOperatorConfigInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -0,0 +1,89 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	time "time"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	versioned "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/client/informers/externalversions/internalinterfaces"
+	v1 "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/client/listers/sriovnetwork/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// SriovNetworkPoolConfigInformer provides access to a shared informer and lister for
+// SriovNetworkPoolConfigs.
+type SriovNetworkPoolConfigInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1.SriovNetworkPoolConfigLister
+}
+
+type sriovNetworkPoolConfigInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewSriovNetworkPoolConfigInformer constructs a new informer for SriovNetworkPoolConfig type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewSriovNetworkPoolConfigInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredSriovNetworkPoolConfigInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredSriovNetworkPoolConfigInformer constructs a new informer for SriovNetworkPoolConfig type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredSriovNetworkPoolConfigInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.SriovnetworkV1().SriovNetworkPoolConfigs(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.SriovnetworkV1().SriovNetworkPoolConfigs(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&sriovnetworkv1.SriovNetworkPoolConfig{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *sriovNetworkPoolConfigInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredSriovNetworkPoolConfigInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *sriovNetworkPoolConfigInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&sriovnetworkv1.SriovNetworkPoolConfig{}, f.defaultInformer)
+}
+
+func (f *sriovNetworkPoolConfigInformer) Lister() v1.SriovNetworkPoolConfigLister {
+	return v1.NewSriovNetworkPoolConfigLister(f.Informer().GetIndexer())
+}
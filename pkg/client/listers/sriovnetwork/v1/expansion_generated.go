@@ -1,7 +1,38 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
 // Code generated by lister-gen. DO NOT EDIT.
 
 package v1
 
+// OVSNetworkListerExpansion allows custom methods to be added to
+// OVSNetworkLister.
+type OVSNetworkListerExpansion interface{}
+
+// OVSNetworkNamespaceListerExpansion allows custom methods to be added to
+// OVSNetworkNamespaceLister.
+type OVSNetworkNamespaceListerExpansion interface{}
+
+// SriovIBNetworkListerExpansion allows custom methods to be added to
+// SriovIBNetworkLister.
+type SriovIBNetworkListerExpansion interface{}
+
+// SriovIBNetworkNamespaceListerExpansion allows custom methods to be added to
+// SriovIBNetworkNamespaceLister.
+type SriovIBNetworkNamespaceListerExpansion interface{}
+
 // SriovNetworkListerExpansion allows custom methods to be added to
 // SriovNetworkLister.
 type SriovNetworkListerExpansion interface{}
@@ -26,6 +57,14 @@ type SriovNetworkNodeStateListerExpansion interface{}
 // SriovNetworkNodeStateNamespaceLister.
 type SriovNetworkNodeStateNamespaceListerExpansion interface{}
 
+// SriovNetworkPoolConfigListerExpansion allows custom methods to be added to
+// SriovNetworkPoolConfigLister.
+type SriovNetworkPoolConfigListerExpansion interface{}
+
+// SriovNetworkPoolConfigNamespaceListerExpansion allows custom methods to be added to
+// SriovNetworkPoolConfigNamespaceLister.
+type SriovNetworkPoolConfigNamespaceListerExpansion interface{}
+
 // SriovOperatorConfigListerExpansion allows custom methods to be added to
 // SriovOperatorConfigLister.
 type SriovOperatorConfigListerExpansion interface{}
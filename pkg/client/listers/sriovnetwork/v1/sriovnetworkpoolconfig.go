@@ -0,0 +1,98 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// SriovNetworkPoolConfigLister helps list SriovNetworkPoolConfigs.
+// All objects returned here must be treated as read-only.
+type SriovNetworkPoolConfigLister interface {
+	// List lists all SriovNetworkPoolConfigs in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.SriovNetworkPoolConfig, err error)
+	// SriovNetworkPoolConfigs returns an object that can list and get SriovNetworkPoolConfigs.
+	SriovNetworkPoolConfigs(namespace string) SriovNetworkPoolConfigNamespaceLister
+	SriovNetworkPoolConfigListerExpansion
+}
+
+// sriovNetworkPoolConfigLister implements the SriovNetworkPoolConfigLister interface.
+type sriovNetworkPoolConfigLister struct {
+	indexer cache.Indexer
+}
+
+// NewSriovNetworkPoolConfigLister returns a new SriovNetworkPoolConfigLister.
+func NewSriovNetworkPoolConfigLister(indexer cache.Indexer) SriovNetworkPoolConfigLister {
+	return &sriovNetworkPoolConfigLister{indexer: indexer}
+}
+
+// List lists all SriovNetworkPoolConfigs in the indexer.
+func (s *sriovNetworkPoolConfigLister) List(selector labels.Selector) (ret []*v1.SriovNetworkPoolConfig, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.SriovNetworkPoolConfig))
+	})
+	return ret, err
+}
+
+// SriovNetworkPoolConfigs returns an object that can list and get SriovNetworkPoolConfigs.
+func (s *sriovNetworkPoolConfigLister) SriovNetworkPoolConfigs(namespace string) SriovNetworkPoolConfigNamespaceLister {
+	return sriovNetworkPoolConfigNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// SriovNetworkPoolConfigNamespaceLister helps list and get SriovNetworkPoolConfigs.
+// All objects returned here must be treated as read-only.
+type SriovNetworkPoolConfigNamespaceLister interface {
+	// List lists all SriovNetworkPoolConfigs in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.SriovNetworkPoolConfig, err error)
+	// Get retrieves the SriovNetworkPoolConfig from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.SriovNetworkPoolConfig, error)
+	SriovNetworkPoolConfigNamespaceListerExpansion
+}
+
+// sriovNetworkPoolConfigNamespaceLister implements the SriovNetworkPoolConfigNamespaceLister
+// interface.
+type sriovNetworkPoolConfigNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all SriovNetworkPoolConfigs in the indexer for a given namespace.
+func (s sriovNetworkPoolConfigNamespaceLister) List(selector labels.Selector) (ret []*v1.SriovNetworkPoolConfig, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.SriovNetworkPoolConfig))
+	})
+	return ret, err
+}
+
+// Get retrieves the SriovNetworkPoolConfig from the indexer for a given namespace and name.
+func (s sriovNetworkPoolConfigNamespaceLister) Get(name string) (*v1.SriovNetworkPoolConfig, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("sriovnetworkpoolconfig"), name)
+	}
+	return obj.(*v1.SriovNetworkPoolConfig), nil
+}
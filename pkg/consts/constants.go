@@ -20,12 +20,14 @@ const (
 	InjectorWebHookPath                = "./bindata/manifests/webhook"
 	OperatorWebHookPath                = "./bindata/manifests/operator-webhook"
 	MetricsExporterPath                = "./bindata/manifests/metrics-exporter"
+	ConfigDaemonMetricsPath            = "./bindata/manifests/config-daemon-metrics"
 	SystemdServiceOcpPath              = "./bindata/manifests/sriov-config-service/openshift"
 	SystemdServiceOcpMachineConfigName = "sriov-config-service"
 	ServiceCAConfigMapAnnotation       = "service.beta.openshift.io/inject-cabundle"
 	InjectorWebHookName                = "network-resources-injector-config"
 	OperatorWebHookName                = "sriov-operator-webhook-config"
 	DeprecatedOperatorWebHookName      = "operator-webhook-config"
+	WebhookCASecretName                = "sriov-webhook-ca"
 	PluginPath                         = "./bindata/manifests/plugins"
 	DaemonPath                         = "./bindata/manifests/daemon"
 	DefaultPolicyName                  = "default"
@@ -38,6 +40,12 @@ const (
 	OVSHWOLMachineConfigNameSuffix     = "ovs-hw-offload"
 	LeaderElectionID                   = "a56def2a.openshift.io"
 
+	ConfigDaemonDaemonSetName    = "sriov-network-config-daemon"
+	DevicePluginDaemonSetName    = "sriov-device-plugin"
+	MetricsExporterDaemonSetName = "sriov-network-metrics-exporter"
+	InjectorDaemonSetName        = "network-resources-injector"
+	OperatorWebhookDaemonSetName = "operator-webhook"
+
 	LinkTypeEthernet   = "ether"
 	LinkTypeInfiniband = "infiniband"
 
@@ -54,6 +62,10 @@ const (
 	VdpaTypeVirtio      = "virtio"
 	VdpaTypeVhost       = "vhost"
 
+	PfFeatureRxVlanFilter = "rx-vlan-filter"
+	PfFeatureHwTcOffload  = "hw-tc-offload"
+	PfFeatureLro          = "lro"
+
 	RdmaSubsystemModeShared    = "shared"
 	RdmaSubsystemModeExclusive = "exclusive"
 
@@ -73,6 +85,9 @@ const (
 	SriovDevicePluginLabel         = "sriovnetwork.openshift.io/device-plugin"
 	SriovDevicePluginLabelEnabled  = "Enabled"
 	SriovDevicePluginLabelDisabled = "Disabled"
+	// SriovDevicePluginLabelPaused is set by the config daemon to stop the device plugin while it reconfigures
+	// VFs without a drain, the operator doesn't enable the device plugin again until the daemon resumes it
+	SriovDevicePluginLabelPaused = "Paused"
 
 	NodeDrainAnnotation             = "sriovnetwork.openshift.io/state"
 	NodeStateDrainAnnotation        = "sriovnetwork.openshift.io/desired-state"
@@ -80,6 +95,9 @@ const (
 	DrainIdle                       = "Idle"
 	DrainRequired                   = "Drain_Required"
 	RebootRequired                  = "Reboot_Required"
+	DrainPending                    = "Drain_Pending"
+	DrainApprovalPending            = "Drain_Approval_Pending"
+	DrainFailed                     = "Drain_Failed"
 	Draining                        = "Draining"
 	DrainComplete                   = "DrainComplete"
 
@@ -87,6 +105,21 @@ const (
 	SyncStatusFailed     = "Failed"
 	SyncStatusInProgress = "InProgress"
 
+	// PolicyDryRunAnnotation set to "true" on a SriovNetworkNodePolicy excludes it from the node configuration,
+	// the operator only reports the changes it would cause in the policy status
+	PolicyDryRunAnnotation = "sriovnetwork.openshift.io/dry-run"
+
+	// NodeStateDrainApprovedAnnotation set to "true" on a SriovNetworkNodeState approves the next drain or reboot
+	// of the node when its pool requires a drain approval
+	NodeStateDrainApprovedAnnotation = "sriovnetwork.openshift.io/drain-approved"
+
+	// NodeStateDrainPfsAnnotation lists the PCI addresses of the PFs reconfigured by the drain requested
+	// by the config daemon, the annotation is missing when the drain is not scoped to specific PFs
+	NodeStateDrainPfsAnnotation = "sriovnetwork.openshift.io/drain-pfs"
+	// NodeStateDrainResourcesAnnotation lists the resource names of the PFs reconfigured by the drain requested
+	// by the config daemon, only the pods requesting these resources are evicted
+	NodeStateDrainResourcesAnnotation = "sriovnetwork.openshift.io/drain-resources"
+
 	DrainDeleted = "Deleted"
 	DrainEvicted = "Evicted"
 
@@ -152,6 +185,15 @@ const (
 	// MellanoxFirmwareResetFeatureGate: enables the firmware reset via mstfwreset before a reboot
 	MellanoxFirmwareResetFeatureGate = "mellanoxFirmwareReset"
 
+	// ConfigDaemonMetricsFeatureGate: expose the metrics of the config-daemon and deploy the related ServiceMonitor
+	ConfigDaemonMetricsFeatureGate = "configDaemonMetrics"
+
+	// SkipUnusedVfsDrainFeatureGate: skip the drain when none of the reconfigured VFs is allocated to a pod
+	SkipUnusedVfsDrainFeatureGate = "skipUnusedVfsDrain"
+
+	// PodResourcesSocketPath is the path of the kubelet pod-resources API socket on the host
+	PodResourcesSocketPath = "/var/lib/kubelet/pod-resources/kubelet.sock"
+
 	// The path to the file on the host filesystem that contains the IB GUID distribution for IB VFs
 	InfinibandGUIDConfigFilePath = SriovConfBasePath + "/infiniband/guids"
 )
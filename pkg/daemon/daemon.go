@@ -2,9 +2,11 @@ package daemon
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
 	"math/rand"
 	"reflect"
+	"sort"
 	"sync"
 	"time"
 
@@ -28,6 +30,7 @@ import (
 	snolog "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/log"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/platforms"
 	plugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/podresources"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/systemd"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
@@ -45,6 +48,38 @@ const (
 type Message struct {
 	syncStatus    string
 	lastSyncError string
+	// name of the plugin that caused the sync failure, empty if the failure is not related to a plugin
+	failedPlugin string
+	// drain and reboot requirements aggregated from the plugins, nil if they are not known yet
+	requirements *nodeRequirements
+	// generation of the node state spec the status refers to, 0 if it is not known
+	generation int64
+}
+
+// nodeRequirements contains the disruptive actions required to apply the desired node state
+type nodeRequirements struct {
+	drain  bool
+	reboot bool
+	// names of the plugins which requested the drain and the reboot
+	drainRequesters  []string
+	rebootRequesters []string
+}
+
+// systemdRequester is reported as the requester of the disruptive actions caused by the systemd mode
+const systemdRequester = "sriov-config-service"
+
+// pluginError wraps an error returned by one of the plugins
+type pluginError struct {
+	plugin string
+	err    error
+}
+
+func (e *pluginError) Error() string {
+	return e.err.Error()
+}
+
+func (e *pluginError) Unwrap() error {
+	return e.err
 }
 
 type Daemon struct {
@@ -85,6 +120,12 @@ type Daemon struct {
 	eventRecorder *EventRecorder
 
 	featureGate featuregate.FeatureGate
+
+	// podResources lists the devices allocated to the pods of the node
+	podResources podresources.Interface
+
+	// time of the last drain request, zero if no drain is pending
+	drainRequestedAt time.Time
 }
 
 func New(
@@ -118,6 +159,7 @@ func New(
 			workqueue.NewItemExponentialFailureRateLimiter(1*time.Second, maxUpdateBackoff)), "SriovNetworkNodeState"),
 		eventRecorder:   er,
 		featureGate:     featureGates,
+		podResources:    podresources.New(utils.GetHostExtensionPath(consts.PodResourcesSocketPath)),
 		disabledPlugins: disabledPlugins,
 		mu:              &sync.Mutex{},
 	}
@@ -156,6 +198,10 @@ func (dn *Daemon) Run(stopCh <-chan struct{}, exitCh <-chan error) error {
 	if err := dn.HostHelpers.PrepareVFRepUdevRule(); err != nil {
 		log.Log.Error(err, "failed to prepare udev files to rename VF representors for requested VFs")
 	}
+	// the device plugin may have been left paused by a sync interrupted by a restart of the daemon
+	if err := dn.resumeDevicePlugin(); err != nil {
+		log.Log.Error(err, "failed to resume the device plugin")
+	}
 
 	var timeout int64 = 5
 	var metadataKey = "metadata.name"
@@ -261,13 +307,21 @@ func (dn *Daemon) processNextWorkItem() bool {
 			return nil
 		}
 
+		start := time.Now()
 		err := dn.nodeStateSyncHandler()
+		dn.observeSync(start, err)
 		if err != nil {
 			// Ereport error message, and put the item back to work queue for retry.
-			dn.refreshCh <- Message{
+			msg := Message{
 				syncStatus:    consts.SyncStatusFailed,
 				lastSyncError: err.Error(),
+				generation:    dn.appliedGeneration(),
+			}
+			var pErr *pluginError
+			if goerrors.As(err, &pErr) {
+				msg.failedPlugin = pErr.plugin
 			}
+			dn.refreshCh <- msg
 			<-dn.syncCh
 			dn.workqueue.AddRateLimited(key)
 			return fmt.Errorf("error syncing: %s, requeuing", err.Error())
@@ -325,10 +379,11 @@ func (dn *Daemon) nodeStateSyncHandler() error {
 	}
 	latest := dn.desiredNodeState.GetGeneration()
 	log.Log.V(0).Info("nodeStateSyncHandler(): new generation", "generation", latest)
+	desiredGeneration.Set(float64(latest))
 
 	// load plugins if it has not loaded
 	if len(dn.loadedPlugins) == 0 {
-		dn.loadedPlugins, err = loadPlugins(dn.desiredNodeState, dn.HostHelpers, dn.disabledPlugins)
+		dn.loadedPlugins, err = LoadPlugins(dn.desiredNodeState, dn.HostHelpers, dn.disabledPlugins)
 		if err != nil {
 			log.Log.Error(err, "nodeStateSyncHandler(): failed to enable vendor plugins")
 			return err
@@ -371,6 +426,7 @@ func (dn *Daemon) nodeStateSyncHandler() error {
 				dn.refreshCh <- Message{
 					syncStatus:    consts.SyncStatusFailed,
 					lastSyncError: sriovResult.LastSyncError,
+					generation:    dn.appliedGeneration(),
 				}
 				<-dn.syncCh
 				return nil
@@ -393,6 +449,7 @@ func (dn *Daemon) nodeStateSyncHandler() error {
 	dn.refreshCh <- Message{
 		syncStatus:    consts.SyncStatusInProgress,
 		lastSyncError: "",
+		generation:    dn.appliedGeneration(),
 	}
 	// wait for writer to refresh status then pull again the latest node state
 	<-dn.syncCh
@@ -409,6 +466,8 @@ func (dn *Daemon) nodeStateSyncHandler() error {
 
 	reqReboot := false
 	reqDrain := false
+	drainRequesters := []string{}
+	rebootRequesters := []string{}
 
 	// check if any of the plugins required to drain or reboot the node
 	for k, p := range dn.loadedPlugins {
@@ -418,15 +477,25 @@ func (dn *Daemon) nodeStateSyncHandler() error {
 		} else {
 			log.Log.V(0).Info("nodeStateSyncHandler(): calling OnNodeStateChange for an updated node state")
 		}
+		start := time.Now()
 		d, r, err = p.OnNodeStateChange(dn.desiredNodeState)
+		observePluginCall(k, pluginOperationOnNodeStateChange, start, err)
 		if err != nil {
 			log.Log.Error(err, "nodeStateSyncHandler(): OnNodeStateChange plugin error", "plugin-name", k)
-			return err
+			return &pluginError{plugin: k, err: err}
 		}
 		log.Log.V(0).Info("nodeStateSyncHandler(): OnNodeStateChange result", "plugin", k, "drain-required", d, "reboot-required", r)
 		reqDrain = reqDrain || d
 		reqReboot = reqReboot || r
+		if d {
+			drainRequesters = append(drainRequesters, k)
+		}
+		if r {
+			rebootRequesters = append(rebootRequesters, k)
+		}
 	}
+	sort.Strings(drainRequesters)
+	sort.Strings(rebootRequesters)
 
 	// When running using systemd check if the applied configuration is the latest one
 	// or there is a new config we need to apply
@@ -447,7 +516,13 @@ func (dn *Daemon) nodeStateSyncHandler() error {
 				return err
 			}
 		}
+		if systemdConfModified {
+			drainRequesters = append(drainRequesters, systemdRequester)
+		}
 		reqDrain = reqDrain || systemdConfModified
+		if reqDrain && !reqReboot {
+			rebootRequesters = append(rebootRequesters, systemdRequester)
+		}
 		// require reboot if drain needed for systemd mode
 		reqReboot = reqReboot || systemdConfModified || reqDrain
 		log.Log.V(0).Info("nodeStateSyncHandler(): systemd mode WriteConfFile results",
@@ -463,61 +538,83 @@ func (dn *Daemon) nodeStateSyncHandler() error {
 	log.Log.V(0).Info("nodeStateSyncHandler(): aggregated daemon",
 		"drain-required", reqDrain, "reboot-required", reqReboot, "disable-drain", dn.disableDrain)
 
-	// handle drain only if the plugin request drain, or we are already in a draining request state
-	if reqDrain || !utils.ObjectHasAnnotation(dn.desiredNodeState,
+	// a drain already requested to the operator is not skipped
+	scope := dn.computeDrainScope(drainRequesters, reqReboot)
+	if reqDrain && !dn.disableDrain && utils.ObjectHasAnnotation(dn.desiredNodeState,
 		consts.NodeStateDrainAnnotationCurrent,
 		consts.DrainIdle) {
-		drainInProcess, err := dn.handleDrain(reqReboot)
+		// the device plugin is paused when the drain is skipped, it is resumed however the sync ends
+		defer func() {
+			if err := dn.resumeDevicePlugin(); err != nil {
+				log.Log.Error(err, "nodeStateSyncHandler(): failed to resume the device plugin")
+			}
+		}()
+		skipDrain, err := dn.canSkipDrain(scope)
 		if err != nil {
-			log.Log.Error(err, "failed to handle drain")
+			log.Log.Error(err, "nodeStateSyncHandler(): failed to check if the drain can be skipped")
 			return err
 		}
-		if drainInProcess {
-			return nil
+		if skipDrain {
+			log.Log.Info("nodeStateSyncHandler(): none of the reconfigured VFs is allocated, skipping the drain", "pfs", scope.pfs)
+			dn.eventRecorder.SendEvent("DrainSkipped", "Drain skipped, none of the reconfigured VFs is allocated to a pod")
+			reqDrain = false
 		}
 	}
 
-	// apply the vendor plugins after we are done with drain if needed
-	for k, p := range dn.loadedPlugins {
-		// Skip both the general and virtual plugin apply them last
-		if k != GenericPluginName && k != VirtualPluginName {
-			err := p.Apply()
-			if err != nil {
-				log.Log.Error(err, "nodeStateSyncHandler(): plugin Apply failed", "plugin-name", k)
-				return err
-			}
+	// publish the disruptive actions before waiting for them
+	if reqDrain || reqReboot {
+		dn.refreshCh <- Message{
+			syncStatus:    consts.SyncStatusInProgress,
+			lastSyncError: "",
+			requirements: &nodeRequirements{drain: reqDrain, reboot: reqReboot,
+				drainRequesters: drainRequesters, rebootRequesters: rebootRequesters},
+			generation: dn.appliedGeneration(),
 		}
+		// wait for writer to refresh the status
+		<-dn.syncCh
 	}
 
-	// if we don't need to reboot, or we are not doing the configuration in systemd
-	// we apply the generic plugin
-	if !reqReboot && !vars.UsingSystemdMode {
-		// For BareMetal machines apply the generic plugin
-		selectedPlugin, ok := dn.loadedPlugins[GenericPluginName]
-		if ok {
-			// Apply generic plugin last
-			err = selectedPlugin.Apply()
-			if err != nil {
-				log.Log.Error(err, "nodeStateSyncHandler(): generic plugin fail to apply")
-				return err
-			}
+	// the drain completed for a narrower scope than the one of the configuration, the consumers of the VFs
+	// reconfigured since then were not evicted so the drain is withdrawn and requested again on the next sync
+	if reqDrain && !dn.disableDrain && dn.isDrainCompleted() && !dn.drainScopeCovers(scope) {
+		log.Log.Info("nodeStateSyncHandler(): the drain scope changed since the node was drained, requesting a new drain")
+		if err := dn.withdrawDrain(); err != nil {
+			log.Log.Error(err, "nodeStateSyncHandler(): failed to withdraw the drain")
+			return err
 		}
+		return nil
+	}
 
-		// For Virtual machines apply the virtual plugin
-		selectedPlugin, ok = dn.loadedPlugins[VirtualPluginName]
-		if ok {
-			// Apply virtual plugin last
-			err = selectedPlugin.Apply()
-			if err != nil {
-				log.Log.Error(err, "nodeStateSyncHandler(): virtual plugin failed to apply")
-				return err
+	// handle drain only if the plugin request drain, or we are already in a draining request state,
+	// a request the operator still holds or failed to drain is withdrawn by the 'Idle' annotation once
+	// the configuration is applied
+	if reqDrain || !(utils.ObjectHasAnnotation(dn.desiredNodeState,
+		consts.NodeStateDrainAnnotationCurrent,
+		consts.DrainIdle) || dn.canWithdrawDrainRequest()) {
+		drainInProcess, err := dn.handleDrain(reqReboot, scope)
+		if err != nil {
+			log.Log.Error(err, "failed to handle drain")
+			return err
+		}
+		if drainInProcess {
+			// the request is kept for the operator to retry the drain, the failure is reported until then
+			if utils.ObjectHasAnnotation(dn.desiredNodeState, consts.NodeStateDrainAnnotationCurrent, consts.DrainFailed) {
+				return fmt.Errorf("the operator failed to drain the node, the drain is retried after a backoff or a configuration change")
 			}
+			return nil
 		}
 	}
 
+	// apply the vendor plugins after we are done with drain if needed, the generic and the virtual plugins
+	// are applied only if we don't need to reboot, or we are not doing the configuration in systemd
+	if err := ApplyPlugins(dn.loadedPlugins, reqReboot || vars.UsingSystemdMode); err != nil {
+		return err
+	}
+
 	if reqReboot {
 		log.Log.Info("nodeStateSyncHandler(): reboot node")
 		dn.eventRecorder.SendEvent("RebootNode", "Reboot node has been initiated")
+		reboots.Inc()
 		dn.rebootNode()
 		return nil
 	}
@@ -528,6 +625,10 @@ func (dn *Daemon) nodeStateSyncHandler() error {
 		log.Log.Error(err, "nodeStateSyncHandler(): fail to restart device plugin pod")
 		return err
 	}
+	if err := dn.resumeDevicePlugin(); err != nil {
+		log.Log.Error(err, "nodeStateSyncHandler(): failed to resume the device plugin")
+		return err
+	}
 
 	log.Log.Info("nodeStateSyncHandler(): apply 'Idle' annotation for node")
 	err = utils.AnnotateNode(context.Background(), vars.NodeName, consts.NodeDrainAnnotation, consts.DrainIdle, dn.client)
@@ -536,6 +637,11 @@ func (dn *Daemon) nodeStateSyncHandler() error {
 		return err
 	}
 
+	if err := dn.publishDrainScope(nil); err != nil {
+		log.Log.Error(err, "nodeStateSyncHandler(): failed to remove the drain scope")
+		return err
+	}
+
 	log.Log.Info("nodeStateSyncHandler(): apply 'Idle' annotation for nodeState")
 	if err := utils.AnnotateObject(context.Background(), dn.desiredNodeState,
 		consts.NodeStateDrainAnnotation,
@@ -545,15 +651,18 @@ func (dn *Daemon) nodeStateSyncHandler() error {
 
 	log.Log.Info("nodeStateSyncHandler(): sync succeeded")
 	dn.currentNodeState = dn.desiredNodeState.DeepCopy()
+	appliedGeneration.Set(float64(dn.currentNodeState.GetGeneration()))
 	if vars.UsingSystemdMode {
 		dn.refreshCh <- Message{
 			syncStatus:    sriovResult.SyncStatus,
 			lastSyncError: sriovResult.LastSyncError,
+			generation:    dn.appliedGeneration(),
 		}
 	} else {
 		dn.refreshCh <- Message{
 			syncStatus:    consts.SyncStatusSucceeded,
 			lastSyncError: "",
+			generation:    dn.appliedGeneration(),
 		}
 	}
 	// wait for writer to refresh the status
@@ -561,6 +670,14 @@ func (dn *Daemon) nodeStateSyncHandler() error {
 	return nil
 }
 
+// appliedGeneration returns the generation of the node state spec the daemon is applying, 0 if it is not known yet
+func (dn *Daemon) appliedGeneration() int64 {
+	if dn.desiredNodeState == nil {
+		return 0
+	}
+	return dn.desiredNodeState.GetGeneration()
+}
+
 func (dn *Daemon) shouldSkipReconciliation(latestState *sriovnetworkv1.SriovNetworkNodeState) (bool, error) {
 	log.Log.V(0).Info("shouldSkipReconciliation()")
 	var err error
@@ -580,6 +697,7 @@ func (dn *Daemon) shouldSkipReconciliation(latestState *sriovnetworkv1.SriovNetw
 			dn.refreshCh <- Message{
 				syncStatus:    consts.SyncStatusSucceeded,
 				lastSyncError: "",
+				generation:    latestState.GetGeneration(),
 			}
 			// wait for writer to refresh status
 			<-dn.syncCh
@@ -609,6 +727,7 @@ func (dn *Daemon) shouldSkipReconciliation(latestState *sriovnetworkv1.SriovNetw
 			dn.refreshCh <- Message{
 				syncStatus:    consts.SyncStatusSucceeded,
 				lastSyncError: "",
+				generation:    latestState.GetGeneration(),
 			}
 			// wait for writer to refresh the status
 			<-dn.syncCh
@@ -622,10 +741,15 @@ func (dn *Daemon) shouldSkipReconciliation(latestState *sriovnetworkv1.SriovNetw
 
 // handleDrain: adds the right annotation to the node and nodeState object
 // returns true if we need to finish the reconcile loop and wait for a new object
-func (dn *Daemon) handleDrain(reqReboot bool) (bool, error) {
+// the scope of the drain is published before the request, nil requests a drain of all the SR-IOV consumers
+func (dn *Daemon) handleDrain(reqReboot bool, scope *drainScope) (bool, error) {
 	// done with the drain we can continue with the configuration
 	if utils.ObjectHasAnnotation(dn.desiredNodeState, consts.NodeStateDrainAnnotationCurrent, consts.DrainComplete) {
 		log.Log.Info("handleDrain(): the node complete the draining")
+		if !dn.drainRequestedAt.IsZero() {
+			drainWaitDuration.Observe(time.Since(dn.drainRequestedAt).Seconds())
+			dn.drainRequestedAt = time.Time{}
+		}
 		return false, nil
 	}
 
@@ -641,6 +765,12 @@ func (dn *Daemon) handleDrain(reqReboot bool) (bool, error) {
 		return false, nil
 	}
 
+	if err := dn.publishDrainScope(scope); err != nil {
+		log.Log.Error(err, "handleDrain(): failed to publish the drain scope")
+		return false, err
+	}
+	previousRequest := dn.desiredNodeState.GetAnnotations()[consts.NodeStateDrainAnnotation]
+
 	if reqReboot {
 		log.Log.Info("handleDrain(): apply 'Reboot_Required' annotation for node")
 		err := utils.AnnotateNode(context.Background(), vars.NodeName, consts.NodeDrainAnnotation, consts.RebootRequired, dn.client)
@@ -657,6 +787,7 @@ func (dn *Daemon) handleDrain(reqReboot bool) (bool, error) {
 		}
 
 		// the node was annotated we need to wait for the operator to finish the drain
+		dn.drainRequested(previousRequest)
 		return true, nil
 	}
 	log.Log.Info("handleDrain(): apply 'Drain_Required' annotation for node")
@@ -674,9 +805,33 @@ func (dn *Daemon) handleDrain(reqReboot bool) (bool, error) {
 	}
 
 	// the node was annotated we need to wait for the operator to finish the drain
+	dn.drainRequested(previousRequest)
 	return true, nil
 }
 
+// drainRequested records a new drain request, the request is repeated on every sync while the operator
+// holds or performs the drain and only the first one is counted
+func (dn *Daemon) drainRequested(previousRequest string) {
+	if previousRequest == consts.DrainRequired || previousRequest == consts.RebootRequired {
+		return
+	}
+	drainRequests.Inc()
+	dn.drainRequestedAt = time.Now()
+}
+
+// observeSync records the duration and the result of a node state synchronization
+func (dn *Daemon) observeSync(start time.Time, err error) {
+	syncDuration.Observe(time.Since(start).Seconds())
+	status := consts.SyncStatusSucceeded
+	if err != nil {
+		status = consts.SyncStatusFailed
+	} else if dn.currentNodeState.GetGeneration() != dn.desiredNodeState.GetGeneration() {
+		// the sync is waiting for a drain or a reboot
+		status = consts.SyncStatusInProgress
+	}
+	syncResults.WithLabelValues(status).Inc()
+}
+
 func (dn *Daemon) restartDevicePluginPod() error {
 	dn.mu.Lock()
 	defer dn.mu.Unlock()
@@ -778,3 +933,11 @@ func (dn *Daemon) prepareNMUdevRule() error {
 func (dn *Daemon) isDrainCompleted() bool {
 	return utils.ObjectHasAnnotation(dn.desiredNodeState, consts.NodeStateDrainAnnotationCurrent, consts.DrainComplete)
 }
+
+// canWithdrawDrainRequest returns true if the operator holds the drain request until a maintenance window of the pool
+// or an approval of the node, or failed to drain the node
+func (dn *Daemon) canWithdrawDrainRequest() bool {
+	return utils.ObjectHasAnnotation(dn.desiredNodeState, consts.NodeStateDrainAnnotationCurrent, consts.DrainPending) ||
+		utils.ObjectHasAnnotation(dn.desiredNodeState, consts.NodeStateDrainAnnotationCurrent, consts.DrainApprovalPending) ||
+		utils.ObjectHasAnnotation(dn.desiredNodeState, consts.NodeStateDrainAnnotationCurrent, consts.DrainFailed)
+}
@@ -3,16 +3,20 @@ package daemon
 import (
 	"context"
 	"flag"
+	"fmt"
+	"sync/atomic"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"go.uber.org/zap/zapcore"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	fakek8s "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -28,6 +32,8 @@ import (
 	plugin "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins/fake"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins/generic"
+	mock_plugins "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/plugins/mock"
+	mock_podresources "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/podresources/mock"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/test/util/fakefilesystem"
 )
@@ -63,6 +69,21 @@ var _ = Describe("Config Daemon", func() {
 
 	var sut *Daemon
 
+	// receiveStatus waits for the daemon to report the sync status
+	receiveStatus := func(status string) Message {
+		var msg Message
+		for msg.syncStatus != status {
+			EventuallyWithOffset(1, refreshCh, "10s").Should(Receive(&msg))
+		}
+		return msg
+	}
+
+	devicePluginLabel := func() string {
+		node := &corev1.Node{}
+		ExpectWithOffset(1, sut.client.Get(context.Background(), client.ObjectKey{Name: "test-node"}, node)).To(Succeed())
+		return node.Labels[consts.SriovDevicePluginLabel]
+	}
+
 	BeforeEach(func() {
 		stopCh = make(chan struct{})
 		refreshCh = make(chan Message)
@@ -111,8 +132,10 @@ var _ = Describe("Config Daemon", func() {
 
 		err = sriovnetworkv1.AddToScheme(scheme.Scheme)
 		Expect(err).ToNot(HaveOccurred())
+		// the device plugin was left paused by a previous run of the daemon
 		kClient := kclient.NewClientBuilder().WithScheme(scheme.Scheme).WithRuntimeObjects(&corev1.Node{
-			ObjectMeta: metav1.ObjectMeta{Name: "test-node"}},
+			ObjectMeta: metav1.ObjectMeta{Name: "test-node", Labels: map[string]string{
+				consts.SriovDevicePluginLabel: consts.SriovDevicePluginLabelPaused}}},
 			&sriovnetworkv1.SriovNetworkNodeState{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-node",
@@ -292,6 +315,166 @@ var _ = Describe("Config Daemon", func() {
 			Expect(sut.desiredNodeState.GetGeneration()).To(BeNumerically("==", 777))
 		})
 
+		It("report the sync metrics", func() {
+			succeeded := testutil.ToFloat64(syncResults.WithLabelValues(consts.SyncStatusSucceeded))
+
+			nodeState := &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-node",
+					Generation:  42,
+					Annotations: map[string]string{consts.NodeStateDrainAnnotationCurrent: consts.DrainIdle},
+				},
+			}
+			Expect(createSriovNetworkNodeState(sut.sriovClient, nodeState)).To(BeNil())
+
+			var msg Message
+			Eventually(refreshCh, "10s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("InProgress"))
+			Eventually(refreshCh, "10s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("Succeeded"))
+
+			Eventually(func() float64 {
+				return testutil.ToFloat64(syncResults.WithLabelValues(consts.SyncStatusSucceeded))
+			}, "10s").Should(Equal(succeeded + 1))
+			Expect(testutil.ToFloat64(desiredGeneration)).To(Equal(float64(42)))
+			Expect(testutil.ToFloat64(appliedGeneration)).To(Equal(float64(42)))
+			// OnNodeStateChange and Apply of the generic plugin
+			Expect(testutil.CollectAndCount(pluginDuration)).To(Equal(2))
+		})
+
+		DescribeTable("withdraw a held drain request the configuration doesn't need anymore", func(currentState string) {
+			nodeState := &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test-node",
+					Generation: 5,
+					Annotations: map[string]string{
+						consts.NodeStateDrainAnnotation:        consts.DrainRequired,
+						consts.NodeStateDrainAnnotationCurrent: currentState,
+					},
+				},
+			}
+			Expect(createSriovNetworkNodeState(sut.sriovClient, nodeState)).To(BeNil())
+
+			var msg Message
+			Eventually(refreshCh, "10s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("InProgress"))
+			Eventually(refreshCh, "10s").Should(Receive(&msg))
+			Expect(msg.syncStatus).To(Equal("Succeeded"))
+
+			// the configuration is applied and the request is withdrawn
+			Expect(sut.currentNodeState.GetGeneration()).To(BeNumerically("==", 5))
+			node := &corev1.Node{}
+			Expect(sut.client.Get(context.Background(), client.ObjectKey{Name: "test-node"}, node)).To(Succeed())
+			Expect(node.Annotations).To(HaveKeyWithValue(consts.NodeDrainAnnotation, consts.DrainIdle))
+			updated := &sriovnetworkv1.SriovNetworkNodeState{}
+			Expect(sut.client.Get(context.Background(), client.ObjectKey{Name: "test-node", Namespace: vars.Namespace}, updated)).To(Succeed())
+			Expect(updated.Annotations).To(HaveKeyWithValue(consts.NodeStateDrainAnnotation, consts.DrainIdle))
+		},
+			Entry("until a maintenance window", consts.DrainPending),
+			Entry("until an approval", consts.DrainApprovalPending),
+			Entry("after a failed drain", consts.DrainFailed),
+		)
+
+		It("report a failed drain until the request is withdrawn", func() {
+			var drainRequired atomic.Bool
+			drainRequired.Store(true)
+			genericPlugin := mock_plugins.NewMockVendorPlugin(gomock.NewController(GinkgoT()))
+			genericPlugin.EXPECT().Name().Return(generic.PluginName).AnyTimes()
+			genericPlugin.EXPECT().OnNodeStateChange(gomock.Any()).DoAndReturn(
+				func(*sriovnetworkv1.SriovNetworkNodeState) (bool, bool, error) {
+					return drainRequired.Load(), false, nil
+				}).AnyTimes()
+			genericPlugin.EXPECT().Apply().DoAndReturn(func() error {
+				defer GinkgoRecover()
+				Expect(drainRequired.Load()).To(BeFalse(), "the configuration is applied before the drain")
+				return nil
+			}).AnyTimes()
+			sut.loadedPlugins = map[string]plugin.VendorPlugin{generic.PluginName: genericPlugin}
+
+			nodeState := &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test-node",
+					Generation: 6,
+					Annotations: map[string]string{
+						consts.NodeStateDrainAnnotation:        consts.DrainRequired,
+						consts.NodeStateDrainAnnotationCurrent: consts.DrainFailed,
+					},
+				},
+			}
+			Expect(createSriovNetworkNodeState(sut.sriovClient, nodeState)).To(BeNil())
+
+			msg := receiveStatus(consts.SyncStatusFailed)
+			Expect(msg.lastSyncError).To(ContainSubstring("failed to drain the node"))
+
+			// the configuration doesn't need the drain anymore, it is applied and the request is withdrawn
+			drainRequired.Store(false)
+			receiveStatus(consts.SyncStatusSucceeded)
+			updated := &sriovnetworkv1.SriovNetworkNodeState{}
+			Expect(sut.client.Get(context.Background(), client.ObjectKey{Name: "test-node", Namespace: vars.Namespace}, updated)).To(Succeed())
+			Expect(updated.Annotations).To(HaveKeyWithValue(consts.NodeStateDrainAnnotation, consts.DrainIdle))
+		})
+
+		It("resume the device plugin on startup", func() {
+			Eventually(devicePluginLabel, "10s").Should(Equal(consts.SriovDevicePluginLabelEnabled))
+
+			// wait for the daemon to be started before stopping it
+			nodeState := &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-node",
+					Generation:  2,
+					Annotations: map[string]string{consts.NodeStateDrainAnnotationCurrent: consts.DrainIdle},
+				},
+			}
+			Expect(createSriovNetworkNodeState(sut.sriovClient, nodeState)).To(BeNil())
+			receiveStatus(consts.SyncStatusSucceeded)
+			Expect(devicePluginLabel()).To(Equal(consts.SriovDevicePluginLabelEnabled))
+		})
+
+		It("resume the device plugin when the sync fails after skipping the drain", func() {
+			Eventually(devicePluginLabel, "10s").Should(Equal(consts.SriovDevicePluginLabelEnabled))
+			sut.featureGate.Init(map[string]bool{consts.SkipUnusedVfsDrainFeatureGate: true})
+			podResources := mock_podresources.NewMockInterface(gomock.NewController(GinkgoT()))
+			podResources.EXPECT().AllocatedDevices(gomock.Any()).Return(map[string]string{}, nil).AnyTimes()
+			sut.podResources = podResources
+			sut.HostHelpers.(*mock_helper.MockHostHelpersInterface).EXPECT().LoadPfsStatus("0000:86:00.0").
+				Return(&sriovnetworkv1.Interface{PciAddress: "0000:86:00.0"}, true, nil).AnyTimes()
+
+			var applyFailed atomic.Bool
+			genericPlugin := mock_plugins.NewMockVendorPlugin(gomock.NewController(GinkgoT()))
+			genericPlugin.EXPECT().Name().Return(generic.PluginName).AnyTimes()
+			genericPlugin.EXPECT().OnNodeStateChange(gomock.Any()).Return(true, false, nil).AnyTimes()
+			genericPlugin.EXPECT().Apply().DoAndReturn(func() error {
+				defer GinkgoRecover()
+				Expect(devicePluginLabel()).To(Equal(consts.SriovDevicePluginLabelPaused))
+				if applyFailed.CompareAndSwap(false, true) {
+					return fmt.Errorf("apply failed")
+				}
+				return nil
+			}).Times(2)
+			sut.loadedPlugins = map[string]plugin.VendorPlugin{generic.PluginName: genericPlugin}
+
+			nodeState := &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-node",
+					Generation:  7,
+					Annotations: map[string]string{consts.NodeStateDrainAnnotationCurrent: consts.DrainIdle},
+				},
+				Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{Interfaces: sriovnetworkv1.Interfaces{
+					{PciAddress: "0000:86:00.0", NumVfs: 2},
+				}},
+				Status: sriovnetworkv1.SriovNetworkNodeStateStatus{Interfaces: sriovnetworkv1.InterfaceExts{
+					{PciAddress: "0000:86:00.0", NumVfs: 4, TotalVfs: 8},
+				}},
+			}
+			Expect(createSriovNetworkNodeState(sut.sriovClient, nodeState)).To(BeNil())
+
+			// the failed sync resumes the device plugin, which is paused again by the next one
+			receiveStatus(consts.SyncStatusFailed)
+			Expect(devicePluginLabel()).To(Equal(consts.SriovDevicePluginLabelEnabled))
+			receiveStatus(consts.SyncStatusSucceeded)
+			Expect(devicePluginLabel()).To(Equal(consts.SriovDevicePluginLabelEnabled))
+		})
+
 		It("restart all the sriov-device-plugin pods present on the node", func() {
 			otherPod1 := SriovDevicePluginPod.DeepCopy()
 			otherPod1.Name = "sriov-device-plugin-xxxa"
@@ -0,0 +1,215 @@
+package daemon
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/utils"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+// drainScope lists the PFs reconfigured by a drain and the resource names their VFs are exposed with
+type drainScope struct {
+	pfs       []string
+	resources []string
+	// PCI addresses of the current VFs of the PFs
+	vfs []string
+}
+
+// computeDrainScope returns the scope of the drain requested by the plugins, nil when the drain can't be
+// restricted to the pods consuming the VFs of specific PFs
+func (dn *Daemon) computeDrainScope(drainRequesters []string, reqReboot bool) *drainScope {
+	// the drain reasons of the other plugins are not known
+	if reqReboot || len(drainRequesters) != 1 || drainRequesters[0] != GenericPluginName {
+		return nil
+	}
+	desired := &dn.desiredNodeState.Spec
+	current := &dn.desiredNodeState.Status
+	if vars.ManageSoftwareBridges && sriovnetworkv1.NeedToUpdateBridges(&desired.Bridges, &current.Bridges) {
+		return nil
+	}
+
+	pfs := sriovnetworkv1.PfsToUpdateVFs(desired, current, func(pciAddress string) *sriovnetworkv1.Interface {
+		pfStatus, exist, err := dn.HostHelpers.LoadPfsStatus(pciAddress)
+		if err != nil || !exist {
+			return nil
+		}
+		return pfStatus
+	})
+	if len(pfs) == 0 {
+		return nil
+	}
+
+	resources := []string{}
+	vfs := []string{}
+	for _, pf := range pfs {
+		// the VFs are consumed with the resource names of the applied configuration, and with the
+		// ones of the desired configuration once the device plugin restarted
+		applied, exist, err := dn.HostHelpers.LoadPfsStatus(pf)
+		if err != nil || !exist {
+			log.Log.V(2).Info("computeDrainScope(): the applied configuration of the PF is unknown, the drain is not scoped",
+				"address", pf, "error", err)
+			return nil
+		}
+		groups := applied.VfGroups
+		if iface, err := sriovnetworkv1.FindInterfaceByPciAddress(desired.Interfaces, pf); err == nil {
+			groups = append(groups, iface.VfGroups...)
+		}
+		for _, group := range groups {
+			if group.ResourceName != "" {
+				resources = sriovnetworkv1.UniqueAppend(resources, vars.ResourcePrefix+"/"+group.ResourceName)
+			}
+		}
+		for _, ifaceStatus := range current.Interfaces {
+			if ifaceStatus.PciAddress != pf {
+				continue
+			}
+			for _, vf := range ifaceStatus.VFs {
+				vfs = append(vfs, vf.PciAddress)
+			}
+		}
+	}
+	// PFs without resources only have VFs consumed by the host
+	sort.Strings(resources)
+	return &drainScope{pfs: pfs, resources: resources, vfs: vfs}
+}
+
+// canSkipDrain returns true if none of the VFs reconfigured by a scoped drain is allocated to a pod, the device
+// plugin of the node is then paused until the configuration is applied so that the VFs can't be allocated
+func (dn *Daemon) canSkipDrain(scope *drainScope) (bool, error) {
+	if scope == nil || !dn.featureGate.IsEnabled(consts.SkipUnusedVfsDrainFeatureGate) {
+		return false, nil
+	}
+	allocated, err := dn.allocatedVfs(scope)
+	if err != nil {
+		log.Log.Error(err, "canSkipDrain(): failed to list the allocated VFs, the node is drained")
+		return false, nil
+	}
+	if len(allocated) > 0 {
+		log.Log.Info("canSkipDrain(): some of the reconfigured VFs are allocated", "vfs", allocated)
+		return false, nil
+	}
+
+	if err := dn.pauseDevicePlugin(); err != nil {
+		return false, err
+	}
+	// a VF can be allocated before the device plugin stops
+	allocated, err = dn.allocatedVfs(scope)
+	if err != nil || len(allocated) > 0 {
+		log.Log.Info("canSkipDrain(): some of the reconfigured VFs may have been allocated while pausing the device plugin",
+			"vfs", allocated, "error", err)
+		return false, dn.resumeDevicePlugin()
+	}
+	return true, nil
+}
+
+// allocatedVfs returns the VFs of the scope allocated to a pod, with the pod holding them
+func (dn *Daemon) allocatedVfs(scope *drainScope) (map[string]string, error) {
+	devices, err := dn.podResources.AllocatedDevices(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	allocated := map[string]string{}
+	for _, vf := range scope.vfs {
+		if pod, ok := devices[vf]; ok {
+			allocated[vf] = pod
+		}
+	}
+	return allocated, nil
+}
+
+// pauseDevicePlugin stops the device plugin of the node, the operator doesn't enable it again until it is resumed
+func (dn *Daemon) pauseDevicePlugin() error {
+	log.Log.Info("pauseDevicePlugin(): apply 'Paused' label for node")
+	err := utils.LabelNode(context.Background(), vars.NodeName, consts.SriovDevicePluginLabel, consts.SriovDevicePluginLabelPaused, dn.client)
+	if err != nil {
+		log.Log.Error(err, "pauseDevicePlugin(): failed to label node")
+		return err
+	}
+	// the pod is not recreated once the node is not selected by the device plugin daemonset anymore
+	return dn.restartDevicePluginPod()
+}
+
+// resumeDevicePlugin enables the device plugin of the node again if it was paused
+func (dn *Daemon) resumeDevicePlugin() error {
+	node := &corev1.Node{}
+	if err := dn.client.Get(context.Background(), client.ObjectKey{Name: vars.NodeName}, node); err != nil {
+		return err
+	}
+	if node.Labels[consts.SriovDevicePluginLabel] != consts.SriovDevicePluginLabelPaused {
+		return nil
+	}
+	log.Log.Info("resumeDevicePlugin(): apply 'Enabled' label for node")
+	return utils.LabelNode(context.Background(), vars.NodeName, consts.SriovDevicePluginLabel, consts.SriovDevicePluginLabelEnabled, dn.client)
+}
+
+// publishDrainScope annotates the node state with the PFs and the resources affected by the drain,
+// the annotations are removed when the drain is not scoped
+func (dn *Daemon) publishDrainScope(scope *drainScope) error {
+	if scope == nil {
+		for _, key := range []string{consts.NodeStateDrainPfsAnnotation, consts.NodeStateDrainResourcesAnnotation} {
+			if err := utils.RemoveAnnotationFromObject(context.Background(), dn.desiredNodeState, key, dn.client); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	log.Log.Info("publishDrainScope(): the drain is scoped", "pfs", scope.pfs, "resources", scope.resources)
+	if err := utils.AnnotateObject(context.Background(), dn.desiredNodeState,
+		consts.NodeStateDrainPfsAnnotation, strings.Join(scope.pfs, ","), dn.client); err != nil {
+		return err
+	}
+	return utils.AnnotateObject(context.Background(), dn.desiredNodeState,
+		consts.NodeStateDrainResourcesAnnotation, strings.Join(scope.resources, ","), dn.client)
+}
+
+// drainScopeCovers returns true if the completed drain evicted the consumers of the VFs of the scope,
+// the node was fully drained when the drain wasn't scoped
+func (dn *Daemon) drainScopeCovers(scope *drainScope) bool {
+	annotations := dn.desiredNodeState.GetAnnotations()
+	drainedPfs, ok := annotations[consts.NodeStateDrainPfsAnnotation]
+	if !ok {
+		return true
+	}
+	if scope == nil {
+		return false
+	}
+	return isSubset(scope.pfs, splitAnnotation(drainedPfs)) &&
+		isSubset(scope.resources, splitAnnotation(annotations[consts.NodeStateDrainResourcesAnnotation]))
+}
+
+// withdrawDrain moves the drain request back to idle so that the operator un-cordons the node,
+// the drain is requested again on the next sync
+func (dn *Daemon) withdrawDrain() error {
+	log.Log.Info("withdrawDrain(): apply 'Idle' annotation for node")
+	if err := utils.AnnotateNode(context.Background(), vars.NodeName, consts.NodeDrainAnnotation, consts.DrainIdle, dn.client); err != nil {
+		return err
+	}
+	log.Log.Info("withdrawDrain(): apply 'Idle' annotation for nodeState")
+	return utils.AnnotateObject(context.Background(), dn.desiredNodeState, consts.NodeStateDrainAnnotation, consts.DrainIdle, dn.client)
+}
+
+func splitAnnotation(value string) []string {
+	if value == "" {
+		return []string{}
+	}
+	return strings.Split(value, ",")
+}
+
+func isSubset(items, set []string) bool {
+	for _, item := range items {
+		if !slices.Contains(set, item) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,146 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/featuregate"
+	mock_helper "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper/mock"
+	mock_podresources "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/podresources/mock"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+var _ = Describe("Drain scope", func() {
+	var (
+		hostHelper *mock_helper.MockHostHelpersInterface
+		dn         *Daemon
+	)
+
+	BeforeEach(func() {
+		origResourcePrefix := vars.ResourcePrefix
+		origManageSoftwareBridges := vars.ManageSoftwareBridges
+		DeferCleanup(func() {
+			vars.ResourcePrefix = origResourcePrefix
+			vars.ManageSoftwareBridges = origManageSoftwareBridges
+		})
+		vars.ResourcePrefix = "openshift.io"
+		vars.ManageSoftwareBridges = false
+
+		hostHelper = mock_helper.NewMockHostHelpersInterface(gomock.NewController(GinkgoT()))
+		dn = &Daemon{HostHelpers: hostHelper, desiredNodeState: &sriovnetworkv1.SriovNetworkNodeState{
+			Spec: sriovnetworkv1.SriovNetworkNodeStateSpec{Interfaces: sriovnetworkv1.Interfaces{
+				{PciAddress: "0000:d8:00.0", NumVfs: 8, VfGroups: []sriovnetworkv1.VfGroup{
+					{ResourceName: "intel_a", VfRange: "0-7"}}},
+				{PciAddress: "0000:d8:00.1", NumVfs: 4, VfGroups: []sriovnetworkv1.VfGroup{
+					{ResourceName: "intel_c", VfRange: "0-3"}}},
+			}},
+			Status: sriovnetworkv1.SriovNetworkNodeStateStatus{Interfaces: sriovnetworkv1.InterfaceExts{
+				{PciAddress: "0000:d8:00.0", NumVfs: 2, TotalVfs: 8, VFs: []sriovnetworkv1.VirtualFunction{
+					{VfID: 0, PciAddress: "0000:d8:02.0"}, {VfID: 1, PciAddress: "0000:d8:02.1"}}},
+				// the VFs of the second PF are created without a drain
+				{PciAddress: "0000:d8:00.1", NumVfs: 0, TotalVfs: 8},
+			}},
+		}}
+	})
+
+	It("lists the PFs whose VFs change with their applied and desired resources", func() {
+		hostHelper.EXPECT().LoadPfsStatus("0000:d8:00.0").Return(&sriovnetworkv1.Interface{
+			PciAddress: "0000:d8:00.0", NumVfs: 4, VfGroups: []sriovnetworkv1.VfGroup{
+				{ResourceName: "intel_a", VfRange: "0-1"}, {ResourceName: "intel_b", VfRange: "2-3"}}}, true, nil)
+
+		Expect(dn.computeDrainScope([]string{GenericPluginName}, false)).To(Equal(&drainScope{
+			pfs:       []string{"0000:d8:00.0"},
+			resources: []string{"openshift.io/intel_a", "openshift.io/intel_b"},
+			vfs:       []string{"0000:d8:02.0", "0000:d8:02.1"},
+		}))
+	})
+
+	It("is not scoped when the applied configuration of a PF is unknown", func() {
+		hostHelper.EXPECT().LoadPfsStatus("0000:d8:00.0").Return(nil, false, nil)
+
+		Expect(dn.computeDrainScope([]string{GenericPluginName}, false)).To(BeNil())
+	})
+
+	It("is not scoped for a reboot or when other plugins request the drain", func() {
+		Expect(dn.computeDrainScope([]string{GenericPluginName}, true)).To(BeNil())
+		Expect(dn.computeDrainScope([]string{GenericPluginName, "mellanox"}, false)).To(BeNil())
+	})
+
+	Context("skipping the drain", func() {
+		var (
+			podResources *mock_podresources.MockInterface
+			scope        *drainScope
+		)
+
+		devicePluginLabel := func() string {
+			node := &corev1.Node{}
+			ExpectWithOffset(1, dn.client.Get(context.Background(), client.ObjectKey{Name: vars.NodeName}, node)).To(Succeed())
+			return node.Labels[consts.SriovDevicePluginLabel]
+		}
+
+		BeforeEach(func() {
+			origNodeName := vars.NodeName
+			DeferCleanup(func() { vars.NodeName = origNodeName })
+			vars.NodeName = "test-node"
+
+			podResources = mock_podresources.NewMockInterface(gomock.NewController(GinkgoT()))
+			featureGate := featuregate.New()
+			featureGate.Init(map[string]bool{consts.SkipUnusedVfsDrainFeatureGate: true})
+			dn.podResources = podResources
+			dn.featureGate = featureGate
+			dn.mu = &sync.Mutex{}
+			dn.kubeClient = fakek8s.NewSimpleClientset()
+			dn.client = kclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(&corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-node", Labels: map[string]string{
+					consts.SriovDevicePluginLabel: consts.SriovDevicePluginLabelEnabled}},
+			}).Build()
+			scope = &drainScope{pfs: []string{"0000:d8:00.0"}, vfs: []string{"0000:d8:02.0", "0000:d8:02.1"}}
+		})
+
+		It("pauses the device plugin when none of the VFs is allocated", func() {
+			podResources.EXPECT().AllocatedDevices(gomock.Any()).Return(map[string]string{"0000:3b:02.0": "default/other"}, nil).Times(2)
+
+			Expect(dn.canSkipDrain(scope)).To(BeTrue())
+			Expect(devicePluginLabel()).To(Equal(consts.SriovDevicePluginLabelPaused))
+
+			Expect(dn.resumeDevicePlugin()).To(Succeed())
+			Expect(devicePluginLabel()).To(Equal(consts.SriovDevicePluginLabelEnabled))
+		})
+
+		It("drains the node when a VF is allocated", func() {
+			podResources.EXPECT().AllocatedDevices(gomock.Any()).Return(map[string]string{"0000:d8:02.1": "default/app"}, nil)
+
+			Expect(dn.canSkipDrain(scope)).To(BeFalse())
+			Expect(devicePluginLabel()).To(Equal(consts.SriovDevicePluginLabelEnabled))
+		})
+
+		It("resumes the device plugin when a VF is allocated while pausing it", func() {
+			gomock.InOrder(
+				podResources.EXPECT().AllocatedDevices(gomock.Any()).Return(map[string]string{}, nil),
+				podResources.EXPECT().AllocatedDevices(gomock.Any()).Return(nil, fmt.Errorf("connection refused")),
+			)
+
+			Expect(dn.canSkipDrain(scope)).To(BeFalse())
+			Expect(devicePluginLabel()).To(Equal(consts.SriovDevicePluginLabelEnabled))
+		})
+
+		It("drains the node when the feature gate is disabled", func() {
+			dn.featureGate = featuregate.New()
+
+			Expect(dn.canSkipDrain(scope)).To(BeFalse())
+		})
+	})
+})
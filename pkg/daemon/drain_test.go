@@ -0,0 +1,129 @@
+package daemon
+
+import (
+	"context"
+	"sync"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	mock_helper "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper/mock"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+var _ = Describe("Handle drain", func() {
+	var dn *Daemon
+
+	// setNodeState stores the node state with the given drain annotations and loads it as the desired one
+	setNodeState := func(annotations map[string]string) {
+		nodeState := &sriovnetworkv1.SriovNetworkNodeState{}
+		ExpectWithOffset(1, dn.client.Get(context.Background(),
+			client.ObjectKey{Namespace: vars.Namespace, Name: vars.NodeName}, nodeState)).To(Succeed())
+		nodeState.Annotations = annotations
+		ExpectWithOffset(1, dn.client.Update(context.Background(), nodeState)).To(Succeed())
+		dn.desiredNodeState = nodeState
+	}
+
+	nodeStateAnnotations := func() map[string]string {
+		nodeState := &sriovnetworkv1.SriovNetworkNodeState{}
+		ExpectWithOffset(1, dn.client.Get(context.Background(),
+			client.ObjectKey{Namespace: vars.Namespace, Name: vars.NodeName}, nodeState)).To(Succeed())
+		return nodeState.Annotations
+	}
+
+	BeforeEach(func() {
+		origNodeName := vars.NodeName
+		origNamespace := vars.Namespace
+		DeferCleanup(func() {
+			vars.NodeName = origNodeName
+			vars.Namespace = origNamespace
+		})
+		vars.NodeName = "test-node"
+		vars.Namespace = "sriov-network-operator"
+
+		Expect(sriovnetworkv1.AddToScheme(scheme.Scheme)).To(Succeed())
+		dn = &Daemon{
+			HostHelpers: mock_helper.NewMockHostHelpersInterface(gomock.NewController(GinkgoT())),
+			mu:          &sync.Mutex{},
+			client: kclient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+				&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: vars.NodeName}},
+				&sriovnetworkv1.SriovNetworkNodeState{ObjectMeta: metav1.ObjectMeta{Name: vars.NodeName, Namespace: vars.Namespace}},
+			).Build(),
+		}
+	})
+
+	It("counts a drain request once while the operator holds it", func() {
+		requests := testutil.ToFloat64(drainRequests)
+
+		setNodeState(map[string]string{
+			consts.NodeStateDrainAnnotation:        consts.DrainIdle,
+			consts.NodeStateDrainAnnotationCurrent: consts.DrainIdle,
+		})
+		Expect(dn.handleDrain(false, nil)).To(BeTrue())
+		Expect(nodeStateAnnotations()).To(HaveKeyWithValue(consts.NodeStateDrainAnnotation, consts.DrainRequired))
+		requestedAt := dn.drainRequestedAt
+		Expect(requestedAt.IsZero()).To(BeFalse())
+
+		// the next syncs repeat the request while the drain is pending
+		setNodeState(map[string]string{
+			consts.NodeStateDrainAnnotation:        consts.DrainRequired,
+			consts.NodeStateDrainAnnotationCurrent: consts.DrainPending,
+		})
+		Expect(dn.handleDrain(false, nil)).To(BeTrue())
+		Expect(dn.handleDrain(false, nil)).To(BeTrue())
+
+		Expect(testutil.ToFloat64(drainRequests)).To(Equal(requests + 1))
+		Expect(dn.drainRequestedAt).To(Equal(requestedAt))
+	})
+
+	DescribeTable("checks if the completed drain covers the scope of the configuration",
+		func(annotations map[string]string, scope *drainScope, covered bool) {
+			dn.desiredNodeState = &sriovnetworkv1.SriovNetworkNodeState{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}}
+			Expect(dn.drainScopeCovers(scope)).To(Equal(covered))
+		},
+		Entry("the node was fully drained", map[string]string{}, nil, true),
+		Entry("the drain is not scoped anymore", map[string]string{
+			consts.NodeStateDrainPfsAnnotation:       "0000:d8:00.0",
+			consts.NodeStateDrainResourcesAnnotation: "openshift.io/intel_a",
+		}, nil, false),
+		Entry("the scope didn't change", map[string]string{
+			consts.NodeStateDrainPfsAnnotation:       "0000:d8:00.0,0000:d8:00.1",
+			consts.NodeStateDrainResourcesAnnotation: "openshift.io/intel_a,openshift.io/intel_b",
+		}, &drainScope{pfs: []string{"0000:d8:00.1"}, resources: []string{"openshift.io/intel_b"}}, true),
+		Entry("the PFs have no resources", map[string]string{
+			consts.NodeStateDrainPfsAnnotation:       "0000:d8:00.0",
+			consts.NodeStateDrainResourcesAnnotation: "",
+		}, &drainScope{pfs: []string{"0000:d8:00.0"}, resources: []string{}}, true),
+		Entry("another PF is reconfigured", map[string]string{
+			consts.NodeStateDrainPfsAnnotation:       "0000:d8:00.0",
+			consts.NodeStateDrainResourcesAnnotation: "openshift.io/intel_a",
+		}, &drainScope{pfs: []string{"0000:d8:00.0", "0000:d8:00.1"}, resources: []string{"openshift.io/intel_a"}}, false),
+		Entry("the VFs are exposed with another resource", map[string]string{
+			consts.NodeStateDrainPfsAnnotation:       "0000:d8:00.0",
+			consts.NodeStateDrainResourcesAnnotation: "",
+		}, &drainScope{pfs: []string{"0000:d8:00.0"}, resources: []string{"openshift.io/intel_b"}}, false),
+	)
+
+	It("withdraws the drain to request it again", func() {
+		setNodeState(map[string]string{
+			consts.NodeStateDrainAnnotation:        consts.DrainRequired,
+			consts.NodeStateDrainAnnotationCurrent: consts.DrainComplete,
+		})
+
+		Expect(dn.withdrawDrain()).To(Succeed())
+
+		node := &corev1.Node{}
+		Expect(dn.client.Get(context.Background(), client.ObjectKey{Name: vars.NodeName}, node)).To(Succeed())
+		Expect(node.Annotations).To(HaveKeyWithValue(consts.NodeDrainAnnotation, consts.DrainIdle))
+		Expect(nodeStateAnnotations()).To(HaveKeyWithValue(consts.NodeStateDrainAnnotation, consts.DrainIdle))
+	})
+})
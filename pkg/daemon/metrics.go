@@ -0,0 +1,146 @@
+package daemon
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+)
+
+const (
+	metricsNamespace = "sriov"
+	metricsSubsystem = "config_daemon"
+
+	pluginOperationOnNodeStateChange = "OnNodeStateChange"
+	pluginOperationApply             = "Apply"
+)
+
+var (
+	// metricsRegistry holds the config daemon metrics, it is separated from the default registry
+	// to expose only the metrics of the daemon and of the Go runtime
+	metricsRegistry = prometheus.NewRegistry()
+
+	syncDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "sync_duration_seconds",
+		Help:      "Duration of the node state synchronizations",
+		Buckets:   []float64{0.1, 0.5, 1, 5, 10, 30, 60, 120, 300, 600},
+	})
+	syncResults = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "sync_results_total",
+		Help:      "Number of node state synchronizations by resulting sync status",
+	}, []string{"status"})
+	syncStatus = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "sync_status",
+		Help:      "Sync status reported in the node state, 1 for the current status and 0 for the others",
+	}, []string{"status"})
+	pluginDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "plugin_duration_seconds",
+		Help:      "Duration of the plugin calls",
+		Buckets:   []float64{0.01, 0.1, 0.5, 1, 5, 10, 30, 60, 120, 300},
+	}, []string{"plugin", "operation"})
+	pluginErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "plugin_errors_total",
+		Help:      "Number of errors returned by the plugin calls",
+	}, []string{"plugin", "operation"})
+	drainRequests = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "drain_requests_total",
+		Help:      "Number of drains requested to the operator",
+	})
+	reboots = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "reboots_total",
+		Help:      "Number of node reboots triggered by the config daemon",
+	})
+	drainWaitDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "drain_wait_duration_seconds",
+		Help:      "Time spent waiting for the operator to drain the node",
+		Buckets:   []float64{10, 30, 60, 120, 300, 600, 1200, 1800, 3600, 7200},
+	})
+	desiredGeneration = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "desired_generation",
+		Help:      "Generation of the node state spec the config daemon is working on",
+	})
+	appliedGeneration = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "applied_generation",
+		Help:      "Generation of the last node state spec applied by the config daemon",
+	})
+)
+
+func init() {
+	metricsRegistry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		syncDuration,
+		syncResults,
+		syncStatus,
+		pluginDuration,
+		pluginErrors,
+		drainRequests,
+		reboots,
+		drainWaitDuration,
+		desiredGeneration,
+		appliedGeneration,
+	)
+}
+
+// RunMetricsServer serves the config daemon metrics on the address until the stop channel is closed
+func RunMetricsServer(address string, stopCh <-chan struct{}) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{}))
+	server := &http.Server{Addr: address, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		<-stopCh
+		if err := server.Close(); err != nil {
+			log.Log.Error(err, "RunMetricsServer(): failed to stop the metrics server")
+		}
+	}()
+
+	log.Log.Info("RunMetricsServer(): serving metrics", "address", address)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Log.Error(err, "RunMetricsServer(): metrics server failed")
+	}
+}
+
+// setSyncStatusMetric reports the sync status written in the node state
+func setSyncStatusMetric(status string) {
+	for _, s := range []string{consts.SyncStatusSucceeded, consts.SyncStatusInProgress, consts.SyncStatusFailed} {
+		value := 0.0
+		if s == status {
+			value = 1
+		}
+		syncStatus.WithLabelValues(s).Set(value)
+	}
+}
+
+// observePluginCall records the duration and the error of a plugin call
+func observePluginCall(plugin, operation string, start time.Time, err error) {
+	pluginDuration.WithLabelValues(plugin, operation).Observe(time.Since(start).Seconds())
+	if err != nil {
+		pluginErrors.WithLabelValues(plugin, operation).Inc()
+	}
+}
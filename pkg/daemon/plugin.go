@@ -2,6 +2,7 @@ package daemon
 
 import (
 	"fmt"
+	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -30,14 +31,15 @@ var (
 	K8sPlugin         = k8splugin.NewK8sPlugin
 )
 
-func loadPlugins(ns *sriovnetworkv1.SriovNetworkNodeState, helpers helper.HostHelpersInterface, disabledPlugins []string) (map[string]plugin.VendorPlugin, error) {
-	log.Log.Info("loadPlugins(): loading plugins")
+// LoadPlugins returns the plugins to run for the platform and the NICs of the node state, without the disabled ones
+func LoadPlugins(ns *sriovnetworkv1.SriovNetworkNodeState, helpers helper.HostHelpersInterface, disabledPlugins []string) (map[string]plugin.VendorPlugin, error) {
+	log.Log.Info("LoadPlugins(): loading plugins")
 	loadedPlugins := map[string]plugin.VendorPlugin{}
 
 	if vars.PlatformType == consts.VirtualOpenStack {
 		virtualPlugin, err := VirtualPlugin(helpers)
 		if err != nil {
-			log.Log.Error(err, "loadPlugins(): failed to load the virtual plugin")
+			log.Log.Error(err, "LoadPlugins(): failed to load the virtual plugin")
 			return nil, err
 		}
 		pluginName := virtualPlugin.Name()
@@ -54,7 +56,7 @@ func loadPlugins(ns *sriovnetworkv1.SriovNetworkNodeState, helpers helper.HostHe
 		if vars.ClusterType != consts.ClusterTypeOpenshift {
 			k8sPlugin, err := K8sPlugin(helpers)
 			if err != nil {
-				log.Log.Error(err, "loadPlugins(): failed to load the k8s plugin")
+				log.Log.Error(err, "LoadPlugins(): failed to load the k8s plugin")
 				return nil, err
 			}
 
@@ -65,7 +67,7 @@ func loadPlugins(ns *sriovnetworkv1.SriovNetworkNodeState, helpers helper.HostHe
 		}
 		genericPlugin, err := GenericPlugin(helpers)
 		if err != nil {
-			log.Log.Error(err, "loadPlugins(): failed to load the generic plugin")
+			log.Log.Error(err, "LoadPlugins(): failed to load the generic plugin")
 			return nil, err
 		}
 		pluginName := genericPlugin.Name()
@@ -78,10 +80,56 @@ func loadPlugins(ns *sriovnetworkv1.SriovNetworkNodeState, helpers helper.HostHe
 	for pluginName := range loadedPlugins {
 		pluginList = append(pluginList, pluginName)
 	}
-	log.Log.Info("loadPlugins(): loaded plugins", "plugins", pluginList)
+	log.Log.Info("LoadPlugins(): loaded plugins", "plugins", pluginList)
 	return loadedPlugins, nil
 }
 
+// ApplyPlugins applies the vendor plugins, then the generic and the virtual plugins unless skipGenericPlugins is set
+func ApplyPlugins(plugins map[string]plugin.VendorPlugin, skipGenericPlugins bool) error {
+	for k, p := range plugins {
+		// Skip both the general and virtual plugin apply them last
+		if k != GenericPluginName && k != VirtualPluginName {
+			start := time.Now()
+			err := p.Apply()
+			observePluginCall(k, pluginOperationApply, start, err)
+			if err != nil {
+				log.Log.Error(err, "ApplyPlugins(): plugin Apply failed", "plugin-name", k)
+				return &pluginError{plugin: k, err: err}
+			}
+		}
+	}
+	if skipGenericPlugins {
+		return nil
+	}
+
+	// For BareMetal machines apply the generic plugin
+	selectedPlugin, ok := plugins[GenericPluginName]
+	if ok {
+		// Apply generic plugin last
+		start := time.Now()
+		err := selectedPlugin.Apply()
+		observePluginCall(GenericPluginName, pluginOperationApply, start, err)
+		if err != nil {
+			log.Log.Error(err, "ApplyPlugins(): generic plugin fail to apply")
+			return &pluginError{plugin: GenericPluginName, err: err}
+		}
+	}
+
+	// For Virtual machines apply the virtual plugin
+	selectedPlugin, ok = plugins[VirtualPluginName]
+	if ok {
+		// Apply virtual plugin last
+		start := time.Now()
+		err := selectedPlugin.Apply()
+		observePluginCall(VirtualPluginName, pluginOperationApply, start, err)
+		if err != nil {
+			log.Log.Error(err, "ApplyPlugins(): virtual plugin failed to apply")
+			return &pluginError{plugin: VirtualPluginName, err: err}
+		}
+	}
+	return nil
+}
+
 func loadVendorPlugins(ns *sriovnetworkv1.SriovNetworkNodeState, helpers helper.HostHelpersInterface, disabledPlugins []string) (map[string]plugin.VendorPlugin, error) {
 	vendorPlugins := map[string]plugin.VendorPlugin{}
 
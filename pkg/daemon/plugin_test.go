@@ -24,7 +24,7 @@ func validateVendorPlugins(loadedPlugins map[string]plugin.VendorPlugin, expecte
 }
 
 var _ = Describe("config daemon plugin loading tests", func() {
-	Context("loadPlugins", func() {
+	Context("LoadPlugins", func() {
 		var gmockController *gomock.Controller
 		var helperMock *helperMocks.MockHostHelpersInterface
 
@@ -64,7 +64,7 @@ var _ = Describe("config daemon plugin loading tests", func() {
 						v1.InterfaceExt{Vendor: "8086"}},
 				},
 			}
-			vendorPlugins, err := loadPlugins(ns, helperMock, nil)
+			vendorPlugins, err := LoadPlugins(ns, helperMock, nil)
 
 			Expect(err).ToNot(HaveOccurred())
 			validateVendorPlugins(vendorPlugins, []string{"mellanox", "intel", "generic", "k8s"})
@@ -79,7 +79,7 @@ var _ = Describe("config daemon plugin loading tests", func() {
 						v1.InterfaceExt{Vendor: "8086"}},
 				},
 			}
-			vendorPlugins, err := loadPlugins(ns, helperMock, nil)
+			vendorPlugins, err := LoadPlugins(ns, helperMock, nil)
 
 			Expect(err).ToNot(HaveOccurred())
 			validateVendorPlugins(vendorPlugins, []string{"mellanox", "intel", "generic"})
@@ -94,7 +94,7 @@ var _ = Describe("config daemon plugin loading tests", func() {
 						v1.InterfaceExt{Vendor: "8086"}},
 				},
 			}
-			vendorPlugins, err := loadPlugins(ns, helperMock, nil)
+			vendorPlugins, err := LoadPlugins(ns, helperMock, nil)
 
 			Expect(err).ToNot(HaveOccurred())
 			validateVendorPlugins(vendorPlugins, []string{"virtual"})
@@ -107,7 +107,7 @@ var _ = Describe("config daemon plugin loading tests", func() {
 						v1.InterfaceExt{Vendor: "8086"}},
 				},
 			}
-			vendorPlugins, err := loadPlugins(ns, helperMock, nil)
+			vendorPlugins, err := LoadPlugins(ns, helperMock, nil)
 
 			Expect(err).ToNot(HaveOccurred())
 			validateVendorPlugins(vendorPlugins, []string{"intel", "generic", "k8s"})
@@ -121,7 +121,7 @@ var _ = Describe("config daemon plugin loading tests", func() {
 						v1.InterfaceExt{Vendor: "8086"}},
 				},
 			}
-			vendorPlugins, err := loadPlugins(ns, helperMock, []string{"mellanox"})
+			vendorPlugins, err := LoadPlugins(ns, helperMock, []string{"mellanox"})
 
 			Expect(err).ToNot(HaveOccurred())
 			validateVendorPlugins(vendorPlugins, []string{"intel", "generic", "k8s"})
@@ -135,7 +135,7 @@ var _ = Describe("config daemon plugin loading tests", func() {
 						v1.InterfaceExt{Vendor: "8086"}},
 				},
 			}
-			vendorPlugins, err := loadPlugins(ns, helperMock, []string{"generic"})
+			vendorPlugins, err := LoadPlugins(ns, helperMock, []string{"generic"})
 
 			Expect(err).ToNot(HaveOccurred())
 			validateVendorPlugins(vendorPlugins, []string{"intel", "k8s", "mellanox"})
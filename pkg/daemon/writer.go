@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/retry"
@@ -195,6 +197,8 @@ func (w *NodeStateStatusWriter) setNodeStateStatus(msg Message) (*sriovnetworkv1
 			nodeState.Status.LastSyncError = msg.lastSyncError
 		}
 		nodeState.Status.SyncStatus = msg.syncStatus
+		updateNodeStateConditions(nodeState, msg)
+		setSyncStatusMetric(msg.syncStatus)
 
 		log.Log.V(0).Info("setNodeStateStatus(): status",
 			"sync-status", nodeState.Status.SyncStatus,
@@ -206,6 +210,91 @@ func (w *NodeStateStatusWriter) setNodeStateStatus(msg Message) (*sriovnetworkv1
 	return nodeState, nil
 }
 
+// updateNodeStateConditions sets the conditions of the node state according to the message
+// received from the daemon, SyncStatus and LastSyncError are kept for backward compatibility
+func updateNodeStateConditions(nodeState *sriovnetworkv1.SriovNetworkNodeState, msg Message) {
+	conditions := &nodeState.Status.Conditions
+	// the status refers to the generation applied by the daemon, not to the latest one of the node state
+	generation := msg.generation
+	newCondition := func(condType string, status metav1.ConditionStatus, reason, message string) metav1.Condition {
+		return metav1.Condition{Type: condType, Status: status, Reason: reason, Message: message, ObservedGeneration: generation}
+	}
+	// initialize the conditions that are only updated on specific messages
+	for _, condType := range []string{sriovnetworkv1.ConditionDegraded, sriovnetworkv1.NodeStateConditionPluginError,
+		sriovnetworkv1.NodeStateConditionDrainRequired, sriovnetworkv1.NodeStateConditionRebootRequired} {
+		if meta.FindStatusCondition(*conditions, condType) == nil {
+			reason := sriovnetworkv1.NodeStateReasonNotRequired
+			switch condType {
+			case sriovnetworkv1.ConditionDegraded:
+				reason = sriovnetworkv1.NodeStateReasonNoFailures
+			case sriovnetworkv1.NodeStateConditionPluginError:
+				reason = sriovnetworkv1.NodeStateReasonNoPluginError
+			}
+			meta.SetStatusCondition(conditions, newCondition(condType, metav1.ConditionFalse, reason, ""))
+		}
+	}
+
+	switch msg.syncStatus {
+	case consts.SyncStatusSucceeded:
+		meta.SetStatusCondition(conditions, newCondition(sriovnetworkv1.NodeStateConditionSynced,
+			metav1.ConditionTrue, sriovnetworkv1.NodeStateReasonSucceeded, ""))
+		meta.SetStatusCondition(conditions, newCondition(sriovnetworkv1.ConditionDegraded,
+			metav1.ConditionFalse, sriovnetworkv1.NodeStateReasonNoFailures, ""))
+		meta.SetStatusCondition(conditions, newCondition(sriovnetworkv1.NodeStateConditionPluginError,
+			metav1.ConditionFalse, sriovnetworkv1.NodeStateReasonNoPluginError, ""))
+		// the configuration is applied, nothing is pending anymore
+		meta.SetStatusCondition(conditions, newCondition(sriovnetworkv1.NodeStateConditionDrainRequired,
+			metav1.ConditionFalse, sriovnetworkv1.NodeStateReasonNotRequired, ""))
+		meta.SetStatusCondition(conditions, newCondition(sriovnetworkv1.NodeStateConditionRebootRequired,
+			metav1.ConditionFalse, sriovnetworkv1.NodeStateReasonNotRequired, ""))
+	case consts.SyncStatusInProgress:
+		meta.SetStatusCondition(conditions, newCondition(sriovnetworkv1.NodeStateConditionSynced,
+			metav1.ConditionFalse, sriovnetworkv1.NodeStateReasonInProgress, "configuration is being applied"))
+	case consts.SyncStatusFailed:
+		meta.SetStatusCondition(conditions, newCondition(sriovnetworkv1.NodeStateConditionSynced,
+			metav1.ConditionFalse, sriovnetworkv1.NodeStateReasonFailed, msg.lastSyncError))
+		meta.SetStatusCondition(conditions, newCondition(sriovnetworkv1.ConditionDegraded,
+			metav1.ConditionTrue, sriovnetworkv1.NodeStateReasonFailed, msg.lastSyncError))
+		if msg.failedPlugin != "" {
+			meta.SetStatusCondition(conditions, newCondition(sriovnetworkv1.NodeStateConditionPluginError,
+				metav1.ConditionTrue, sriovnetworkv1.NodeStateReasonPluginFailed,
+				fmt.Sprintf("plugin %s: %s", msg.failedPlugin, msg.lastSyncError)))
+		} else {
+			meta.SetStatusCondition(conditions, newCondition(sriovnetworkv1.NodeStateConditionPluginError,
+				metav1.ConditionFalse, sriovnetworkv1.NodeStateReasonNoPluginError, ""))
+		}
+	}
+
+	if msg.requirements != nil {
+		drainCond := newCondition(sriovnetworkv1.NodeStateConditionDrainRequired,
+			metav1.ConditionFalse, sriovnetworkv1.NodeStateReasonNotRequired, "")
+		if msg.requirements.drain {
+			drainCond.Status = metav1.ConditionTrue
+			drainCond.Reason = sriovnetworkv1.NodeStateReasonDrainRequired
+			drainCond.Message = requirementMessage("the node needs to be drained to apply the configuration",
+				msg.requirements.drainRequesters)
+		}
+		meta.SetStatusCondition(conditions, drainCond)
+		rebootCond := newCondition(sriovnetworkv1.NodeStateConditionRebootRequired,
+			metav1.ConditionFalse, sriovnetworkv1.NodeStateReasonNotRequired, "")
+		if msg.requirements.reboot {
+			rebootCond.Status = metav1.ConditionTrue
+			rebootCond.Reason = sriovnetworkv1.NodeStateReasonRebootRequired
+			rebootCond.Message = requirementMessage("the node needs to be rebooted to apply the configuration",
+				msg.requirements.rebootRequesters)
+		}
+		meta.SetStatusCondition(conditions, rebootCond)
+	}
+}
+
+// requirementMessage appends the plugins which requested a disruptive action to the condition message
+func requirementMessage(message string, requesters []string) string {
+	if len(requesters) == 0 {
+		return message
+	}
+	return fmt.Sprintf("%s, requested by: %s", message, strings.Join(requesters, ", "))
+}
+
 // recordStatusChangeEvent sends event in case oldStatus differs from newStatus
 func (w *NodeStateStatusWriter) recordStatusChangeEvent(oldStatus, newStatus, lastError string) {
 	if oldStatus != newStatus {
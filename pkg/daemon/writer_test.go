@@ -0,0 +1,89 @@
+package daemon
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+)
+
+var _ = Describe("NodeStateStatusWriter", func() {
+	Context("updateNodeStateConditions", func() {
+		var nodeState *sriovnetworkv1.SriovNetworkNodeState
+
+		BeforeEach(func() {
+			nodeState = &sriovnetworkv1.SriovNetworkNodeState{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-node", Generation: 3},
+			}
+		})
+
+		conditionStatus := func(condType string) metav1.ConditionStatus {
+			cond := meta.FindStatusCondition(nodeState.Status.Conditions, condType)
+			Expect(cond).ToNot(BeNil())
+			Expect(cond.ObservedGeneration).To(BeNumerically("==", 3))
+			return cond.Status
+		}
+
+		It("should initialize all the conditions", func() {
+			updateNodeStateConditions(nodeState, Message{generation: 3, syncStatus: consts.SyncStatusInProgress})
+			Expect(nodeState.Status.Conditions).To(HaveLen(5))
+			Expect(conditionStatus(sriovnetworkv1.NodeStateConditionSynced)).To(Equal(metav1.ConditionFalse))
+			Expect(conditionStatus(sriovnetworkv1.ConditionDegraded)).To(Equal(metav1.ConditionFalse))
+			Expect(conditionStatus(sriovnetworkv1.NodeStateConditionPluginError)).To(Equal(metav1.ConditionFalse))
+			Expect(conditionStatus(sriovnetworkv1.NodeStateConditionDrainRequired)).To(Equal(metav1.ConditionFalse))
+			Expect(conditionStatus(sriovnetworkv1.NodeStateConditionRebootRequired)).To(Equal(metav1.ConditionFalse))
+		})
+
+		It("should report drain and reboot requirements until the sync succeeds", func() {
+			updateNodeStateConditions(nodeState, Message{generation: 3, syncStatus: consts.SyncStatusInProgress,
+				requirements: &nodeRequirements{drain: true, reboot: true,
+					drainRequesters: []string{GenericPluginName}, rebootRequesters: []string{systemdRequester}}})
+			Expect(conditionStatus(sriovnetworkv1.NodeStateConditionDrainRequired)).To(Equal(metav1.ConditionTrue))
+			Expect(conditionStatus(sriovnetworkv1.NodeStateConditionRebootRequired)).To(Equal(metav1.ConditionTrue))
+			cond := meta.FindStatusCondition(nodeState.Status.Conditions, sriovnetworkv1.NodeStateConditionDrainRequired)
+			Expect(cond.Message).To(HaveSuffix("requested by: " + GenericPluginName))
+			cond = meta.FindStatusCondition(nodeState.Status.Conditions, sriovnetworkv1.NodeStateConditionRebootRequired)
+			Expect(cond.Message).To(HaveSuffix("requested by: " + systemdRequester))
+
+			updateNodeStateConditions(nodeState, Message{generation: 3, syncStatus: consts.SyncStatusInProgress})
+			Expect(conditionStatus(sriovnetworkv1.NodeStateConditionDrainRequired)).To(Equal(metav1.ConditionTrue))
+
+			updateNodeStateConditions(nodeState, Message{generation: 3, syncStatus: consts.SyncStatusSucceeded})
+			Expect(conditionStatus(sriovnetworkv1.NodeStateConditionSynced)).To(Equal(metav1.ConditionTrue))
+			Expect(conditionStatus(sriovnetworkv1.NodeStateConditionDrainRequired)).To(Equal(metav1.ConditionFalse))
+			Expect(conditionStatus(sriovnetworkv1.NodeStateConditionRebootRequired)).To(Equal(metav1.ConditionFalse))
+		})
+
+		It("should report the generation applied by the daemon", func() {
+			// the spec was updated after the daemon applied the configuration
+			nodeState.Generation = 4
+			updateNodeStateConditions(nodeState, Message{generation: 3, syncStatus: consts.SyncStatusSucceeded})
+			Expect(conditionStatus(sriovnetworkv1.NodeStateConditionSynced)).To(Equal(metav1.ConditionTrue))
+			Expect(conditionStatus(sriovnetworkv1.ConditionDegraded)).To(Equal(metav1.ConditionFalse))
+		})
+
+		It("should report plugin errors", func() {
+			updateNodeStateConditions(nodeState, Message{generation: 3, syncStatus: consts.SyncStatusFailed,
+				lastSyncError: "failed to configure PF", failedPlugin: GenericPluginName})
+			Expect(conditionStatus(sriovnetworkv1.NodeStateConditionSynced)).To(Equal(metav1.ConditionFalse))
+			Expect(conditionStatus(sriovnetworkv1.ConditionDegraded)).To(Equal(metav1.ConditionTrue))
+			Expect(conditionStatus(sriovnetworkv1.NodeStateConditionPluginError)).To(Equal(metav1.ConditionTrue))
+			cond := meta.FindStatusCondition(nodeState.Status.Conditions, sriovnetworkv1.NodeStateConditionPluginError)
+			Expect(cond.Message).To(ContainSubstring(GenericPluginName))
+			Expect(cond.Message).To(ContainSubstring("failed to configure PF"))
+
+			// the degraded state is kept while the daemon retries
+			updateNodeStateConditions(nodeState, Message{generation: 3, syncStatus: consts.SyncStatusInProgress})
+			Expect(conditionStatus(sriovnetworkv1.ConditionDegraded)).To(Equal(metav1.ConditionTrue))
+
+			updateNodeStateConditions(nodeState, Message{generation: 3, syncStatus: consts.SyncStatusFailed, lastSyncError: "api error"})
+			Expect(conditionStatus(sriovnetworkv1.NodeStateConditionPluginError)).To(Equal(metav1.ConditionFalse))
+
+			updateNodeStateConditions(nodeState, Message{generation: 3, syncStatus: consts.SyncStatusSucceeded})
+			Expect(conditionStatus(sriovnetworkv1.ConditionDegraded)).To(Equal(metav1.ConditionFalse))
+		})
+	})
+})
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/kubectl/pkg/drain"
@@ -29,10 +30,35 @@ func (w writer) Write(p []byte) (n int, err error) {
 }
 
 type DrainInterface interface {
-	DrainNode(context.Context, *corev1.Node, bool) (bool, error)
+	DrainNode(context.Context, *corev1.Node, bool, DrainOptions) (bool, error)
 	CompleteDrainNode(context.Context, *corev1.Node) (bool, error)
 }
 
+// DrainOptions configures the eviction of the pods of a node
+type DrainOptions struct {
+	// Timeout of each attempt to evict the pods of the node
+	Timeout time.Duration
+	// GracePeriodSeconds overrides the termination grace period of the pods, -1 uses the grace period of each pod
+	GracePeriodSeconds int
+	// SkipPodSelector selects the pods which are never evicted, nil skips no pod
+	SkipPodSelector labels.Selector
+	// EvictPodSelector restricts the eviction to the selected pods, nil evicts all the pods
+	EvictPodSelector labels.Selector
+	// DisableEviction deletes the pods instead of evicting them, bypassing the PodDisruptionBudgets
+	DisableEviction bool
+	// ResourceNames restricts a drain without reboot to the pods requesting one of the resources,
+	// nil evicts the pods requesting any SR-IOV resource
+	ResourceNames []string
+}
+
+// DefaultDrainOptions returns the options used when the pool of the node doesn't configure the drain
+func DefaultDrainOptions() DrainOptions {
+	return DrainOptions{
+		Timeout:            90 * time.Second,
+		GracePeriodSeconds: -1,
+	}
+}
+
 type Drainer struct {
 	kubeClient      kubernetes.Interface
 	platformHelpers platforms.Interface
@@ -53,7 +79,7 @@ func NewDrainer(platformHelpers platforms.Interface) (DrainInterface, error) {
 // DrainNode the function cordon a node and drain pods from it
 // if fullNodeDrain true all the pods on the system will get drained
 // for openshift system we also pause the machine config pool this machine is part of it
-func (d *Drainer) DrainNode(ctx context.Context, node *corev1.Node, fullNodeDrain bool) (bool, error) {
+func (d *Drainer) DrainNode(ctx context.Context, node *corev1.Node, fullNodeDrain bool, opts DrainOptions) (bool, error) {
 	reqLogger := log.FromContext(ctx).WithValues("drain node", node.Name)
 	reqLogger.Info("drainNode(): Node drain requested", "node", node.Name)
 
@@ -68,7 +94,7 @@ func (d *Drainer) DrainNode(ctx context.Context, node *corev1.Node, fullNodeDrai
 		return false, nil
 	}
 
-	drainHelper := createDrainHelper(d.kubeClient, ctx, fullNodeDrain)
+	drainHelper := createDrainHelper(d.kubeClient, ctx, fullNodeDrain, opts)
 	backoff := wait.Backoff{
 		Steps:    5,
 		Duration: 10 * time.Second,
@@ -111,7 +137,7 @@ func (d *Drainer) CompleteDrainNode(ctx context.Context, node *corev1.Node) (boo
 
 	// Create drain helper object
 	// full drain is not important here
-	drainHelper := createDrainHelper(d.kubeClient, ctx, false)
+	drainHelper := createDrainHelper(d.kubeClient, ctx, false, DefaultDrainOptions())
 
 	// run the un cordon function on the node
 	if err := drain.RunCordonOrUncordon(drainHelper, node, false); err != nil {
@@ -132,17 +158,19 @@ func (d *Drainer) CompleteDrainNode(ctx context.Context, node *corev1.Node) (boo
 }
 
 // createDrainHelper function to create a drain helper
-// if fullDrain is false we only remove pods that have the resourcePrefix
+// if fullDrain is false we only remove pods that have the resourcePrefix, or one of the resource names of the options
 // if not we remove all the pods in the node
-func createDrainHelper(kubeClient kubernetes.Interface, ctx context.Context, fullDrain bool) *drain.Helper {
+// the pods are then filtered with the selectors of the drain options
+func createDrainHelper(kubeClient kubernetes.Interface, ctx context.Context, fullDrain bool, opts DrainOptions) *drain.Helper {
 	logger := log.FromContext(ctx)
 	drainer := &drain.Helper{
 		Client:              kubeClient,
 		Force:               true,
 		IgnoreAllDaemonSets: true,
 		DeleteEmptyDirData:  true,
-		GracePeriodSeconds:  -1,
-		Timeout:             90 * time.Second,
+		GracePeriodSeconds:  opts.GracePeriodSeconds,
+		Timeout:             opts.Timeout,
+		DisableEviction:     opts.DisableEviction,
 		OnPodDeletedOrEvicted: func(pod *corev1.Pod, usingEviction bool) {
 			verbStr := constants.DrainDeleted
 			if usingEviction {
@@ -161,7 +189,7 @@ func createDrainHelper(kubeClient kubernetes.Interface, ctx context.Context, ful
 			for _, c := range p.Spec.Containers {
 				if c.Resources.Requests != nil {
 					for r := range c.Resources.Requests {
-						if strings.HasPrefix(r.String(), vars.ResourcePrefix) {
+						if requestsDrainedResource(r.String(), opts.ResourceNames) {
 							return drain.PodDeleteStatus{
 								Delete:  true,
 								Reason:  "pod contain SR-IOV device",
@@ -174,8 +202,39 @@ func createDrainHelper(kubeClient kubernetes.Interface, ctx context.Context, ful
 			return drain.PodDeleteStatus{Delete: false}
 		}
 
-		drainer.AdditionalFilters = []drain.PodFilter{deleteFunction}
+		drainer.AdditionalFilters = append(drainer.AdditionalFilters, deleteFunction)
+	}
+
+	if opts.SkipPodSelector != nil {
+		drainer.AdditionalFilters = append(drainer.AdditionalFilters, func(p corev1.Pod) drain.PodDeleteStatus {
+			if opts.SkipPodSelector.Matches(labels.Set(p.Labels)) {
+				return drain.MakePodDeleteStatusSkip()
+			}
+			return drain.MakePodDeleteStatusOkay()
+		})
+	}
+	if opts.EvictPodSelector != nil {
+		drainer.AdditionalFilters = append(drainer.AdditionalFilters, func(p corev1.Pod) drain.PodDeleteStatus {
+			if !opts.EvictPodSelector.Matches(labels.Set(p.Labels)) {
+				return drain.MakePodDeleteStatusSkip()
+			}
+			return drain.MakePodDeleteStatusOkay()
+		})
 	}
 
 	return drainer
 }
+
+// requestsDrainedResource returns true if the resource is one of the resource names, or an SR-IOV resource
+// when the resource names are nil
+func requestsDrainedResource(resource string, resourceNames []string) bool {
+	if resourceNames == nil {
+		return strings.HasPrefix(resource, vars.ResourcePrefix)
+	}
+	for _, name := range resourceNames {
+		if resource == name {
+			return true
+		}
+	}
+	return false
+}
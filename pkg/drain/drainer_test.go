@@ -0,0 +1,82 @@
+package drain
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	k8sresource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vars"
+)
+
+func newPod(name string, podLabels map[string]string, resource string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: podLabels},
+		Spec:       corev1.PodSpec{NodeName: "node-1", Containers: []corev1.Container{{Name: "c"}}},
+	}
+	if resource != "" {
+		pod.Spec.Containers[0].Resources.Requests = corev1.ResourceList{
+			corev1.ResourceName(vars.ResourcePrefix + "/" + resource): k8sresource.MustParse("1"),
+		}
+	}
+	return pod
+}
+
+func TestCreateDrainHelperPodFilters(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset(
+		newPod("db", map[string]string{"app": "db"}, "vf"),
+		newPod("web", map[string]string{"app": "web"}, "other-vf"),
+		newPod("cache", map[string]string{"app": "cache"}, ""),
+	)
+
+	tests := []struct {
+		name      string
+		fullDrain bool
+		opts      func(*DrainOptions)
+		expected  []string
+	}{
+		{name: "default options", expected: []string{"db", "web"}},
+		{name: "full drain", fullDrain: true, expected: []string{"cache", "db", "web"}},
+		{name: "skip selector", fullDrain: true, expected: []string{"cache", "web"},
+			opts: func(o *DrainOptions) { o.SkipPodSelector = labels.SelectorFromSet(labels.Set{"app": "db"}) }},
+		{name: "resource names", expected: []string{"db"},
+			opts: func(o *DrainOptions) { o.ResourceNames = []string{vars.ResourcePrefix + "/vf"} }},
+		{name: "no resource names", expected: []string{},
+			opts: func(o *DrainOptions) { o.ResourceNames = []string{} }},
+		{name: "resource names with a full drain", fullDrain: true, expected: []string{"cache", "db", "web"},
+			opts: func(o *DrainOptions) { o.ResourceNames = []string{} }},
+		{name: "evict selector", expected: []string{"web"},
+			opts: func(o *DrainOptions) { o.EvictPodSelector = labels.SelectorFromSet(labels.Set{"app": "web"}) }},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := DefaultDrainOptions()
+			if tc.opts != nil {
+				tc.opts(&opts)
+			}
+			helper := createDrainHelper(kubeClient, context.Background(), tc.fullDrain, opts)
+			podList, errs := helper.GetPodsForDeletion("node-1")
+			if len(errs) > 0 {
+				t.Fatalf("unexpected errors: %v", errs)
+			}
+			names := []string{}
+			for _, pod := range podList.Pods() {
+				names = append(names, pod.Name)
+			}
+			sort.Strings(names)
+			if len(names) != len(tc.expected) {
+				t.Fatalf("unexpected pods to delete want: %v got: %v", tc.expected, names)
+			}
+			for i := range names {
+				if names[i] != tc.expected[i] {
+					t.Errorf("unexpected pods to delete want: %v got: %v", tc.expected, names)
+				}
+			}
+		})
+	}
+}
@@ -80,6 +80,20 @@ func (mr *MockHostHelpersInterfaceMockRecorder) AddVfRepresentorUdevRule(pfPciAd
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVfRepresentorUdevRule", reflect.TypeOf((*MockHostHelpersInterface)(nil).AddVfRepresentorUdevRule), pfPciAddress, pfName, pfSwitchID, pfSwitchPort)
 }
 
+// ApplyPfSettings mocks base method.
+func (m *MockHostHelpersInterface) ApplyPfSettings(ifaceName string, settings *v1.PfSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyPfSettings", ifaceName, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyPfSettings indicates an expected call of ApplyPfSettings.
+func (mr *MockHostHelpersInterfaceMockRecorder) ApplyPfSettings(ifaceName, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyPfSettings", reflect.TypeOf((*MockHostHelpersInterface)(nil).ApplyPfSettings), ifaceName, settings)
+}
+
 // BindDefaultDriver mocks base method.
 func (m *MockHostHelpersInterface) BindDefaultDriver(pciAddr string) error {
 	m.ctrl.T.Helper()
@@ -600,6 +614,20 @@ func (mr *MockHostHelpersInterfaceMockRecorder) GetPciAddressFromInterfaceName(i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPciAddressFromInterfaceName", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetPciAddressFromInterfaceName), interfaceName)
 }
 
+// GetPfSettings mocks base method.
+func (m *MockHostHelpersInterface) GetPfSettings(ifaceName string) *v1.PfSettings {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPfSettings", ifaceName)
+	ret0, _ := ret[0].(*v1.PfSettings)
+	return ret0
+}
+
+// GetPfSettings indicates an expected call of GetPfSettings.
+func (mr *MockHostHelpersInterfaceMockRecorder) GetPfSettings(ifaceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPfSettings", reflect.TypeOf((*MockHostHelpersInterface)(nil).GetPfSettings), ifaceName)
+}
+
 // GetPhysPortName mocks base method.
 func (m *MockHostHelpersInterface) GetPhysPortName(name string) (string, error) {
 	m.ctrl.T.Helper()
@@ -1,13 +1,40 @@
 package ethtool
 
 import (
+	"unsafe"
+
 	"github.com/safchain/ethtool"
+	"golang.org/x/sys/unix"
 )
 
 func New() EthtoolLib {
 	return &libWrapper{}
 }
 
+// RingParam contains the RX/TX ring parameters of a network device, see ethtool_ringparam in uapi/linux/ethtool.h
+type RingParam struct {
+	Cmd               uint32
+	RxMaxPending      uint32
+	RxMiniMaxPending  uint32
+	RxJumboMaxPending uint32
+	TxMaxPending      uint32
+	RxPending         uint32
+	RxMiniPending     uint32
+	RxJumboPending    uint32
+	TxPending         uint32
+}
+
+// PauseParam contains the pause frames parameters of a network device, see ethtool_pauseparam in uapi/linux/ethtool.h
+type PauseParam struct {
+	Cmd     uint32
+	Autoneg uint32
+	RxPause uint32
+	TxPause uint32
+}
+
+// Channels contains the channels parameters of a network device
+type Channels = ethtool.Channels
+
 //go:generate ../../../../../bin/mockgen -destination mock/mock_ethtool.go -source ethtool.go
 type EthtoolLib interface {
 	// Features retrieves features of the given interface name.
@@ -16,6 +43,18 @@ type EthtoolLib interface {
 	FeatureNames(ifaceName string) (map[string]uint, error)
 	// Change requests a change in the given device's features.
 	Change(ifaceName string, config map[string]bool) error
+	// GetRingParam retrieves the ring parameters of the given interface name.
+	GetRingParam(ifaceName string) (RingParam, error)
+	// SetRingParam sets the ring parameters of the given interface name.
+	SetRingParam(ifaceName string, ring RingParam) error
+	// GetChannels retrieves the channels of the given interface name.
+	GetChannels(ifaceName string) (Channels, error)
+	// SetChannels sets the channels of the given interface name.
+	SetChannels(ifaceName string, channels Channels) error
+	// GetPauseParam retrieves the pause frames parameters of the given interface name.
+	GetPauseParam(ifaceName string) (PauseParam, error)
+	// SetPauseParam sets the pause frames parameters of the given interface name.
+	SetPauseParam(ifaceName string, pause PauseParam) error
 }
 
 type libWrapper struct{}
@@ -49,3 +88,78 @@ func (w *libWrapper) Change(ifaceName string, config map[string]bool) error {
 	defer e.Close()
 	return e.Change(ifaceName, config)
 }
+
+// GetRingParam retrieves the ring parameters of the given interface name.
+func (w *libWrapper) GetRingParam(ifaceName string) (RingParam, error) {
+	ring := RingParam{Cmd: unix.ETHTOOL_GRINGPARAM}
+	if err := ethtoolIoctl(ifaceName, unsafe.Pointer(&ring)); err != nil {
+		return RingParam{}, err
+	}
+	return ring, nil
+}
+
+// SetRingParam sets the ring parameters of the given interface name.
+func (w *libWrapper) SetRingParam(ifaceName string, ring RingParam) error {
+	ring.Cmd = unix.ETHTOOL_SRINGPARAM
+	return ethtoolIoctl(ifaceName, unsafe.Pointer(&ring))
+}
+
+// GetChannels retrieves the channels of the given interface name.
+func (w *libWrapper) GetChannels(ifaceName string) (Channels, error) {
+	e, err := ethtool.NewEthtool()
+	if err != nil {
+		return Channels{}, err
+	}
+	defer e.Close()
+	return e.GetChannels(ifaceName)
+}
+
+// SetChannels sets the channels of the given interface name.
+func (w *libWrapper) SetChannels(ifaceName string, channels Channels) error {
+	e, err := ethtool.NewEthtool()
+	if err != nil {
+		return err
+	}
+	defer e.Close()
+	_, err = e.SetChannels(ifaceName, channels)
+	return err
+}
+
+// GetPauseParam retrieves the pause frames parameters of the given interface name.
+func (w *libWrapper) GetPauseParam(ifaceName string) (PauseParam, error) {
+	pause := PauseParam{Cmd: unix.ETHTOOL_GPAUSEPARAM}
+	if err := ethtoolIoctl(ifaceName, unsafe.Pointer(&pause)); err != nil {
+		return PauseParam{}, err
+	}
+	return pause, nil
+}
+
+// SetPauseParam sets the pause frames parameters of the given interface name.
+func (w *libWrapper) SetPauseParam(ifaceName string, pause PauseParam) error {
+	pause.Cmd = unix.ETHTOOL_SPAUSEPARAM
+	return ethtoolIoctl(ifaceName, unsafe.Pointer(&pause))
+}
+
+// ifreq is the ifreq structure used by the SIOCETHTOOL ioctl
+type ifreq struct {
+	name [unix.IFNAMSIZ]byte
+	data unsafe.Pointer
+}
+
+// ethtoolIoctl runs the SIOCETHTOOL ioctl for the ethtool command stored in data,
+// the github.com/safchain/ethtool lib doesn't support the ring and pause parameters
+func ethtoolIoctl(ifaceName string, data unsafe.Pointer) error {
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, unix.IPPROTO_IP)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+
+	ifr := ifreq{data: data}
+	copy(ifr.name[:unix.IFNAMSIZ-1], ifaceName)
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(fd), unix.SIOCETHTOOL, uintptr(unsafe.Pointer(&ifr)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
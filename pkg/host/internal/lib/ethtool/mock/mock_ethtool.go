@@ -8,6 +8,7 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	ethtool "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/ethtool"
 )
 
 // MockEthtoolLib is a mock of EthtoolLib interface.
@@ -76,3 +77,90 @@ func (mr *MockEthtoolLibMockRecorder) Features(ifaceName interface{}) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Features", reflect.TypeOf((*MockEthtoolLib)(nil).Features), ifaceName)
 }
+
+// GetChannels mocks base method.
+func (m *MockEthtoolLib) GetChannels(ifaceName string) (ethtool.Channels, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannels", ifaceName)
+	ret0, _ := ret[0].(ethtool.Channels)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannels indicates an expected call of GetChannels.
+func (mr *MockEthtoolLibMockRecorder) GetChannels(ifaceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannels", reflect.TypeOf((*MockEthtoolLib)(nil).GetChannels), ifaceName)
+}
+
+// GetPauseParam mocks base method.
+func (m *MockEthtoolLib) GetPauseParam(ifaceName string) (ethtool.PauseParam, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPauseParam", ifaceName)
+	ret0, _ := ret[0].(ethtool.PauseParam)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPauseParam indicates an expected call of GetPauseParam.
+func (mr *MockEthtoolLibMockRecorder) GetPauseParam(ifaceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPauseParam", reflect.TypeOf((*MockEthtoolLib)(nil).GetPauseParam), ifaceName)
+}
+
+// GetRingParam mocks base method.
+func (m *MockEthtoolLib) GetRingParam(ifaceName string) (ethtool.RingParam, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRingParam", ifaceName)
+	ret0, _ := ret[0].(ethtool.RingParam)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRingParam indicates an expected call of GetRingParam.
+func (mr *MockEthtoolLibMockRecorder) GetRingParam(ifaceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRingParam", reflect.TypeOf((*MockEthtoolLib)(nil).GetRingParam), ifaceName)
+}
+
+// SetChannels mocks base method.
+func (m *MockEthtoolLib) SetChannels(ifaceName string, channels ethtool.Channels) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChannels", ifaceName, channels)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetChannels indicates an expected call of SetChannels.
+func (mr *MockEthtoolLibMockRecorder) SetChannels(ifaceName, channels interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChannels", reflect.TypeOf((*MockEthtoolLib)(nil).SetChannels), ifaceName, channels)
+}
+
+// SetPauseParam mocks base method.
+func (m *MockEthtoolLib) SetPauseParam(ifaceName string, pause ethtool.PauseParam) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPauseParam", ifaceName, pause)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPauseParam indicates an expected call of SetPauseParam.
+func (mr *MockEthtoolLibMockRecorder) SetPauseParam(ifaceName, pause interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPauseParam", reflect.TypeOf((*MockEthtoolLib)(nil).SetPauseParam), ifaceName, pause)
+}
+
+// SetRingParam mocks base method.
+func (m *MockEthtoolLib) SetRingParam(ifaceName string, ring ethtool.RingParam) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRingParam", ifaceName, ring)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRingParam indicates an expected call of SetRingParam.
+func (mr *MockEthtoolLibMockRecorder) SetRingParam(ifaceName, ring interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRingParam", reflect.TypeOf((*MockEthtoolLib)(nil).SetRingParam), ifaceName, ring)
+}
@@ -274,6 +274,62 @@ func (mr *MockNetlinkLibMockRecorder) LinkSetVfPortGUID(link, vf, portguid inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetVfPortGUID", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetVfPortGUID), link, vf, portguid)
 }
 
+// LinkSetVfRate mocks base method.
+func (m *MockNetlinkLib) LinkSetVfRate(link netlink.Link, vf, minRate, maxRate int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetVfRate", link, vf, minRate, maxRate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetVfRate indicates an expected call of LinkSetVfRate.
+func (mr *MockNetlinkLibMockRecorder) LinkSetVfRate(link, vf, minRate, maxRate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetVfRate", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetVfRate), link, vf, minRate, maxRate)
+}
+
+// LinkSetVfSpoofchk mocks base method.
+func (m *MockNetlinkLib) LinkSetVfSpoofchk(link netlink.Link, vf int, check bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetVfSpoofchk", link, vf, check)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetVfSpoofchk indicates an expected call of LinkSetVfSpoofchk.
+func (mr *MockNetlinkLibMockRecorder) LinkSetVfSpoofchk(link, vf, check interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetVfSpoofchk", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetVfSpoofchk), link, vf, check)
+}
+
+// LinkSetVfState mocks base method.
+func (m *MockNetlinkLib) LinkSetVfState(link netlink.Link, vf int, state uint32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetVfState", link, vf, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetVfState indicates an expected call of LinkSetVfState.
+func (mr *MockNetlinkLibMockRecorder) LinkSetVfState(link, vf, state interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetVfState", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetVfState), link, vf, state)
+}
+
+// LinkSetVfTrust mocks base method.
+func (m *MockNetlinkLib) LinkSetVfTrust(link netlink.Link, vf int, state bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkSetVfTrust", link, vf, state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkSetVfTrust indicates an expected call of LinkSetVfTrust.
+func (mr *MockNetlinkLibMockRecorder) LinkSetVfTrust(link, vf, state interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkSetVfTrust", reflect.TypeOf((*MockNetlinkLib)(nil).LinkSetVfTrust), link, vf, state)
+}
+
 // RdmaLinkByName mocks base method.
 func (m *MockNetlinkLib) RdmaLinkByName(name string) (*netlink0.RdmaLink, error) {
 	m.ctrl.T.Helper()
@@ -32,6 +32,18 @@ type NetlinkLib interface {
 	// LinkSetVfHardwareAddr sets the hardware address of a vf for the link.
 	// Equivalent to: `ip link set $link vf $vf mac $hwaddr`
 	LinkSetVfHardwareAddr(link Link, vf int, hwaddr net.HardwareAddr) error
+	// LinkSetVfTrust enables/disables trust state on a vf for the link.
+	// Equivalent to: `ip link set $link vf $vf trust $state`
+	LinkSetVfTrust(link Link, vf int, state bool) error
+	// LinkSetVfSpoofchk enables/disables spoof check on a vf for the link.
+	// Equivalent to: `ip link set $link vf $vf spoofchk $check`
+	LinkSetVfSpoofchk(link Link, vf int, check bool) error
+	// LinkSetVfState sets the link state of a vf for the link.
+	// Equivalent to: `ip link set $link vf $vf state $state`
+	LinkSetVfState(link Link, vf int, state uint32) error
+	// LinkSetVfRate sets the min and max tx rate of a vf for the link.
+	// Equivalent to: `ip link set $link vf $vf min_tx_rate $min_rate max_tx_rate $max_rate`
+	LinkSetVfRate(link Link, vf, minRate, maxRate int) error
 	// LinkSetUp enables the link device.
 	// Equivalent to: `ip link set $link up`
 	LinkSetUp(link Link) error
@@ -119,6 +131,30 @@ func (w *libWrapper) LinkSetVfHardwareAddr(link Link, vf int, hwaddr net.Hardwar
 	return netlink.LinkSetVfHardwareAddr(link, vf, hwaddr)
 }
 
+// LinkSetVfTrust enables/disables trust state on a vf for the link.
+// Equivalent to: `ip link set $link vf $vf trust $state`
+func (w *libWrapper) LinkSetVfTrust(link Link, vf int, state bool) error {
+	return netlink.LinkSetVfTrust(link, vf, state)
+}
+
+// LinkSetVfSpoofchk enables/disables spoof check on a vf for the link.
+// Equivalent to: `ip link set $link vf $vf spoofchk $check`
+func (w *libWrapper) LinkSetVfSpoofchk(link Link, vf int, check bool) error {
+	return netlink.LinkSetVfSpoofchk(link, vf, check)
+}
+
+// LinkSetVfState sets the link state of a vf for the link.
+// Equivalent to: `ip link set $link vf $vf state $state`
+func (w *libWrapper) LinkSetVfState(link Link, vf int, state uint32) error {
+	return netlink.LinkSetVfState(link, vf, state)
+}
+
+// LinkSetVfRate sets the min and max tx rate of a vf for the link.
+// Equivalent to: `ip link set $link vf $vf min_tx_rate $min_rate max_tx_rate $max_rate`
+func (w *libWrapper) LinkSetVfRate(link Link, vf, minRate, maxRate int) error {
+	return netlink.LinkSetVfRate(link, vf, minRate, maxRate)
+}
+
 // LinkSetUp enables the link device.
 // Equivalent to: `ip link set $link up`
 func (w *libWrapper) LinkSetUp(link Link) error {
@@ -14,6 +14,7 @@ import (
 	"github.com/vishvananda/netlink/nl"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
 	dputilsPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/dputils"
 	ethtoolPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/ethtool"
@@ -393,6 +394,194 @@ func (n *network) EnableHwTcOffload(ifaceName string) error {
 	return nil
 }
 
+// pfFeatureKernelNames maps the PF features supported in the policy to the ethtool feature names
+var pfFeatureKernelNames = map[string]string{
+	consts.PfFeatureRxVlanFilter: "rx-vlan-filter",
+	consts.PfFeatureHwTcOffload:  "hw-tc-offload",
+	consts.PfFeatureLro:          "rx-lro",
+}
+
+// ApplyPfSettings applies ethtool settings (ring sizes, channels, features and pause frames) to the PF,
+// settings which are not defined are left unchanged
+func (n *network) ApplyPfSettings(ifaceName string, settings *sriovnetworkv1.PfSettings) error {
+	if settings == nil {
+		return nil
+	}
+	funcLog := log.Log.WithValues("device", ifaceName)
+	funcLog.V(2).Info("ApplyPfSettings(): apply PF settings", "settings", settings)
+
+	if settings.RxRingSize != nil || settings.TxRingSize != nil {
+		ring, err := n.ethtoolLib.GetRingParam(ifaceName)
+		if err != nil {
+			funcLog.Error(err, "ApplyPfSettings(): can't read ring parameters")
+			return err
+		}
+		desired := ring
+		if settings.RxRingSize != nil {
+			if uint32(*settings.RxRingSize) > ring.RxMaxPending {
+				return fmt.Errorf("rx ring size %d is larger than the maximum %d of device %s", *settings.RxRingSize, ring.RxMaxPending, ifaceName)
+			}
+			desired.RxPending = uint32(*settings.RxRingSize)
+		}
+		if settings.TxRingSize != nil {
+			if uint32(*settings.TxRingSize) > ring.TxMaxPending {
+				return fmt.Errorf("tx ring size %d is larger than the maximum %d of device %s", *settings.TxRingSize, ring.TxMaxPending, ifaceName)
+			}
+			desired.TxPending = uint32(*settings.TxRingSize)
+		}
+		if desired != ring {
+			if err := n.ethtoolLib.SetRingParam(ifaceName, desired); err != nil {
+				funcLog.Error(err, "ApplyPfSettings(): can't set ring parameters")
+				return err
+			}
+		}
+	}
+
+	if settings.CombinedChannels != nil {
+		channels, err := n.ethtoolLib.GetChannels(ifaceName)
+		if err != nil {
+			funcLog.Error(err, "ApplyPfSettings(): can't read channels")
+			return err
+		}
+		if uint32(*settings.CombinedChannels) > channels.MaxCombined {
+			return fmt.Errorf("combined channels %d is larger than the maximum %d of device %s", *settings.CombinedChannels, channels.MaxCombined, ifaceName)
+		}
+		if channels.CombinedCount != uint32(*settings.CombinedChannels) {
+			channels.CombinedCount = uint32(*settings.CombinedChannels)
+			if err := n.ethtoolLib.SetChannels(ifaceName, channels); err != nil {
+				funcLog.Error(err, "ApplyPfSettings(): can't set channels")
+				return err
+			}
+		}
+	}
+
+	if len(settings.Features) > 0 {
+		if err := n.applyPfFeatures(ifaceName, settings.Features); err != nil {
+			funcLog.Error(err, "ApplyPfSettings(): can't set features")
+			return err
+		}
+	}
+
+	if settings.Pause != nil {
+		pause, err := n.ethtoolLib.GetPauseParam(ifaceName)
+		if err != nil {
+			funcLog.Error(err, "ApplyPfSettings(): can't read pause parameters")
+			return err
+		}
+		desired := pause
+		setPauseValue(&desired.Autoneg, settings.Pause.Autoneg)
+		setPauseValue(&desired.RxPause, settings.Pause.Rx)
+		setPauseValue(&desired.TxPause, settings.Pause.Tx)
+		if desired != pause {
+			if err := n.ethtoolLib.SetPauseParam(ifaceName, desired); err != nil {
+				funcLog.Error(err, "ApplyPfSettings(): can't set pause parameters")
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (n *network) applyPfFeatures(ifaceName string, features map[string]bool) error {
+	knownFeatures, err := n.ethtoolLib.FeatureNames(ifaceName)
+	if err != nil {
+		return err
+	}
+	currentFeaturesState, err := n.ethtoolLib.Features(ifaceName)
+	if err != nil {
+		return err
+	}
+	changes := map[string]bool{}
+	for feature, enabled := range features {
+		kernelName, ok := pfFeatureKernelNames[feature]
+		if !ok {
+			return fmt.Errorf("unknown PF feature %s", feature)
+		}
+		if _, isKnown := knownFeatures[kernelName]; !isKnown {
+			return fmt.Errorf("feature %s is not supported by device %s", feature, ifaceName)
+		}
+		if currentFeaturesState[kernelName] != enabled {
+			changes[kernelName] = enabled
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	if err := n.ethtoolLib.Change(ifaceName, changes); err != nil {
+		return err
+	}
+	updatedFeaturesState, err := n.ethtoolLib.Features(ifaceName)
+	if err != nil {
+		return err
+	}
+	for kernelName, enabled := range changes {
+		if updatedFeaturesState[kernelName] != enabled {
+			return fmt.Errorf("feature %s can't be changed on device %s", kernelName, ifaceName)
+		}
+	}
+	return nil
+}
+
+func setPauseValue(target *uint32, value *bool) {
+	if value == nil {
+		return
+	}
+	if *value {
+		*target = 1
+	} else {
+		*target = 0
+	}
+}
+
+// GetPfSettings returns the current ethtool settings of the PF,
+// settings which can't be read are not reported
+func (n *network) GetPfSettings(ifaceName string) *sriovnetworkv1.PfSettings {
+	funcLog := log.Log.WithValues("device", ifaceName)
+	if len(ifaceName) == 0 {
+		return nil
+	}
+	settings := &sriovnetworkv1.PfSettings{}
+	found := false
+	if ring, err := n.ethtoolLib.GetRingParam(ifaceName); err != nil {
+		funcLog.V(2).Info("GetPfSettings(): can't read ring parameters", "error", err)
+	} else {
+		rx, tx := int(ring.RxPending), int(ring.TxPending)
+		settings.RxRingSize, settings.TxRingSize = &rx, &tx
+		found = true
+	}
+	if channels, err := n.ethtoolLib.GetChannels(ifaceName); err != nil {
+		funcLog.V(2).Info("GetPfSettings(): can't read channels", "error", err)
+	} else if channels.MaxCombined > 0 {
+		combined := int(channels.CombinedCount)
+		settings.CombinedChannels = &combined
+		found = true
+	}
+	if features, err := n.ethtoolLib.Features(ifaceName); err != nil {
+		funcLog.V(2).Info("GetPfSettings(): can't read features", "error", err)
+	} else {
+		for feature, kernelName := range pfFeatureKernelNames {
+			if enabled, ok := features[kernelName]; ok {
+				if settings.Features == nil {
+					settings.Features = map[string]bool{}
+				}
+				settings.Features[feature] = enabled
+				found = true
+			}
+		}
+	}
+	if pause, err := n.ethtoolLib.GetPauseParam(ifaceName); err != nil {
+		funcLog.V(2).Info("GetPfSettings(): can't read pause parameters", "error", err)
+	} else {
+		autoneg, rx, tx := pause.Autoneg != 0, pause.RxPause != 0, pause.TxPause != 0
+		settings.Pause = &sriovnetworkv1.PauseSettings{Autoneg: &autoneg, Rx: &rx, Tx: &tx}
+		found = true
+	}
+	if !found {
+		return nil
+	}
+	return settings
+}
+
 // GetNetDevLinkAdminState returns the admin state of the interface.
 func (n *network) GetNetDevLinkAdminState(ifaceName string) string {
 	log.Log.V(2).Info("GetNetDevLinkAdminState(): get LinkAdminState", "device", ifaceName)
@@ -10,8 +10,10 @@ import (
 
 	"github.com/golang/mock/gomock"
 
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	hostMockPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/helper/mock"
 	dputilsMockPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/dputils/mock"
+	ethtoolPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/ethtool"
 	ethtoolMockPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/ethtool/mock"
 	netlinkMockPkg "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/internal/lib/netlink/mock"
 	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/host/types"
@@ -204,6 +206,89 @@ var _ = Describe("Network", func() {
 			Expect(n.EnableHwTcOffload("enp216s0f0np0")).To(MatchError(testErr))
 		})
 	})
+	Context("ApplyPfSettings", func() {
+		intPtr := func(v int) *int { return &v }
+		boolPtr := func(v bool) *bool { return &v }
+		It("nil settings", func() {
+			Expect(n.ApplyPfSettings("enp216s0f0np0", nil)).NotTo(HaveOccurred())
+		})
+		It("Applied", func() {
+			ethtoolLibMock.EXPECT().GetRingParam("enp216s0f0np0").Return(
+				ethtoolPkg.RingParam{RxMaxPending: 8192, TxMaxPending: 8192, RxPending: 1024, TxPending: 1024}, nil)
+			ethtoolLibMock.EXPECT().SetRingParam("enp216s0f0np0",
+				ethtoolPkg.RingParam{RxMaxPending: 8192, TxMaxPending: 8192, RxPending: 4096, TxPending: 1024}).Return(nil)
+			ethtoolLibMock.EXPECT().GetChannels("enp216s0f0np0").Return(ethtoolPkg.Channels{MaxCombined: 64, CombinedCount: 64}, nil)
+			ethtoolLibMock.EXPECT().SetChannels("enp216s0f0np0", ethtoolPkg.Channels{MaxCombined: 64, CombinedCount: 8}).Return(nil)
+			ethtoolLibMock.EXPECT().FeatureNames("enp216s0f0np0").Return(map[string]uint{"rx-vlan-filter": 1, "rx-lro": 2}, nil)
+			ethtoolLibMock.EXPECT().Features("enp216s0f0np0").Return(map[string]bool{"rx-vlan-filter": true, "rx-lro": true}, nil)
+			ethtoolLibMock.EXPECT().Change("enp216s0f0np0", map[string]bool{"rx-vlan-filter": false}).Return(nil)
+			ethtoolLibMock.EXPECT().Features("enp216s0f0np0").Return(map[string]bool{"rx-vlan-filter": false, "rx-lro": true}, nil)
+			ethtoolLibMock.EXPECT().GetPauseParam("enp216s0f0np0").Return(ethtoolPkg.PauseParam{Autoneg: 1, RxPause: 1, TxPause: 1}, nil)
+			ethtoolLibMock.EXPECT().SetPauseParam("enp216s0f0np0", ethtoolPkg.PauseParam{Autoneg: 0, RxPause: 1, TxPause: 1}).Return(nil)
+			Expect(n.ApplyPfSettings("enp216s0f0np0", &sriovnetworkv1.PfSettings{
+				RxRingSize:       intPtr(4096),
+				TxRingSize:       intPtr(1024),
+				CombinedChannels: intPtr(8),
+				Features:         map[string]bool{"rx-vlan-filter": false, "lro": true},
+				Pause:            &sriovnetworkv1.PauseSettings{Autoneg: boolPtr(false)},
+			})).NotTo(HaveOccurred())
+		})
+		It("Already applied", func() {
+			ethtoolLibMock.EXPECT().GetRingParam("enp216s0f0np0").Return(
+				ethtoolPkg.RingParam{RxMaxPending: 8192, TxMaxPending: 8192, RxPending: 4096, TxPending: 1024}, nil)
+			ethtoolLibMock.EXPECT().GetPauseParam("enp216s0f0np0").Return(ethtoolPkg.PauseParam{RxPause: 1}, nil)
+			Expect(n.ApplyPfSettings("enp216s0f0np0", &sriovnetworkv1.PfSettings{
+				RxRingSize: intPtr(4096),
+				Pause:      &sriovnetworkv1.PauseSettings{Rx: boolPtr(true), Tx: boolPtr(false)},
+			})).NotTo(HaveOccurred())
+		})
+		It("fail - ring size is larger than the maximum", func() {
+			ethtoolLibMock.EXPECT().GetRingParam("enp216s0f0np0").Return(
+				ethtoolPkg.RingParam{RxMaxPending: 8192, TxMaxPending: 8192, RxPending: 4096, TxPending: 1024}, nil)
+			Expect(n.ApplyPfSettings("enp216s0f0np0", &sriovnetworkv1.PfSettings{
+				TxRingSize: intPtr(16384),
+			})).To(MatchError(ContainSubstring("larger than the maximum")))
+		})
+		It("fail - feature is not supported", func() {
+			ethtoolLibMock.EXPECT().FeatureNames("enp216s0f0np0").Return(map[string]uint{"rx-vlan-filter": 1}, nil)
+			ethtoolLibMock.EXPECT().Features("enp216s0f0np0").Return(map[string]bool{"rx-vlan-filter": true}, nil)
+			Expect(n.ApplyPfSettings("enp216s0f0np0", &sriovnetworkv1.PfSettings{
+				Features: map[string]bool{"lro": false},
+			})).To(MatchError(ContainSubstring("not supported")))
+		})
+		It("fail - can't read channels", func() {
+			ethtoolLibMock.EXPECT().GetChannels("enp216s0f0np0").Return(ethtoolPkg.Channels{}, testErr)
+			Expect(n.ApplyPfSettings("enp216s0f0np0", &sriovnetworkv1.PfSettings{
+				CombinedChannels: intPtr(8),
+			})).To(MatchError(testErr))
+		})
+	})
+	Context("GetPfSettings", func() {
+		It("Returns the current settings", func() {
+			ethtoolLibMock.EXPECT().GetRingParam("enp216s0f0np0").Return(
+				ethtoolPkg.RingParam{RxMaxPending: 8192, TxMaxPending: 8192, RxPending: 4096, TxPending: 1024}, nil)
+			ethtoolLibMock.EXPECT().GetChannels("enp216s0f0np0").Return(ethtoolPkg.Channels{MaxCombined: 64, CombinedCount: 8}, nil)
+			ethtoolLibMock.EXPECT().Features("enp216s0f0np0").Return(
+				map[string]bool{"rx-vlan-filter": true, "hw-tc-offload": false, "rx-gro": true}, nil)
+			ethtoolLibMock.EXPECT().GetPauseParam("enp216s0f0np0").Return(ethtoolPkg.PauseParam{RxPause: 1}, nil)
+			settings := n.GetPfSettings("enp216s0f0np0")
+			Expect(settings).NotTo(BeNil())
+			Expect(*settings.RxRingSize).To(Equal(4096))
+			Expect(*settings.TxRingSize).To(Equal(1024))
+			Expect(*settings.CombinedChannels).To(Equal(8))
+			Expect(settings.Features).To(Equal(map[string]bool{"rx-vlan-filter": true, "hw-tc-offload": false}))
+			Expect(*settings.Pause.Autoneg).To(BeFalse())
+			Expect(*settings.Pause.Rx).To(BeTrue())
+			Expect(*settings.Pause.Tx).To(BeFalse())
+		})
+		It("Returns nil when nothing can be read", func() {
+			ethtoolLibMock.EXPECT().GetRingParam("enp216s0f0np0").Return(ethtoolPkg.RingParam{}, testErr)
+			ethtoolLibMock.EXPECT().GetChannels("enp216s0f0np0").Return(ethtoolPkg.Channels{}, testErr)
+			ethtoolLibMock.EXPECT().Features("enp216s0f0np0").Return(nil, testErr)
+			ethtoolLibMock.EXPECT().GetPauseParam("enp216s0f0np0").Return(ethtoolPkg.PauseParam{}, testErr)
+			Expect(n.GetPfSettings("enp216s0f0np0")).To(BeNil())
+		})
+	})
 	Context("GetNetDevNodeGUID", func() {
 		It("Returns empty when pciAddr is empty", func() {
 			Expect(n.GetNetDevNodeGUID("")).To(Equal(""))
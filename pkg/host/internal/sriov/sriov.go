@@ -3,6 +3,7 @@ package sriov
 import (
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -169,6 +170,85 @@ func (s *sriov) getVfInfo(vfAddr string, pfName string, eswitchMode string, devi
 	return vf
 }
 
+// setVfAttributes fills the VF attributes configured on the PF (spoof check, trust,
+// link state and tx rates) from the VF info of the PF link
+func setVfAttributes(vf *sriovnetworkv1.VirtualFunction, vfInfos []netlink.VfInfo) {
+	onOff := func(enabled bool) string {
+		if enabled {
+			return sriovnetworkv1.SriovCniStateOn
+		}
+		return sriovnetworkv1.SriovCniStateOff
+	}
+	for _, info := range vfInfos {
+		if info.ID != vf.VfID {
+			continue
+		}
+		vf.SpoofChk = onOff(info.Spoofchk)
+		vf.Trust = onOff(info.Trust != 0)
+		switch info.LinkState {
+		case netlink.VF_LINK_STATE_AUTO:
+			vf.LinkState = sriovnetworkv1.SriovCniStateAuto
+		case netlink.VF_LINK_STATE_ENABLE:
+			vf.LinkState = sriovnetworkv1.SriovCniStateEnable
+		case netlink.VF_LINK_STATE_DISABLE:
+			vf.LinkState = sriovnetworkv1.SriovCniStateDisable
+		}
+		vf.MinTxRate = int(info.MinTxRate)
+		vf.MaxTxRate = int(info.MaxTxRate)
+		if len(info.Mac) > 0 {
+			vf.AdminMac = info.Mac.String()
+		}
+		return
+	}
+}
+
+// configureVfDefaults sets the default attributes of the VF group on the VF
+func (s *sriov) configureVfDefaults(pfLink netlink.Link, vfID int, defaults *sriovnetworkv1.VfDefaults) error {
+	if defaults == nil {
+		return nil
+	}
+	log.Log.V(2).Info("configureVfDefaults(): configure VF default attributes",
+		"pf", pfLink.Attrs().Name, "vf", vfID, "defaults", defaults)
+	if defaults.SpoofChk != "" {
+		if err := s.netlinkLib.LinkSetVfSpoofchk(pfLink, vfID, defaults.SpoofChk == sriovnetworkv1.SriovCniStateOn); err != nil {
+			return fmt.Errorf("failed to set spoofchk for VF %d: %v", vfID, err)
+		}
+	}
+	if defaults.Trust != "" {
+		if err := s.netlinkLib.LinkSetVfTrust(pfLink, vfID, defaults.Trust == sriovnetworkv1.SriovCniStateOn); err != nil {
+			return fmt.Errorf("failed to set trust for VF %d: %v", vfID, err)
+		}
+	}
+	if defaults.LinkState != "" {
+		state := netlink.VF_LINK_STATE_AUTO
+		switch defaults.LinkState {
+		case sriovnetworkv1.SriovCniStateEnable:
+			state = netlink.VF_LINK_STATE_ENABLE
+		case sriovnetworkv1.SriovCniStateDisable:
+			state = netlink.VF_LINK_STATE_DISABLE
+		}
+		if err := s.netlinkLib.LinkSetVfState(pfLink, vfID, state); err != nil {
+			return fmt.Errorf("failed to set link state for VF %d: %v", vfID, err)
+		}
+	}
+	if defaults.MinTxRate != nil || defaults.MaxTxRate != nil {
+		// min and max rates are set together, keep the current value of the rate which is not defined
+		current := sriovnetworkv1.VirtualFunction{VfID: vfID}
+		setVfAttributes(&current, pfLink.Attrs().Vfs)
+		minRate, maxRate := current.MinTxRate, current.MaxTxRate
+		if defaults.MinTxRate != nil {
+			minRate = *defaults.MinTxRate
+		}
+		if defaults.MaxTxRate != nil {
+			maxRate = *defaults.MaxTxRate
+		}
+		if err := s.netlinkLib.LinkSetVfRate(pfLink, vfID, minRate, maxRate); err != nil {
+			return fmt.Errorf("failed to set tx rate for VF %d: %v", vfID, err)
+		}
+	}
+	return nil
+}
+
 func (s *sriov) VFIsReady(pciAddr string) (netlink.Link, error) {
 	log.Log.Info("VFIsReady()", "device", pciAddr)
 	var err error
@@ -208,6 +288,23 @@ func (s *sriov) SetVfAdminMac(vfAddr string, pfLink, vfLink netlink.Link) error
 	return nil
 }
 
+// setVfAssignedMac sets the admin MAC address assigned by the policy to the VF, the VF is rebound to
+// its driver when the VF netdevice doesn't use the new admin MAC address yet
+func (s *sriov) setVfAssignedMac(vfAddr string, vfID int, mac string, pfLink, vfLink netlink.Link) error {
+	log.Log.Info("setVfAssignedMac()", "vf", vfAddr, "mac", mac)
+	hwAddr, err := net.ParseMAC(mac)
+	if err != nil {
+		return err
+	}
+	if err := s.netlinkLib.LinkSetVfHardwareAddr(pfLink, vfID, hwAddr); err != nil {
+		return err
+	}
+	if vfLink == nil || vfLink.Attrs().HardwareAddr.String() == hwAddr.String() {
+		return nil
+	}
+	return s.kernelHelper.RebindVfToDefaultDriver(vfAddr)
+}
+
 func (s *sriov) DiscoverSriovDevices(storeManager store.ManagerInterface) ([]sriovnetworkv1.InterfaceExt, error) {
 	log.Log.V(2).Info("DiscoverSriovDevices")
 	pfList := []sriovnetworkv1.InterfaceExt{}
@@ -278,6 +375,10 @@ func (s *sriov) DiscoverSriovDevices(storeManager store.ManagerInterface) ([]sri
 			LinkSpeed:      s.networkHelper.GetNetDevLinkSpeed(pfNetName),
 			LinkAdminState: s.networkHelper.GetNetDevLinkAdminState(pfNetName),
 		}
+		if device.Node != nil {
+			numaNode := device.Node.ID
+			iface.NumaNode = &numaNode
+		}
 
 		pfStatus, exist, err := storeManager.LoadPfsStatus(iface.PciAddress)
 		if err != nil {
@@ -292,6 +393,7 @@ func (s *sriov) DiscoverSriovDevices(storeManager store.ManagerInterface) ([]sri
 			iface.TotalVfs = s.dputilsLib.GetSriovVFcapacity(device.Address)
 			iface.NumVfs = s.dputilsLib.GetVFconfigured(device.Address)
 			iface.EswitchMode = s.GetNicSriovMode(device.Address)
+			iface.PfSettings = s.networkHelper.GetPfSettings(pfNetName)
 			if s.dputilsLib.SriovConfigured(device.Address) {
 				vfs, err := s.dputilsLib.GetVFList(device.Address)
 				if err != nil {
@@ -301,6 +403,7 @@ func (s *sriov) DiscoverSriovDevices(storeManager store.ManagerInterface) ([]sri
 				}
 				for _, vf := range vfs {
 					instance := s.getVfInfo(vf, pfNetName, iface.EswitchMode, devices)
+					setVfAttributes(&instance, link.Attrs().Vfs)
 					iface.VFs = append(iface.VFs, instance)
 				}
 			}
@@ -352,6 +455,19 @@ func (s *sriov) configSriovPFDevice(iface *sriovnetworkv1.Interface) error {
 			return err
 		}
 	}
+	if iface.PfSettings != nil {
+		// the name of the PF in the spec can be outdated, e.g. when the PF is renamed by udev
+		pfName := s.networkHelper.TryGetInterfaceName(iface.PciAddress)
+		if pfName == "" {
+			err := fmt.Errorf("failed to get netdevice for device %s", iface.PciAddress)
+			log.Log.Error(err, "configSriovPFDevice(): fail to apply PF settings", "device", iface.PciAddress)
+			return err
+		}
+		if err := s.networkHelper.ApplyPfSettings(pfName, iface.PfSettings); err != nil {
+			log.Log.Error(err, "configSriovPFDevice(): fail to apply PF settings", "device", iface.PciAddress)
+			return err
+		}
+	}
 	return nil
 }
 
@@ -470,6 +586,9 @@ func (s *sriov) configSriovVFDevices(iface *sriovnetworkv1.Interface) error {
 				continue
 			}
 
+			// admin MAC address assigned by the policy, empty when the VF keeps the kernel generated MAC
+			vfMac := group.MacForVf(vfID)
+
 			// only set GUID and MAC for VF with default driver
 			// for userspace drivers like vfio we configure the vf mac using the kernel nic mac address
 			// before we switch to the userspace driver
@@ -504,11 +623,27 @@ func (s *sriov) configSriovVFDevices(iface *sriovnetworkv1.Interface) error {
 							return err
 						}
 					}
-					if err = s.SetVfAdminMac(addr, pfLink, vfLink); err != nil {
+					if vfMac != "" {
+						err = s.setVfAssignedMac(addr, vfID, vfMac, pfLink, vfLink)
+					} else {
+						err = s.SetVfAdminMac(addr, pfLink, vfLink)
+					}
+					if err != nil {
 						log.Log.Error(err, "configSriovVFDevices(): fail to configure VF admin mac", "device", addr)
 						return err
 					}
 				}
+			} else if vfMac != "" {
+				// the VF is bound to a userspace driver, the admin MAC can only be set through the PF
+				if err := s.setVfAssignedMac(addr, vfID, vfMac, pfLink, nil); err != nil {
+					log.Log.Error(err, "configSriovVFDevices(): fail to configure VF admin mac", "device", addr)
+					return err
+				}
+			}
+
+			if err := s.configureVfDefaults(pfLink, vfID, group.VfDefaults); err != nil {
+				log.Log.Error(err, "configSriovVFDevices(): fail to configure VF default attributes", "device", addr)
+				return err
 			}
 
 			if err = s.kernelHelper.UnbindDriverIfNeeded(addr, group.IsRdma); err != nil {
@@ -776,10 +911,22 @@ func (s *sriov) resetSriovInterfaces(storeManager store.ManagerInterface, interf
 // / skipSriovConfig checks if we need to apply SR-IOV configuration specified specific interface
 func skipSriovConfig(iface *sriovnetworkv1.Interface, ifaceStatus *sriovnetworkv1.InterfaceExt, storeManager store.ManagerInterface) (bool, error) {
 	if !sriovnetworkv1.NeedToUpdateSriov(iface, ifaceStatus) {
+		// the VF defaults and the PF ring sizes and channels are not compared with the status,
+		// they are compared with the last applied configuration
+		applied, exist, err := storeManager.LoadPfsStatus(iface.PciAddress)
+		if err != nil {
+			log.Log.Error(err, "ConfigSriovInterfaces(): failed to load the PF applied status", "address", iface.PciAddress)
+			return false, err
+		}
+		if exist && (sriovnetworkv1.VfDefaultsChanged(iface, applied) || sriovnetworkv1.PfSettingsChanged(iface, applied)) {
+			log.Log.V(2).Info("ConfigSriovInterfaces(): VF default attributes or PF settings need update", "address", iface.PciAddress)
+			return false, nil
+		}
+
 		log.Log.V(2).Info("ConfigSriovInterfaces(): no need update interface", "address", iface.PciAddress)
 
 		// Save the PF status to the host
-		err := storeManager.SaveLastPfAppliedStatus(iface)
+		err = storeManager.SaveLastPfAppliedStatus(iface)
 		if err != nil {
 			log.Log.Error(err, "ConfigSriovInterfaces(): failed to save PF applied status config to host")
 			return false, err
@@ -83,9 +83,12 @@ var _ = Describe("SRIOV", func() {
 				MTU:          1500,
 				HardwareAddr: mac,
 				EncapType:    "ether",
+				Vfs:          []netlink.VfInfo{{ID: 0, Spoofchk: true, Trust: 1, MaxTxRate: 1000}},
 			}).MinTimes(1)
 			hostMock.EXPECT().GetNetDevLinkSpeed("enp216s0f0np0").Return("100000 Mb/s")
 			hostMock.EXPECT().GetNetDevLinkAdminState("enp216s0f0np0").Return("up")
+			hostMock.EXPECT().GetPfSettings("enp216s0f0np0").Return(&sriovnetworkv1.PfSettings{
+				Features: map[string]bool{"rx-vlan-filter": true}})
 			hostMock.EXPECT().GetNetDevNodeGUID("0000:d8:00.2").Return("guid1")
 			storeManagerMode.EXPECT().LoadPfsStatus("0000:d8:00.0").Return(nil, false, nil)
 
@@ -130,6 +133,8 @@ var _ = Describe("SRIOV", func() {
 				EswitchMode:       "switchdev",
 				ExternallyManaged: false,
 				TotalVfs:          1,
+				PfSettings: &sriovnetworkv1.PfSettings{
+					Features: map[string]bool{"rx-vlan-filter": true}},
 				VFs: []sriovnetworkv1.VirtualFunction{{
 					Name:            "enp216s0f0v0",
 					Mac:             "4e:fd:3d:08:59:b1",
@@ -141,11 +146,65 @@ var _ = Describe("SRIOV", func() {
 					VfID:            0,
 					RepresentorName: "enp216s0f0np0_0",
 					GUID:            "guid1",
+					SpoofChk:        "on",
+					Trust:           "on",
+					LinkState:       "auto",
+					MaxTxRate:       1000,
 				}},
 			}))
 		})
 	})
 
+	Context("configureVfDefaults", func() {
+		It("should do nothing without defaults", func() {
+			pfLinkMock := netlinkMockPkg.NewMockLink(testCtrl)
+			Expect(s.(*sriov).configureVfDefaults(pfLinkMock, 1, nil)).NotTo(HaveOccurred())
+		})
+		It("should set the defaults", func() {
+			pfLinkMock := netlinkMockPkg.NewMockLink(testCtrl)
+			pfLinkMock.EXPECT().Attrs().Return(&netlink.LinkAttrs{
+				Name: "enp216s0f0np0",
+				Vfs:  []netlink.VfInfo{{ID: 1, MinTxRate: 100, MaxTxRate: 1000}},
+			}).MinTimes(1)
+			netlinkLibMock.EXPECT().LinkSetVfSpoofchk(pfLinkMock, 1, false).Return(nil)
+			netlinkLibMock.EXPECT().LinkSetVfTrust(pfLinkMock, 1, true).Return(nil)
+			netlinkLibMock.EXPECT().LinkSetVfState(pfLinkMock, 1, netlink.VF_LINK_STATE_ENABLE).Return(nil)
+			netlinkLibMock.EXPECT().LinkSetVfRate(pfLinkMock, 1, 100, 5000).Return(nil)
+			maxTxRate := 5000
+			Expect(s.(*sriov).configureVfDefaults(pfLinkMock, 1, &sriovnetworkv1.VfDefaults{
+				SpoofChk:  "off",
+				Trust:     "on",
+				LinkState: "enable",
+				MaxTxRate: &maxTxRate,
+			})).NotTo(HaveOccurred())
+		})
+		It("should fail if the attribute can't be set", func() {
+			pfLinkMock := netlinkMockPkg.NewMockLink(testCtrl)
+			pfLinkMock.EXPECT().Attrs().Return(&netlink.LinkAttrs{Name: "enp216s0f0np0"})
+			netlinkLibMock.EXPECT().LinkSetVfTrust(pfLinkMock, 1, false).Return(testError)
+			Expect(s.(*sriov).configureVfDefaults(pfLinkMock, 1, &sriovnetworkv1.VfDefaults{
+				Trust: "off",
+			})).To(MatchError(ContainSubstring("failed to set trust")))
+		})
+	})
+	Context("setVfAssignedMac", func() {
+		It("should set the admin MAC and rebind the VF", func() {
+			pfLinkMock := netlinkMockPkg.NewMockLink(testCtrl)
+			vfLinkMock := netlinkMockPkg.NewMockLink(testCtrl)
+			oldMac, _ := net.ParseMAC("4e:fd:3d:08:59:b1")
+			newMac, _ := net.ParseMAC("02:5a:00:00:00:01")
+			vfLinkMock.EXPECT().Attrs().Return(&netlink.LinkAttrs{HardwareAddr: oldMac})
+			netlinkLibMock.EXPECT().LinkSetVfHardwareAddr(pfLinkMock, 1, newMac).Return(nil)
+			hostMock.EXPECT().RebindVfToDefaultDriver("0000:d8:00.3").Return(nil)
+			Expect(s.(*sriov).setVfAssignedMac("0000:d8:00.3", 1, "02:5a:00:00:00:01", pfLinkMock, vfLinkMock)).NotTo(HaveOccurred())
+		})
+		It("should only set the admin MAC for userspace drivers", func() {
+			pfLinkMock := netlinkMockPkg.NewMockLink(testCtrl)
+			newMac, _ := net.ParseMAC("02:5a:00:00:00:01")
+			netlinkLibMock.EXPECT().LinkSetVfHardwareAddr(pfLinkMock, 1, newMac).Return(nil)
+			Expect(s.(*sriov).setVfAssignedMac("0000:d8:00.3", 1, "02:5a:00:00:00:01", pfLinkMock, nil)).NotTo(HaveOccurred())
+		})
+	})
 	Context("SetSriovNumVfs", func() {
 		It("set", func() {
 			helpers.GinkgoConfigureFakeFS(&fakefilesystem.FS{
@@ -216,6 +275,9 @@ var _ = Describe("SRIOV", func() {
 			hostMock.EXPECT().RemovePersistPFNameUdevRule("0000:d8:00.0").Return(nil)
 			hostMock.EXPECT().RemoveVfRepresentorUdevRule("0000:d8:00.0").Return(nil)
 			hostMock.EXPECT().AddDisableNMUdevRule("0000:d8:00.0").Return(nil)
+			hostMock.EXPECT().TryGetInterfaceName("0000:d8:00.0").Return("enp216s0f0np0")
+			hostMock.EXPECT().ApplyPfSettings("enp216s0f0np0", &sriovnetworkv1.PfSettings{
+				Features: map[string]bool{"rx-vlan-filter": false}}).Return(nil)
 			dputilsLibMock.EXPECT().GetVFList("0000:d8:00.0").Return([]string{"0000:d8:00.2", "0000:d8:00.3"}, nil)
 			pfLinkMock := netlinkMockPkg.NewMockLink(testCtrl)
 			netlinkLibMock.EXPECT().LinkByName("enp216s0f0np0").Return(pfLinkMock, nil).Times(3)
@@ -249,6 +311,8 @@ var _ = Describe("SRIOV", func() {
 					Name:       "enp216s0f0np0",
 					PciAddress: "0000:d8:00.0",
 					NumVfs:     2,
+					PfSettings: &sriovnetworkv1.PfSettings{
+						Features: map[string]bool{"rx-vlan-filter": false}},
 					VfGroups: []sriovnetworkv1.VfGroup{
 						{
 							VfRange:      "0-0",
@@ -79,6 +79,20 @@ func (mr *MockHostManagerInterfaceMockRecorder) AddVfRepresentorUdevRule(pfPciAd
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVfRepresentorUdevRule", reflect.TypeOf((*MockHostManagerInterface)(nil).AddVfRepresentorUdevRule), pfPciAddress, pfName, pfSwitchID, pfSwitchPort)
 }
 
+// ApplyPfSettings mocks base method.
+func (m *MockHostManagerInterface) ApplyPfSettings(ifaceName string, settings *v1.PfSettings) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyPfSettings", ifaceName, settings)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyPfSettings indicates an expected call of ApplyPfSettings.
+func (mr *MockHostManagerInterfaceMockRecorder) ApplyPfSettings(ifaceName, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyPfSettings", reflect.TypeOf((*MockHostManagerInterface)(nil).ApplyPfSettings), ifaceName, settings)
+}
+
 // BindDefaultDriver mocks base method.
 func (m *MockHostManagerInterface) BindDefaultDriver(pciAddr string) error {
 	m.ctrl.T.Helper()
@@ -524,6 +538,20 @@ func (mr *MockHostManagerInterfaceMockRecorder) GetPciAddressFromInterfaceName(i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPciAddressFromInterfaceName", reflect.TypeOf((*MockHostManagerInterface)(nil).GetPciAddressFromInterfaceName), interfaceName)
 }
 
+// GetPfSettings mocks base method.
+func (m *MockHostManagerInterface) GetPfSettings(ifaceName string) *v1.PfSettings {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPfSettings", ifaceName)
+	ret0, _ := ret[0].(*v1.PfSettings)
+	return ret0
+}
+
+// GetPfSettings indicates an expected call of GetPfSettings.
+func (mr *MockHostManagerInterfaceMockRecorder) GetPfSettings(ifaceName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPfSettings", reflect.TypeOf((*MockHostManagerInterface)(nil).GetPfSettings), ifaceName)
+}
+
 // GetPhysPortName mocks base method.
 func (m *MockHostManagerInterface) GetPhysPortName(name string) (string, error) {
 	m.ctrl.T.Helper()
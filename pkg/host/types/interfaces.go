@@ -86,6 +86,10 @@ type NetworkInterface interface {
 	SetDevlinkDeviceParam(pciAddr, paramName, value string) error
 	// EnableHwTcOffload make sure that hw-tc-offload feature is enabled if device supports it
 	EnableHwTcOffload(ifaceName string) error
+	// ApplyPfSettings applies ethtool settings (ring sizes, channels, features and pause frames) to the PF
+	ApplyPfSettings(ifaceName string, settings *sriovnetworkv1.PfSettings) error
+	// GetPfSettings returns the current ethtool settings of the PF
+	GetPfSettings(ifaceName string) *sriovnetworkv1.PfSettings
 	// GetNetDevLinkAdminState returns the admin state of the interface.
 	GetNetDevLinkAdminState(ifaceName string) string
 	// GetPciAddressFromInterfaceName parses sysfs to get pci address of an interface by name
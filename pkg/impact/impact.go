@@ -0,0 +1,175 @@
+package impact
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	mlx "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/vendors/mellanox"
+)
+
+// NodeImpact is the estimated disruption caused by a change of the desired configuration of a node
+type NodeImpact struct {
+	// Drain is true if the node has to be drained
+	Drain bool
+	// KernelArgsReboot is true if the node has to be rebooted to update the kernel args
+	KernelArgsReboot bool
+	// FirmwareReboot is true if the node has to be rebooted to apply a firmware change
+	FirmwareReboot bool
+	// EswitchModeChanges is the number of PFs which switch eswitch mode
+	EswitchModeChanges int
+}
+
+// Estimate applies the config-daemon decision logic to the difference between the current spec
+// of the node state and the next spec the operator would render for the node
+func Estimate(ns *sriovnetworkv1.SriovNetworkNodeState, next *sriovnetworkv1.SriovNetworkNodeStateSpec) NodeImpact {
+	impact := NodeImpact{}
+	// the managed bridges are reconfigured with a drain
+	if !equality.Semantic.DeepEqual(next.Bridges, ns.Spec.Bridges) &&
+		sriovnetworkv1.NeedToUpdateBridges(&next.Bridges, &ns.Status.Bridges) {
+		impact.Drain = true
+	}
+	if equality.Semantic.DeepEqual(next.Interfaces, ns.Spec.Interfaces) {
+		return impact
+	}
+
+	impact.Drain = impact.Drain || sriovnetworkv1.NeedToUpdateVFs(next, &ns.Status, func(pciAddress string) *sriovnetworkv1.Interface {
+		// the current spec is the configuration applied on the PFs, the VFs of a PF removed from
+		// the spec are reset only if they were created by the operator
+		for i := range ns.Spec.Interfaces {
+			if ns.Spec.Interfaces[i].PciAddress == pciAddress {
+				return &ns.Spec.Interfaces[i]
+			}
+		}
+		return nil
+	})
+
+	// the vfio-pci driver requires the IOMMU kernel args
+	impact.KernelArgsReboot = specUsesDeviceType(next, consts.DeviceTypeVfioPci) &&
+		!specUsesDeviceType(&ns.Spec, consts.DeviceTypeVfioPci) && !statusUsesDriver(&ns.Status, consts.DeviceTypeVfioPci)
+
+	impact.FirmwareReboot = needMlxFirmwareReboot(next, &ns.Status) && !needMlxFirmwareReboot(&ns.Spec, &ns.Status)
+
+	for i := range next.Interfaces {
+		iface := &next.Interfaces[i]
+		desired := sriovnetworkv1.GetEswitchModeFromSpec(iface)
+		status := ns.GetInterfaceStateByPciAddress(iface.PciAddress)
+		if status == nil || desired == sriovnetworkv1.GetEswitchModeFromStatus(status) {
+			continue
+		}
+		if eswitchModePending(&ns.Spec, iface.PciAddress, desired) {
+			continue
+		}
+		impact.EswitchModeChanges++
+	}
+
+	if impact.KernelArgsReboot || impact.FirmwareReboot {
+		impact.Drain = true
+	}
+	return impact
+}
+
+// RebootRequired returns true if the node has to be rebooted
+func (i NodeImpact) RebootRequired() bool {
+	return i.KernelArgsReboot || i.FirmwareReboot
+}
+
+// eswitchModePending returns true if the current spec already requests the eswitch mode for the PF
+func eswitchModePending(spec *sriovnetworkv1.SriovNetworkNodeStateSpec, pciAddress, mode string) bool {
+	for i := range spec.Interfaces {
+		if spec.Interfaces[i].PciAddress == pciAddress {
+			return sriovnetworkv1.GetEswitchModeFromSpec(&spec.Interfaces[i]) == mode
+		}
+	}
+	return false
+}
+
+func specUsesDeviceType(spec *sriovnetworkv1.SriovNetworkNodeStateSpec, deviceType string) bool {
+	for _, iface := range spec.Interfaces {
+		for _, group := range iface.VfGroups {
+			if group.DeviceType == deviceType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func statusUsesDriver(status *sriovnetworkv1.SriovNetworkNodeStateStatus, driver string) bool {
+	for _, iface := range status.Interfaces {
+		for _, vf := range iface.VFs {
+			if vf.Driver == driver {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// needMlxFirmwareReboot runs the firmware handlers of the mellanox plugin on the Mellanox NICs of the spec.
+// The firmware configuration can only be read on the node, it is estimated from the total number of VFs
+// and the link type reported in the status, which reflect the firmware configuration of the current boot.
+func needMlxFirmwareReboot(spec *sriovnetworkv1.SriovNetworkNodeStateSpec, status *sriovnetworkv1.SriovNetworkNodeStateStatus) bool {
+	mellanoxNicsStatus := map[string]map[string]sriovnetworkv1.InterfaceExt{}
+	for _, iface := range status.Interfaces {
+		if iface.Vendor != mlx.MellanoxVendorID {
+			continue
+		}
+		pciPrefix := mlx.GetPciAddressPrefix(iface.PciAddress)
+		if _, ok := mellanoxNicsStatus[pciPrefix]; !ok {
+			mellanoxNicsStatus[pciPrefix] = map[string]sriovnetworkv1.InterfaceExt{}
+		}
+		mellanoxNicsStatus[pciPrefix][iface.PciAddress] = iface
+	}
+
+	mellanoxNicsSpec := map[string]sriovnetworkv1.Interface{}
+	for _, iface := range spec.Interfaces {
+		if _, ok := mellanoxNicsStatus[mlx.GetPciAddressPrefix(iface.PciAddress)]; ok {
+			mellanoxNicsSpec[iface.PciAddress] = iface
+		}
+	}
+
+	for _, ifaceSpec := range mellanoxNicsSpec {
+		pciPrefix := mlx.GetPciAddressPrefix(ifaceSpec.PciAddress)
+		fwData := mlxFirmwareFromStatus(mellanoxNicsStatus[pciPrefix], pciPrefix)
+		attrs := &mlx.MlxNic{TotalVfs: -1}
+
+		isDualPort := mlx.IsDualPort(ifaceSpec.PciAddress, mellanoxNicsStatus)
+		totalVfs, totalVfsNeedReboot, _ := mlx.HandleTotalVfs(fwData, fwData, attrs, ifaceSpec, isDualPort, mellanoxNicsSpec)
+		sriovEnNeedReboot, _ := mlx.HandleEnableSriov(totalVfs, fwData, fwData, attrs)
+		needLinkChange, err := mlx.HandleLinkType(pciPrefix, fwData, attrs, mellanoxNicsSpec, mellanoxNicsStatus)
+		if err != nil {
+			log.Log.V(2).Info("needMlxFirmwareReboot(): failed to check the link type", "pciAddress", ifaceSpec.PciAddress, "error", err)
+			continue
+		}
+		if totalVfsNeedReboot || sriovEnNeedReboot || needLinkChange {
+			return true
+		}
+	}
+	return false
+}
+
+func mlxFirmwareFromStatus(ports map[string]sriovnetworkv1.InterfaceExt, pciPrefix string) *mlx.MlxNic {
+	fwData := &mlx.MlxNic{
+		LinkTypeP1: mlxFirmwareLinkType(ports, pciPrefix+"0"),
+		LinkTypeP2: mlxFirmwareLinkType(ports, pciPrefix+"1"),
+	}
+	for _, port := range ports {
+		if port.TotalVfs > fwData.TotalVfs {
+			fwData.TotalVfs = port.TotalVfs
+		}
+	}
+	fwData.EnableSriov = fwData.TotalVfs > 0
+	return fwData
+}
+
+func mlxFirmwareLinkType(ports map[string]sriovnetworkv1.InterfaceExt, pciAddress string) string {
+	port, ok := ports[pciAddress]
+	if !ok || port.LinkType == "" {
+		return mlx.PreconfiguredLinkType
+	}
+	return strings.ToUpper(port.LinkType)
+}
@@ -373,54 +373,26 @@ func (p *GenericPlugin) needDrainNode(desired sriovnetworkv1.SriovNetworkNodeSta
 }
 
 func (p *GenericPlugin) needToUpdateVFs(desired sriovnetworkv1.SriovNetworkNodeStateSpec, current sriovnetworkv1.SriovNetworkNodeStateStatus) bool {
-	for _, ifaceStatus := range current.Interfaces {
-		configured := false
-		for _, iface := range desired.Interfaces {
-			if iface.PciAddress == ifaceStatus.PciAddress {
-				configured = true
-				if ifaceStatus.NumVfs == 0 {
-					log.Log.V(2).Info("generic plugin needToUpdateVFs(): no need drain, for PCI address, current NumVfs is 0",
-						"address", iface.PciAddress)
-					break
-				}
-				if sriovnetworkv1.NeedToUpdateSriov(&iface, &ifaceStatus) {
-					log.Log.V(2).Info("generic plugin needToUpdateVFs(): need drain, for PCI address request update",
-						"address", iface.PciAddress)
-					return true
-				}
-				log.Log.V(2).Info("generic plugin needToUpdateVFs(): no need drain,for PCI address",
-					"address", iface.PciAddress, "expected-vfs", iface.NumVfs, "current-vfs", ifaceStatus.NumVfs)
-			}
-		}
-		if !configured && ifaceStatus.NumVfs > 0 {
-			// load the PF info
-			pfStatus, exist, err := p.helpers.LoadPfsStatus(ifaceStatus.PciAddress)
-			if err != nil {
-				log.Log.Error(err, "generic plugin needToUpdateVFs(): failed to load info about PF status for pci device",
-					"address", ifaceStatus.PciAddress)
-				continue
-			}
-
-			if !exist {
-				log.Log.Info("generic plugin needToUpdateVFs(): PF name with pci address has VFs configured but they weren't created by the sriov operator. Skipping drain",
-					"name", ifaceStatus.Name,
-					"address", ifaceStatus.PciAddress)
-				continue
-			}
+	return sriovnetworkv1.NeedToUpdateVFs(&desired, &current, p.lastAppliedPf)
+}
 
-			if pfStatus.ExternallyManaged {
-				log.Log.Info("generic plugin needToUpdateVFs(): PF name with pci address was externally created. Skipping drain",
-					"name", ifaceStatus.Name,
-					"address", ifaceStatus.PciAddress)
-				continue
-			}
+// lastAppliedPf returns the configuration last applied on a PF, or nil if its VFs weren't created by the operator
+func (p *GenericPlugin) lastAppliedPf(pciAddress string) *sriovnetworkv1.Interface {
+	// load the PF info
+	pfStatus, exist, err := p.helpers.LoadPfsStatus(pciAddress)
+	if err != nil {
+		log.Log.Error(err, "generic plugin needToUpdateVFs(): failed to load info about PF status for pci device",
+			"address", pciAddress)
+		return nil
+	}
 
-			log.Log.V(2).Info("generic plugin needToUpdateVFs(): need drain since interface needs to be reset",
-				"interface", ifaceStatus)
-			return true
-		}
+	if !exist {
+		log.Log.V(2).Info("generic plugin needToUpdateVFs(): PF with pci address has no configuration applied by the sriov operator",
+			"address", pciAddress)
+		return nil
 	}
-	return false
+
+	return pfStatus
 }
 
 func (p *GenericPlugin) shouldConfigureBridges() bool {
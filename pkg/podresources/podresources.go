@@ -0,0 +1,65 @@
+package podresources
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
+)
+
+const (
+	// connectionTimeout is the timeout to connect to the kubelet and to list the pod resources
+	connectionTimeout = 10 * time.Second
+	// maxMessageSize is the maximum size of the pod resources list
+	maxMessageSize = 16 * 1024 * 1024
+)
+
+//go:generate ../../bin/mockgen -destination mock/mock_podresources.go -source podresources.go
+type Interface interface {
+	// AllocatedDevices returns the IDs of the devices the kubelet allocated to the containers of the node,
+	// with the namespace/name of the pod holding them
+	AllocatedDevices(ctx context.Context) (map[string]string, error)
+}
+
+type podResources struct {
+	socketPath string
+}
+
+// New returns a client of the kubelet pod-resources API listening on the socket
+func New(socketPath string) Interface {
+	return &podResources{socketPath: socketPath}
+}
+
+func (p *podResources) AllocatedDevices(ctx context.Context) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, connectionTimeout)
+	defer cancel()
+
+	conn, err := grpc.DialContext(ctx, "unix://"+p.socketPath,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock(),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxMessageSize)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the kubelet pod-resources socket %s: %v", p.socketPath, err)
+	}
+	defer conn.Close()
+
+	resp, err := podresourcesapi.NewPodResourcesListerClient(conn).List(ctx, &podresourcesapi.ListPodResourcesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the pod resources: %v", err)
+	}
+
+	devices := map[string]string{}
+	for _, pod := range resp.GetPodResources() {
+		for _, container := range pod.GetContainers() {
+			for _, device := range container.GetDevices() {
+				for _, id := range device.GetDeviceIds() {
+					devices[id] = pod.GetNamespace() + "/" + pod.GetName()
+				}
+			}
+		}
+	}
+	return devices, nil
+}
@@ -151,6 +151,25 @@ func AnnotateObject(ctx context.Context, obj client.Object, key, value string, c
 	return nil
 }
 
+// RemoveAnnotationFromObject removes an annotation from a kubernetes object
+func RemoveAnnotationFromObject(ctx context.Context, obj client.Object, key string, c client.Client) error {
+	if !ObjectHasAnnotationKey(obj, key) {
+		return nil
+	}
+	log.Log.V(2).Info("RemoveAnnotationFromObject(): Remove annotation from object",
+		"objectName", obj.GetName(),
+		"objectKind", obj.GetObjectKind(),
+		"annotationKey", key)
+	newObj := obj.DeepCopyObject().(client.Object)
+	delete(newObj.GetAnnotations(), key)
+	err := c.Patch(ctx, newObj, client.MergeFrom(obj))
+	if err != nil {
+		log.Log.Error(err, "RemoveAnnotationFromObject(): Failed to patch object")
+		return err
+	}
+	return nil
+}
+
 // AnnotateNode add annotation to a node
 func AnnotateNode(ctx context.Context, nodeName string, key, value string, c client.Client) error {
 	node := &corev1.Node{}
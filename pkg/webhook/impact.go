@@ -0,0 +1,158 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
+	"github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/impact"
+)
+
+// policyImpactWarnings estimates the disruption caused by the creation or the update of the policy
+// on the nodes it selects or used to select, and returns it as admission warnings
+func policyImpactWarnings(cr *sriovnetworkv1.SriovNetworkNodePolicy, nodes []corev1.Node,
+	nsList *sriovnetworkv1.SriovNetworkNodeStateList, npList *sriovnetworkv1.SriovNetworkNodePolicyList,
+	manageSoftwareBridges bool) []string {
+	if cr.IsDryRun() {
+		return []string{fmt.Sprintf("SriovNetworkNodePolicy %s is in dry-run mode, the changes it would cause are reported in its status", cr.Name)}
+	}
+
+	nodesByName := map[string]*corev1.Node{}
+	for i := range nodes {
+		nodesByName[nodes[i].Name] = &nodes[i]
+	}
+
+	// the nodes configured by the previous version of the policy are impacted as well
+	for _, ns := range nsList.Items {
+		if _, ok := nodesByName[ns.Name]; ok || !nodeStateUsesPolicy(&ns, cr.Name) {
+			continue
+		}
+		node, err := kubeclient.CoreV1().Nodes().Get(context.Background(), ns.Name, metav1.GetOptions{})
+		if err != nil {
+			log.Log.V(2).Info("policyImpactWarnings(): failed to get node", "node", ns.Name, "error", err)
+			continue
+		}
+		nodesByName[node.Name] = node
+	}
+
+	next := make([]sriovnetworkv1.SriovNetworkNodePolicy, 0, len(npList.Items)+1)
+	for _, p := range npList.Items {
+		if p.Name != cr.Name && !p.IsDryRun() {
+			next = append(next, p)
+		}
+	}
+	next = append(next, *cr)
+	sort.Sort(sriovnetworkv1.ByPriority(next))
+
+	var drainNodes, rebootNodes, eswitchModeChanges int
+	var kernelArgs, firmware bool
+	for i := range nsList.Items {
+		ns := &nsList.Items[i]
+		node, ok := nodesByName[ns.Name]
+		if !ok {
+			continue
+		}
+		if len(ns.Status.Interfaces) == 0 {
+			// the operator doesn't update the spec of a node state without interfaces
+			continue
+		}
+		spec, err := renderNodeStateSpec(ns, node, next, manageSoftwareBridges)
+		if err != nil {
+			log.Log.V(2).Info("policyImpactWarnings(): failed to render the node state", "node", ns.Name, "error", err)
+			continue
+		}
+		nodeImpact := impact.Estimate(ns, spec)
+		if nodeImpact.Drain {
+			drainNodes++
+		}
+		if nodeImpact.RebootRequired() {
+			rebootNodes++
+		}
+		kernelArgs = kernelArgs || nodeImpact.KernelArgsReboot
+		firmware = firmware || nodeImpact.FirmwareReboot
+		eswitchModeChanges += nodeImpact.EswitchModeChanges
+	}
+
+	var effects []string
+	if drainNodes > 0 {
+		effects = append(effects, fmt.Sprintf("drain %d node(s)", drainNodes))
+	}
+	if rebootNodes > 0 {
+		var reasons []string
+		if kernelArgs {
+			reasons = append(reasons, "kernel args")
+		}
+		if firmware {
+			reasons = append(reasons, "firmware")
+		}
+		effects = append(effects, fmt.Sprintf("reboot %d node(s) (%s)", rebootNodes, strings.Join(reasons, " / ")))
+	}
+	if eswitchModeChanges > 0 {
+		effects = append(effects, fmt.Sprintf("switch the eswitch mode of %d PF(s)", eswitchModeChanges))
+	}
+	if len(effects) == 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("SriovNetworkNodePolicy %s: applying this change will %s", cr.Name, strings.Join(effects, ", "))}
+}
+
+func nodeStateUsesPolicy(ns *sriovnetworkv1.SriovNetworkNodeState, policyName string) bool {
+	for _, iface := range ns.Spec.Interfaces {
+		for _, group := range iface.VfGroups {
+			if group.PolicyName == policyName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// renderNodeStateSpec applies the policies to the node state the same way the policy controller does
+func renderNodeStateSpec(ns *sriovnetworkv1.SriovNetworkNodeState, node *corev1.Node, policies []sriovnetworkv1.SriovNetworkNodePolicy,
+	manageSoftwareBridges bool) (*sriovnetworkv1.SriovNetworkNodeStateSpec, error) {
+	newVersion := ns.DeepCopy()
+	newVersion.Spec = sriovnetworkv1.SriovNetworkNodeStateSpec{System: ns.Spec.System}
+	if err := newVersion.ApplyPolicies(policies, node, manageSoftwareBridges); err != nil {
+		return nil, err
+	}
+
+	// the VF MAC addresses are assigned by the controller, keep the current ones
+	for i := range newVersion.Spec.Interfaces {
+		iface := &newVersion.Spec.Interfaces[i]
+		for j := range iface.VfGroups {
+			iface.VfGroups[j].VfMacs = currentVfMacs(ns, iface.PciAddress, iface.VfGroups[j].PolicyName)
+		}
+	}
+	return &newVersion.Spec, nil
+}
+
+func currentVfMacs(ns *sriovnetworkv1.SriovNetworkNodeState, pciAddress, policyName string) []sriovnetworkv1.VfMac {
+	for _, iface := range ns.Spec.Interfaces {
+		if iface.PciAddress != pciAddress {
+			continue
+		}
+		for _, group := range iface.VfGroups {
+			if group.PolicyName == policyName {
+				return group.VfMacs
+			}
+		}
+	}
+	return nil
+}
+
+// manageSoftwareBridges returns true if the operator renders the bridge configuration of the node states
+func manageSoftwareBridges() bool {
+	config, err := snclient.SriovnetworkV1().SriovOperatorConfigs(namespace).Get(context.Background(), consts.DefaultConfigName, metav1.GetOptions{})
+	if err != nil {
+		log.Log.V(2).Info("manageSoftwareBridges(): failed to get the default SriovOperatorConfig", "error", err)
+		return false
+	}
+	return config.Spec.FeatureGates[consts.ManageSoftwareBridgesFeatureGate]
+}
@@ -0,0 +1,148 @@
+package webhook
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+)
+
+func newImpactNode(name string) corev1.Node {
+	return corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   name,
+		Labels: map[string]string{"feature.node.kubernetes.io/network-sriov.capable": "true"},
+	}}
+}
+
+func newImpactPolicy(pfName string, numVfs int) *SriovNetworkNodePolicy {
+	return &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "p1", Namespace: namespace},
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType:   "netdevice",
+			NicSelector:  SriovNetworkNicSelector{PfNames: []string{pfName}},
+			NodeSelector: map[string]string{"feature.node.kubernetes.io/network-sriov.capable": "true"},
+			NumVfs:       numVfs,
+			Priority:     99,
+			ResourceName: "nic1",
+		},
+	}
+}
+
+func newImpactPf(name, pciAddress, vendor string, numVfs, totalVfs int) InterfaceExt {
+	iface := InterfaceExt{
+		Name:       name,
+		PciAddress: pciAddress,
+		Vendor:     vendor,
+		Mtu:        1500,
+		NumVfs:     numVfs,
+		TotalVfs:   totalVfs,
+		LinkType:   "ETH",
+	}
+	for i := 0; i < numVfs; i++ {
+		iface.VFs = append(iface.VFs, VirtualFunction{VfID: i, Driver: "iavf", Mtu: 1500})
+	}
+	return iface
+}
+
+// newImpactNodeState returns a node state where the policy is already applied
+func newImpactNodeState(t *testing.T, node corev1.Node, policy *SriovNetworkNodePolicy, pfs ...InterfaceExt) *SriovNetworkNodeState {
+	ns := &SriovNetworkNodeState{
+		ObjectMeta: metav1.ObjectMeta{Name: node.Name, Namespace: namespace},
+		Status:     SriovNetworkNodeStateStatus{Interfaces: pfs},
+	}
+	spec, err := renderNodeStateSpec(ns, &node, []SriovNetworkNodePolicy{*policy}, false)
+	if err != nil {
+		t.Fatalf("failed to render the node state: %v", err)
+	}
+	ns.Spec = *spec
+	return ns
+}
+
+func TestPolicyImpactWarnings(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	current := newImpactPolicy("ens803f0", 4)
+	nodes := []corev1.Node{newImpactNode("worker-0"), newImpactNode("worker-1")}
+	nsList := &SriovNetworkNodeStateList{}
+	for _, node := range nodes {
+		nsList.Items = append(nsList.Items, *newImpactNodeState(t, node, current,
+			newImpactPf("ens803f0", "0000:86:00.0", "8086", 4, 64)))
+	}
+	npList := &SriovNetworkNodePolicyList{Items: []SriovNetworkNodePolicy{*current}}
+
+	// no change
+	g.Expect(policyImpactWarnings(current, nodes, nsList, npList, false)).To(BeEmpty())
+
+	// a bigger MTU requires to reconfigure the PF
+	policy := current.DeepCopy()
+	policy.Spec.Mtu = 9000
+	g.Expect(policyImpactWarnings(policy, nodes, nsList, npList, false)).To(ConsistOf(
+		"SriovNetworkNodePolicy p1: applying this change will drain 2 node(s)"))
+
+	// the vfio-pci driver requires the IOMMU kernel args
+	policy = current.DeepCopy()
+	policy.Spec.DeviceType = "vfio-pci"
+	g.Expect(policyImpactWarnings(policy, nodes, nsList, npList, false)).To(ConsistOf(
+		"SriovNetworkNodePolicy p1: applying this change will drain 2 node(s), reboot 2 node(s) (kernel args)"))
+
+	// a policy in dry-run mode is not applied
+	policy.Annotations = map[string]string{"sriovnetwork.openshift.io/dry-run": "true"}
+	g.Expect(policyImpactWarnings(policy, nodes, nsList, npList, false)).To(ConsistOf(
+		"SriovNetworkNodePolicy p1 is in dry-run mode, the changes it would cause are reported in its status"))
+
+	policy = current.DeepCopy()
+	policy.Spec.EswitchMode = ESwithModeSwitchDev
+	g.Expect(policyImpactWarnings(policy, nodes[:1], &SriovNetworkNodeStateList{Items: nsList.Items[:1]}, npList, false)).To(ConsistOf(
+		"SriovNetworkNodePolicy p1: applying this change will drain 1 node(s), switch the eswitch mode of 1 PF(s)"))
+}
+
+func TestPolicyImpactWarningsMellanoxFirmware(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	current := newImpactPolicy("ens1f0", 8)
+	node := newImpactNode("worker-0")
+	ns := newImpactNodeState(t, node, current,
+		newImpactPf("ens1f0", "0000:3b:00.0", "15b3", 8, 8),
+		newImpactPf("ens1f1", "0000:3b:00.1", "15b3", 0, 8))
+	nsList := &SriovNetworkNodeStateList{Items: []SriovNetworkNodeState{*ns}}
+	npList := &SriovNetworkNodePolicyList{Items: []SriovNetworkNodePolicy{*current}}
+
+	// the firmware configuration doesn't depend on the MTU
+	policy := current.DeepCopy()
+	policy.Spec.Mtu = 9000
+	g.Expect(policyImpactWarnings(policy, []corev1.Node{node}, nsList, npList, false)).To(ConsistOf(
+		"SriovNetworkNodePolicy p1: applying this change will drain 1 node(s)"))
+
+	// the total number of VFs of the firmware configuration follows the policy
+	policy = newImpactPolicy("ens1f0", 16)
+	g.Expect(policyImpactWarnings(policy, []corev1.Node{node}, nsList, npList, false)).To(ConsistOf(
+		"SriovNetworkNodePolicy p1: applying this change will drain 1 node(s), reboot 1 node(s) (firmware)"))
+
+	policy = current.DeepCopy()
+	policy.Spec.LinkType = "IB"
+	g.Expect(policyImpactWarnings(policy, []corev1.Node{node}, nsList, npList, false)).To(ConsistOf(
+		"SriovNetworkNodePolicy p1: applying this change will drain 1 node(s), reboot 1 node(s) (firmware)"))
+}
+
+func TestPolicyImpactWarningsSoftwareBridges(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	current := newImpactPolicy("ens1f0", 4)
+	current.Spec.EswitchMode = ESwithModeSwitchDev
+	node := newImpactNode("worker-0")
+	pf := newImpactPf("ens1f0", "0000:3b:00.0", "15b3", 4, 8)
+	pf.EswitchMode = ESwithModeSwitchDev
+	ns := newImpactNodeState(t, node, current, pf)
+	nsList := &SriovNetworkNodeStateList{Items: []SriovNetworkNodeState{*ns}}
+	npList := &SriovNetworkNodePolicyList{Items: []SriovNetworkNodePolicy{*current}}
+
+	policy := current.DeepCopy()
+	policy.Spec.Bridge.OVS = &OVSConfig{}
+	// the bridge configuration is only rendered when the operator manages the software bridges
+	g.Expect(policyImpactWarnings(policy, []corev1.Node{node}, nsList, npList, false)).To(BeEmpty())
+	g.Expect(policyImpactWarnings(policy, []corev1.Node{node}, nsList, npList, true)).To(ConsistOf(
+		"SriovNetworkNodePolicy p1: applying this change will drain 1 node(s)"))
+}
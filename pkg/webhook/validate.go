@@ -2,17 +2,21 @@ package webhook
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	v1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
+	uns "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	sriovnetworkv1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
@@ -107,10 +111,23 @@ func validateSriovNetworkPoolConfig(cr *sriovnetworkv1.SriovNetworkPoolConfig, o
 		}
 	}
 
+	if (len(cr.Spec.MaintenanceWindows) > 0 || cr.Spec.RequireDrainApproval || cr.Spec.DrainConfig != nil) &&
+		cr.Spec.OvsHardwareOffloadConfig.Name != "" {
+		return false, warnings, fmt.Errorf("SriovNetworkPoolConfig can't have both drain configuration and OvsHardwareOffloadConfig")
+	}
+
+	if _, _, err := cr.MaintenanceWindowOpen(time.Now()); err != nil {
+		return false, warnings, fmt.Errorf("SriovNetworkPoolConfig invalid maintenance windows: %v", err)
+	}
+
+	if err := cr.ValidateDrainConfig(); err != nil {
+		return false, warnings, fmt.Errorf("SriovNetworkPoolConfig invalid drain configuration: %v", err)
+	}
+
 	return true, warnings, nil
 }
 
-func validateSriovNetworkNodePolicy(cr *sriovnetworkv1.SriovNetworkNodePolicy, operation v1.Operation) (bool, []string, error) {
+func validateSriovNetworkNodePolicy(cr, old *sriovnetworkv1.SriovNetworkNodePolicy, operation v1.Operation) (bool, []string, error) {
 	log.Log.V(2).Info("validateSriovNetworkNodePolicy", "object", cr)
 	var warnings []string
 
@@ -133,20 +150,44 @@ func validateSriovNetworkNodePolicy(cr *sriovnetworkv1.SriovNetworkNodePolicy, o
 		return admit, warnings, err
 	}
 
-	admit, err = dynamicValidateSriovNetworkNodePolicy(cr)
+	if err := validateRootDevicePatterns(cr, old); err != nil {
+		return false, warnings, err
+	}
+
+	admit, impactWarnings, err := dynamicValidateSriovNetworkNodePolicy(cr)
 	if err != nil {
 		return admit, warnings, err
 	}
+	warnings = append(warnings, impactWarnings...)
 
 	return admit, warnings, nil
 }
 
+// validateRootDevicePatterns checks the PCI address patterns of the rootDevices and excludeRootDevices,
+// the root devices of the policies created before the patterns were supported are only checked when they change
+func validateRootDevicePatterns(cr, old *sriovnetworkv1.SriovNetworkNodePolicy) error {
+	if old != nil && slices.Equal(old.Spec.NicSelector.RootDevices, cr.Spec.NicSelector.RootDevices) &&
+		slices.Equal(old.Spec.NicSelector.ExcludeRootDevices, cr.Spec.NicSelector.ExcludeRootDevices) {
+		return nil
+	}
+	for _, rootDevice := range slices.Concat(cr.Spec.NicSelector.RootDevices, cr.Spec.NicSelector.ExcludeRootDevices) {
+		if err := sriovnetworkv1.ValidatePciAddressPattern(rootDevice); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func staticValidateSriovNetworkNodePolicy(cr *sriovnetworkv1.SriovNetworkNodePolicy) (bool, error) {
 	var validString = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
 	if !validString.MatchString(cr.Spec.ResourceName) {
 		return false, fmt.Errorf("resource name \"%s\" contains invalid characters, the accepted syntax of the regular expressions is: \"^[a-zA-Z0-9_]+$\"", cr.Spec.ResourceName)
 	}
 
+	if _, err := cr.NodeLabelSelector(); err != nil {
+		return false, fmt.Errorf("invalid nodeSelectorExpressions in CR %s: %v", cr.GetName(), err)
+	}
+
 	if cr.Spec.NicSelector.Vendor == "" && cr.Spec.NicSelector.DeviceID == "" && len(cr.Spec.NicSelector.PfNames) == 0 && len(cr.Spec.NicSelector.RootDevices) == 0 && cr.Spec.NicSelector.NetFilter == "" {
 		return false, fmt.Errorf("at least one of these parameters (vendor, deviceID, pfNames, rootDevices or netFilter) has to be defined in nicSelector in CR %s", cr.GetName())
 	}
@@ -231,40 +272,71 @@ func staticValidateSriovNetworkNodePolicy(cr *sriovnetworkv1.SriovNetworkNodePol
 	if !cr.Spec.Bridge.IsEmpty() && cr.Spec.ExternallyManaged {
 		return false, fmt.Errorf("software bridge management can't be used when the device externally managed")
 	}
+	if err := sriovnetworkv1.ValidateVfMacAllocation(cr.Spec.VfMacAllocation); err != nil {
+		return false, err
+	}
+	if cr.Spec.VfDefaults != nil && cr.Spec.VfDefaults.MinTxRate != nil && cr.Spec.VfDefaults.MaxTxRate != nil &&
+		*cr.Spec.VfDefaults.MaxTxRate > 0 && *cr.Spec.VfDefaults.MinTxRate > *cr.Spec.VfDefaults.MaxTxRate {
+		return false, fmt.Errorf("vfDefaults minTxRate %d can't be larger than maxTxRate %d",
+			*cr.Spec.VfDefaults.MinTxRate, *cr.Spec.VfDefaults.MaxTxRate)
+	}
+	if cr.Spec.PfSettings != nil {
+		// PF settings are applied only to the PFs managed by the operator
+		if cr.Spec.ExternallyManaged {
+			return false, fmt.Errorf("pfSettings can't be used when the device externally managed")
+		}
+		for feature, enabled := range cr.Spec.PfSettings.Features {
+			if feature != consts.PfFeatureRxVlanFilter && feature != consts.PfFeatureHwTcOffload && feature != consts.PfFeatureLro {
+				return false, fmt.Errorf("pfSettings feature %s is not supported, allowed features are %s, %s and %s",
+					feature, consts.PfFeatureRxVlanFilter, consts.PfFeatureHwTcOffload, consts.PfFeatureLro)
+			}
+			// hw-tc-offload is always enabled for the PFs in switchdev mode
+			if feature == consts.PfFeatureHwTcOffload && !enabled && cr.Spec.EswitchMode == sriovnetworkv1.ESwithModeSwitchDev {
+				return false, fmt.Errorf("pfSettings feature %s can't be disabled in switchdev mode", feature)
+			}
+		}
+	}
 	return true, nil
 }
 
-func dynamicValidateSriovNetworkNodePolicy(cr *sriovnetworkv1.SriovNetworkNodePolicy) (bool, error) {
+func dynamicValidateSriovNetworkNodePolicy(cr *sriovnetworkv1.SriovNetworkNodePolicy) (bool, []string, error) {
 	nodesSelected = false
 	interfaceSelected = false
 	nodeInterfaceErrorList := make(map[string][]string)
 
+	selector, err := cr.NodeLabelSelector()
+	if err != nil {
+		return false, nil, err
+	}
 	nodeList, err := kubeclient.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{
-		LabelSelector: labels.Set(cr.Spec.NodeSelector).String(),
+		LabelSelector: selector.String(),
 	})
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
 	nsList, err := snclient.SriovnetworkV1().SriovNetworkNodeStates(namespace).List(context.Background(), metav1.ListOptions{})
 	if err != nil {
-		return false, err
+		return false, nil, err
 	}
 	npList, err := snclient.SriovnetworkV1().SriovNetworkNodePolicies(namespace).List(context.Background(), metav1.ListOptions{})
 	if err != nil {
-		return false, err
+		return false, nil, err
+	}
+	if err := validatePolicyDryRun(cr, nsList); err != nil {
+		return false, nil, err
 	}
 	for _, node := range nodeList.Items {
 		if cr.Selected(&node) {
 			nodesSelected = true
 			err = validatePolicyForNodeStateAndPolicy(nsList, npList, &node, cr, nodeInterfaceErrorList)
 			if err != nil {
-				return false, err
+				return false, nil, err
 			}
 		}
 	}
 
 	if !nodesSelected {
-		return false, fmt.Errorf("no matched node is selected by the nodeSelector in CR %s", cr.GetName())
+		return false, nil, fmt.Errorf("no matched node is selected by the nodeSelector in CR %s", cr.GetName())
 	}
 	if !interfaceSelected {
 		for nodeName, messages := range nodeInterfaceErrorList {
@@ -272,10 +344,25 @@ func dynamicValidateSriovNetworkNodePolicy(cr *sriovnetworkv1.SriovNetworkNodePo
 				log.Log.V(2).Info("interface selection errors", "nodeName", nodeName, "message", message)
 			}
 		}
-		return false, fmt.Errorf("no supported NIC is selected by the nicSelector in CR %s", cr.GetName())
+		return false, nil, fmt.Errorf("no supported NIC is selected by the nicSelector in CR %s", cr.GetName())
 	}
 
-	return true, nil
+	return true, policyImpactWarnings(cr, nodeList.Items, nsList, npList, manageSoftwareBridges()), nil
+}
+
+// validatePolicyDryRun rejects the dry-run annotation on a policy already applied on some nodes, as the operator
+// stops applying a policy in dry-run mode which would remove its VFs from the nodes
+func validatePolicyDryRun(cr *sriovnetworkv1.SriovNetworkNodePolicy, nsList *sriovnetworkv1.SriovNetworkNodeStateList) error {
+	if !cr.IsDryRun() {
+		return nil
+	}
+	for i := range nsList.Items {
+		if nodeStateUsesPolicy(&nsList.Items[i], cr.Name) {
+			return fmt.Errorf("SriovNetworkNodePolicy %s is applied on node %s, the dry-run annotation can only be set on a policy which is not applied, "+
+				"preview the changes with a copy of the policy instead", cr.Name, nsList.Items[i].Name)
+		}
+	}
+	return nil
 }
 
 func validatePolicyForNodeStateAndPolicy(nsList *sriovnetworkv1.SriovNetworkNodeStateList, npList *sriovnetworkv1.SriovNetworkNodePolicyList, node *corev1.Node, cr *sriovnetworkv1.SriovNetworkNodePolicy, nodeInterfaceErrorList map[string][]string) error {
@@ -448,9 +535,24 @@ func validateNicModel(selector *sriovnetworkv1.SriovNetworkNicSelector, iface *s
 	if selector.DeviceID != "" && selector.DeviceID != iface.DeviceID {
 		return fmt.Errorf("selector device ID: %s is not equal to the interface device ID: %s", selector.Vendor, iface.Vendor)
 	}
-	if len(selector.RootDevices) > 0 && !sriovnetworkv1.StringInArray(iface.PciAddress, selector.RootDevices) {
+	if len(selector.RootDevices) > 0 && !sriovnetworkv1.PciAddressInList(iface.PciAddress, selector.RootDevices) {
 		return fmt.Errorf("interface PCI address: %s not found in root devices", iface.PciAddress)
 	}
+	if sriovnetworkv1.StringInArray(iface.Name, selector.ExcludePfNames) {
+		return fmt.Errorf("interface name: %s is excluded", iface.Name)
+	}
+	if sriovnetworkv1.PciAddressInList(iface.PciAddress, selector.ExcludeRootDevices) {
+		return fmt.Errorf("interface PCI address: %s is excluded", iface.PciAddress)
+	}
+	if len(selector.NumaNodes) > 0 && (iface.NumaNode == nil || !slices.Contains(selector.NumaNodes, *iface.NumaNode)) {
+		return fmt.Errorf("interface PCI address: %s is not attached to the selected NUMA nodes", iface.PciAddress)
+	}
+	if len(selector.LinkSpeeds) > 0 && !slices.Contains(selector.LinkSpeeds, sriovnetworkv1.ParseLinkSpeed(iface.LinkSpeed)) {
+		return fmt.Errorf("interface link speed: %s is not in the selected link speeds", iface.LinkSpeed)
+	}
+	if len(selector.PfDrivers) > 0 && !sriovnetworkv1.StringInArray(iface.Driver, selector.PfDrivers) {
+		return fmt.Errorf("interface driver: %s is not in the selected drivers", iface.Driver)
+	}
 	if len(selector.PfNames) > 0 {
 		var pfNames []string
 		for _, p := range selector.PfNames {
@@ -482,3 +584,163 @@ func validateNicModel(selector *sriovnetworkv1.SriovNetworkNicSelector, iface *s
 
 	return fmt.Errorf("vendor and device ID is not in supported list")
 }
+
+func validateSriovNetwork(cr, old *sriovnetworkv1.SriovNetwork, operation v1.Operation) (bool, []string, error) {
+	log.Log.V(2).Info("validateSriovNetwork", "object", cr)
+	var warnings []string
+
+	if operation == v1.Delete {
+		return true, warnings, nil
+	}
+	if old != nil && skipNetworkValidation(cr, old.Spec, cr.Spec) {
+		return true, warnings, nil
+	}
+
+	if cr.Spec.Vlan < 0 || cr.Spec.Vlan > 4095 {
+		return false, warnings, fmt.Errorf("SriovNetwork %s: vlan %d is out of range [0-4095]", cr.Name, cr.Spec.Vlan)
+	}
+	if cr.Spec.VlanQoS < 0 || cr.Spec.VlanQoS > 7 {
+		return false, warnings, fmt.Errorf("SriovNetwork %s: vlanQoS %d is out of range [0-7]", cr.Name, cr.Spec.VlanQoS)
+	}
+	if cr.Spec.VlanProto != "" && !strings.EqualFold(cr.Spec.VlanProto, "802.1q") && !strings.EqualFold(cr.Spec.VlanProto, "802.1ad") {
+		return false, warnings, fmt.Errorf("SriovNetwork %s: invalid vlanProto %q, supported values are 802.1q and 802.1ad", cr.Name, cr.Spec.VlanProto)
+	}
+	// the sriov-cni rejects the QoS and the protocol of an untagged VF
+	if cr.Spec.Vlan == 0 && (cr.Spec.VlanQoS != 0 || strings.EqualFold(cr.Spec.VlanProto, "802.1ad")) {
+		return false, warnings, fmt.Errorf("SriovNetwork %s: vlanQoS and vlanProto require a non zero vlan", cr.Name)
+	}
+	if cr.Spec.MinTxRate != nil && cr.Spec.MaxTxRate != nil && *cr.Spec.MaxTxRate > 0 && *cr.Spec.MinTxRate > *cr.Spec.MaxTxRate {
+		return false, warnings, fmt.Errorf("SriovNetwork %s: minTxRate %d is greater than maxTxRate %d", cr.Name, *cr.Spec.MinTxRate, *cr.Spec.MaxTxRate)
+	}
+	if err := validateNetworkCniConfig(cr); err != nil {
+		return false, warnings, fmt.Errorf("SriovNetwork %s: %v", cr.Name, err)
+	}
+
+	if old == nil || old.Spec.ResourceName != cr.Spec.ResourceName {
+		warnings = append(warnings, validateNetworkResourceName("SriovNetwork", cr.Name, cr.Spec.ResourceName)...)
+	}
+	return true, warnings, nil
+}
+
+func validateSriovIBNetwork(cr, old *sriovnetworkv1.SriovIBNetwork, operation v1.Operation) (bool, []string, error) {
+	log.Log.V(2).Info("validateSriovIBNetwork", "object", cr)
+	var warnings []string
+
+	if operation == v1.Delete {
+		return true, warnings, nil
+	}
+	if old != nil && skipNetworkValidation(cr, old.Spec, cr.Spec) {
+		return true, warnings, nil
+	}
+
+	if err := validateNetworkCniConfig(cr); err != nil {
+		return false, warnings, fmt.Errorf("SriovIBNetwork %s: %v", cr.Name, err)
+	}
+
+	if old == nil || old.Spec.ResourceName != cr.Spec.ResourceName {
+		warnings = append(warnings, validateNetworkResourceName("SriovIBNetwork", cr.Name, cr.Spec.ResourceName)...)
+	}
+	return true, warnings, nil
+}
+
+func validateOVSNetwork(cr, old *sriovnetworkv1.OVSNetwork, operation v1.Operation) (bool, []string, error) {
+	log.Log.V(2).Info("validateOVSNetwork", "object", cr)
+	var warnings []string
+
+	if operation == v1.Delete {
+		return true, warnings, nil
+	}
+	if old != nil && skipNetworkValidation(cr, old.Spec, cr.Spec) {
+		return true, warnings, nil
+	}
+
+	if cr.Spec.Vlan > 4095 {
+		return false, warnings, fmt.Errorf("OVSNetwork %s: vlan %d is out of range [0-4095]", cr.Name, cr.Spec.Vlan)
+	}
+	for _, trunk := range cr.Spec.Trunk {
+		if trunk == nil {
+			continue
+		}
+		for _, id := range []*uint{trunk.ID, trunk.MinID, trunk.MaxID} {
+			if id != nil && *id > 4095 {
+				return false, warnings, fmt.Errorf("OVSNetwork %s: trunk vlan %d is out of range [0-4095]", cr.Name, *id)
+			}
+		}
+		if trunk.MinID != nil && trunk.MaxID != nil && *trunk.MinID > *trunk.MaxID {
+			return false, warnings, fmt.Errorf("OVSNetwork %s: trunk minID %d is greater than maxID %d", cr.Name, *trunk.MinID, *trunk.MaxID)
+		}
+	}
+	if err := validateNetworkCniConfig(cr); err != nil {
+		return false, warnings, fmt.Errorf("OVSNetwork %s: %v", cr.Name, err)
+	}
+
+	if old == nil || old.Spec.ResourceName != cr.Spec.ResourceName {
+		warnings = append(warnings, validateNetworkResourceName("OVSNetwork", cr.Name, cr.Spec.ResourceName)...)
+	}
+	return true, warnings, nil
+}
+
+// skipNetworkValidation returns true for the updates of a network that don't change its spec, like the
+// finalizer and annotation updates of the controller, so that a network created before a validation rule
+// can still be deleted
+func skipNetworkValidation(cr metav1.Object, oldSpec, spec interface{}) bool {
+	return cr.GetDeletionTimestamp() != nil || equality.Semantic.DeepEqual(oldSpec, spec)
+}
+
+// netAttDefRenderer is implemented by the network CRs rendered into a NetworkAttachmentDefinition
+type netAttDefRenderer interface {
+	RenderNetAttDef() (*uns.Unstructured, error)
+}
+
+// validateNetworkCniConfig checks that the NetworkAttachmentDefinition rendered from a network CR
+// contains a valid CNI configuration
+func validateNetworkCniConfig(cr netAttDefRenderer) error {
+	netAttDef, err := cr.RenderNetAttDef()
+	if err != nil {
+		return fmt.Errorf("can't render the NetworkAttachmentDefinition: %v", err)
+	}
+	config, _, err := uns.NestedString(netAttDef.Object, "spec", "config")
+	if err != nil {
+		return fmt.Errorf("can't render the NetworkAttachmentDefinition: %v", err)
+	}
+
+	conf := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(config), &conf); err != nil {
+		return fmt.Errorf("invalid CNI configuration, check the ipam, capabilities and metaPlugins fields: %v", err)
+	}
+	// the metaplugins are appended to the plugin list after the main plugin
+	plugins := []interface{}{conf}
+	if list, ok := conf["plugins"].([]interface{}); ok {
+		plugins = list
+	}
+	for i, plugin := range plugins {
+		pluginConf, ok := plugin.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("invalid metaPlugins configuration: plugin %d is not an object", i)
+		}
+		if pluginType, ok := pluginConf["type"].(string); !ok || pluginType == "" {
+			return fmt.Errorf("invalid metaPlugins configuration: plugin %d has no type", i)
+		}
+		if ipam, ok := pluginConf["ipam"]; ok && i == 0 {
+			if _, ok := ipam.(map[string]interface{}); !ok {
+				return fmt.Errorf("invalid ipam configuration: %v is not an object", ipam)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateNetworkResourceName returns a warning if the resource of the network is not advertised by any policy
+func validateNetworkResourceName(kind, name, resourceName string) []string {
+	policies, err := snclient.SriovnetworkV1().SriovNetworkNodePolicies(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return []string{fmt.Sprintf("can't verify that resource %s of %s %s is provided by a SriovNetworkNodePolicy: %v", resourceName, kind, name, err)}
+	}
+	for _, policy := range policies.Items {
+		if policy.Spec.ResourceName == resourceName {
+			return nil
+		}
+	}
+	return []string{fmt.Sprintf("resource %s of %s %s is not provided by any SriovNetworkNodePolicy yet, pods using this network can't be scheduled", resourceName, kind, name)}
+}
@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
 
 	. "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 	constants "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/consts"
@@ -39,6 +41,7 @@ func TestMain(m *testing.M) {
 		"14e4 16d7 16dc", // BCM57414 2x25G
 		"14e4 1750 1806", // BCM75508 2x100G
 	}
+	ManifestsPath = "../../bindata/manifests/cni-config"
 	os.Exit(m.Run())
 }
 
@@ -257,6 +260,216 @@ func TestValidateSriovNetworkPoolConfigWithParallelAndHWOffload(t *testing.T) {
 	g.Expect(ok).To(BeFalse())
 }
 
+func TestValidateSriovNetworkPoolConfigMaintenanceWindows(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	config := newDefaultNetworkPoolConfig()
+	config.Spec.TimeZone = "Europe/Paris"
+	config.Spec.MaintenanceWindows = []MaintenanceWindow{
+		{Schedule: "0 2 * * 6", Duration: &metav1.Duration{Duration: 4 * time.Hour}},
+		{Days: []string{"Sunday"}, StartTime: "22:00", EndTime: "04:00"},
+	}
+	ok, _, err := validateSriovNetworkPoolConfig(config, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	config.Spec.MaintenanceWindows = append(config.Spec.MaintenanceWindows, MaintenanceWindow{Schedule: "0 2 * * 6"})
+	ok, _, err = validateSriovNetworkPoolConfig(config, "UPDATE")
+	g.Expect(err).To(MatchError(ContainSubstring("invalid maintenance windows")))
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestValidateSriovNetworkPoolConfigDrainConfig(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	config := newDefaultNetworkPoolConfig()
+	config.Spec.DrainConfig = &DrainConfig{
+		Timeout:         &metav1.Duration{Duration: 5 * time.Minute},
+		SkipPodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+		PDBPolicy:       PDBPolicyForce,
+		PDBForceTimeout: &metav1.Duration{Duration: 30 * time.Minute},
+	}
+	ok, _, err := validateSriovNetworkPoolConfig(config, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+
+	config.Spec.DrainConfig.PDBPolicy = PDBPolicyRetry
+	ok, _, err = validateSriovNetworkPoolConfig(config, "UPDATE")
+	g.Expect(err).To(MatchError(ContainSubstring("pdbForceTimeout can only be used with the Force pdbPolicy")))
+	g.Expect(ok).To(BeFalse())
+
+	config.Spec.DrainConfig.PDBForceTimeout = nil
+	config.Spec.DrainConfig.EvictPodSelector = &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+		{Key: "app", Operator: "Bad"},
+	}}
+	ok, _, err = validateSriovNetworkPoolConfig(config, "UPDATE")
+	g.Expect(err).To(MatchError(ContainSubstring("invalid evictPodSelector")))
+	g.Expect(ok).To(BeFalse())
+}
+
+func newSriovNetworkNodePolicyForResource(resourceName string) *SriovNetworkNodePolicy {
+	return &SriovNetworkNodePolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "p1", Namespace: namespace},
+		Spec: SriovNetworkNodePolicySpec{
+			ResourceName: resourceName,
+			NumVfs:       4,
+			NicSelector:  SriovNetworkNicSelector{PfNames: []string{"ens803f0"}},
+			NodeSelector: map[string]string{"feature.node.kubernetes.io/network-sriov.capable": "true"},
+		},
+	}
+}
+
+func TestValidateSriovNetwork(t *testing.T) {
+	g := NewGomegaWithT(t)
+	snclient = fakesnclientset.NewSimpleClientset(newSriovNetworkNodePolicyForResource("nic1"))
+
+	valid := func() *SriovNetwork {
+		return &SriovNetwork{
+			ObjectMeta: metav1.ObjectMeta{Name: "net1", Namespace: namespace},
+			Spec: SriovNetworkSpec{
+				ResourceName: "nic1",
+				IPAM: `{
+  "type": "host-local",
+  "subnet": "10.56.217.0/24"
+}`,
+				MetaPluginsConfig: `{"type": "tuning", "sysctl": {"net.core.somaxconn": "500"}}, {"type": "vrf", "vrfname": "red"}`,
+				Capabilities:      `{"mac": true}`,
+				Vlan:              100,
+				VlanQoS:           3,
+				VlanProto:         "802.1AD",
+			},
+		}
+	}
+
+	ok, w, err := validateSriovNetwork(valid(), nil, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+	g.Expect(w).To(BeEmpty())
+
+	testCases := []struct {
+		name   string
+		mutate func(*SriovNetwork)
+		err    string
+	}{
+		{"malformed ipam", func(n *SriovNetwork) { n.Spec.IPAM = `{"type": "host-local",}` }, "invalid CNI configuration"},
+		{"ipam not an object", func(n *SriovNetwork) { n.Spec.IPAM = `"host-local"` }, "invalid ipam configuration"},
+		{"malformed metaPlugins", func(n *SriovNetwork) { n.Spec.MetaPluginsConfig = `{"type": "tuning"} {"type": "vrf"}` }, "invalid CNI configuration"},
+		{"metaPlugin without type", func(n *SriovNetwork) { n.Spec.MetaPluginsConfig = `{"sysctl": {}}` }, "plugin 1 has no type"},
+		{"malformed capabilities", func(n *SriovNetwork) { n.Spec.Capabilities = `mac` }, "invalid CNI configuration"},
+		{"vlan out of range", func(n *SriovNetwork) { n.Spec.Vlan = 4096 }, "vlan 4096 is out of range"},
+		{"qos out of range", func(n *SriovNetwork) { n.Spec.VlanQoS = 8 }, "vlanQoS 8 is out of range"},
+		{"invalid vlanProto", func(n *SriovNetwork) { n.Spec.VlanProto = "802.1x" }, "invalid vlanProto"},
+		{"qos without vlan", func(n *SriovNetwork) { n.Spec.Vlan = 0 }, "require a non zero vlan"},
+		{"min rate above max rate", func(n *SriovNetwork) {
+			n.Spec.MinTxRate = pointer.Int(200)
+			n.Spec.MaxTxRate = pointer.Int(100)
+		}, "minTxRate 200 is greater than maxTxRate 100"},
+	}
+	for _, tc := range testCases {
+		network := valid()
+		tc.mutate(network)
+		ok, _, err := validateSriovNetwork(network, nil, "CREATE")
+		g.Expect(err).To(MatchError(ContainSubstring(tc.err)), tc.name)
+		g.Expect(ok).To(BeFalse(), tc.name)
+
+		// deleting an invalid network is always allowed
+		ok, _, err = validateSriovNetwork(network, nil, "DELETE")
+		g.Expect(err).NotTo(HaveOccurred(), tc.name)
+		g.Expect(ok).To(BeTrue(), tc.name)
+
+		// the controller can update the annotations and remove the finalizer of a network created before the check
+		updated := network.DeepCopy()
+		updated.Annotations = map[string]string{"operator.sriovnetwork.openshift.io/last-network-namespace": "default"}
+		ok, _, err = validateSriovNetwork(updated, network, "UPDATE")
+		g.Expect(err).NotTo(HaveOccurred(), tc.name)
+		g.Expect(ok).To(BeTrue(), tc.name)
+
+		updated.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		updated.Spec.ResourceName = "nic2"
+		ok, _, err = validateSriovNetwork(updated, network, "UPDATE")
+		g.Expect(err).NotTo(HaveOccurred(), tc.name)
+		g.Expect(ok).To(BeTrue(), tc.name)
+	}
+
+	network := valid()
+	network.Spec.ResourceName = "nic2"
+	ok, w, err = validateSriovNetwork(network, valid(), "UPDATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+	g.Expect(w).To(ConsistOf(ContainSubstring("resource nic2 of SriovNetwork net1 is not provided by any SriovNetworkNodePolicy")))
+
+	// the policies are not listed when the resource of the network doesn't change
+	snclient = fakesnclientset.NewSimpleClientset()
+	oldNetwork := network.DeepCopy()
+	network.Spec.Vlan = 200
+	ok, w, err = validateSriovNetwork(network, oldNetwork, "UPDATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+	g.Expect(w).To(BeEmpty())
+	g.Expect(snclient.(*fakesnclientset.Clientset).Actions()).To(BeEmpty())
+}
+
+func TestValidateSriovIBNetwork(t *testing.T) {
+	g := NewGomegaWithT(t)
+	snclient = fakesnclientset.NewSimpleClientset()
+
+	network := &SriovIBNetwork{
+		ObjectMeta: metav1.ObjectMeta{Name: "ibnet1", Namespace: namespace},
+		Spec: SriovIBNetworkSpec{
+			ResourceName: "ib1",
+			IPAM:         `{"type": "whereabouts", "range": "192.168.2.0/24"}`,
+			Capabilities: `{"infinibandGUID": true}`,
+		},
+	}
+
+	ok, w, err := validateSriovIBNetwork(network, nil, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+	g.Expect(w).To(ConsistOf(ContainSubstring("resource ib1 of SriovIBNetwork ibnet1 is not provided")))
+
+	network.Spec.MetaPluginsConfig = `[{"type": "tuning"}]`
+	ok, _, err = validateSriovIBNetwork(network, nil, "CREATE")
+	g.Expect(err).To(MatchError(ContainSubstring("plugin 1 is not an object")))
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestValidateOVSNetwork(t *testing.T) {
+	g := NewGomegaWithT(t)
+	snclient = fakesnclientset.NewSimpleClientset(newSriovNetworkNodePolicyForResource("switchdevnics"))
+
+	network := &OVSNetwork{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovsnet1", Namespace: namespace},
+		Spec: OVSNetworkSpec{
+			ResourceName: "switchdevnics",
+			IPAM:         `{"type": "host-local", "subnet": "10.56.217.0/24"}`,
+			Vlan:         100,
+			Trunk:        []*TrunkConfig{{MinID: pointer.Uint(10), MaxID: pointer.Uint(20)}},
+		},
+	}
+
+	ok, w, err := validateOVSNetwork(network, nil, "CREATE")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(BeTrue())
+	g.Expect(w).To(BeEmpty())
+
+	network.Spec.Trunk = []*TrunkConfig{{MinID: pointer.Uint(20), MaxID: pointer.Uint(10)}}
+	ok, _, err = validateOVSNetwork(network, nil, "CREATE")
+	g.Expect(err).To(MatchError(ContainSubstring("trunk minID 20 is greater than maxID 10")))
+	g.Expect(ok).To(BeFalse())
+
+	network.Spec.Trunk = nil
+	network.Spec.Vlan = 5000
+	ok, _, err = validateOVSNetwork(network, nil, "CREATE")
+	g.Expect(err).To(MatchError(ContainSubstring("vlan 5000 is out of range")))
+	g.Expect(ok).To(BeFalse())
+
+	network.Spec.Vlan = 0
+	network.Spec.IPAM = `{"type": "host-local"`
+	ok, _, err = validateOVSNetwork(network, nil, "UPDATE")
+	g.Expect(err).To(MatchError(ContainSubstring("invalid CNI configuration")))
+	g.Expect(ok).To(BeFalse())
+}
+
 func TestValidateSriovNetworkNodePolicyWithDefaultPolicy(t *testing.T) {
 	var err error
 	var ok bool
@@ -275,16 +488,16 @@ func TestValidateSriovNetworkNodePolicyWithDefaultPolicy(t *testing.T) {
 	os.Setenv("NAMESPACE", "openshift-sriov-network-operator")
 	vars.Namespace = "openshift-sriov-network-operator"
 	g := NewGomegaWithT(t)
-	ok, w, err := validateSriovNetworkNodePolicy(policy, "DELETE")
+	ok, w, err := validateSriovNetworkNodePolicy(policy, nil, "DELETE")
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(ok).To(Equal(true))
 	g.Expect(w).To(BeEmpty())
 
-	ok, _, err = validateSriovNetworkNodePolicy(policy, "UPDATE")
+	ok, _, err = validateSriovNetworkNodePolicy(policy, nil, "UPDATE")
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(ok).To(Equal(true))
 
-	ok, _, err = validateSriovNetworkNodePolicy(policy, "CREATE")
+	ok, _, err = validateSriovNetworkNodePolicy(policy, nil, "CREATE")
 	g.Expect(err).NotTo(HaveOccurred())
 	g.Expect(ok).To(Equal(true))
 }
@@ -765,6 +978,174 @@ func TestStaticValidateSriovNetworkNodePolicyWithValidVendorDevice(t *testing.T)
 	g.Expect(ok).To(Equal(true))
 }
 
+func TestStaticValidateSriovNetworkNodePolicyWithInvalidNodeSelectorExpressions(t *testing.T) {
+	policy := &SriovNetworkNodePolicy{
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				Vendor:   "8086",
+				DeviceID: "158b",
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NodeSelectorExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "rack", Operator: metav1.LabelSelectorOpIn},
+			},
+			NumVfs:       63,
+			Priority:     99,
+			ResourceName: "p0",
+		},
+	}
+	g := NewGomegaWithT(t)
+	ok, err := staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("invalid nodeSelectorExpressions")))
+	g.Expect(ok).To(Equal(false))
+}
+
+func TestValidateSriovNetworkNodePolicyWithInvalidRootDevicePattern(t *testing.T) {
+	policy := &SriovNetworkNodePolicy{
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				Vendor:             "8086",
+				ExcludeRootDevices: []string{"0000:3d-3b:00.0"},
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:       63,
+			Priority:     99,
+			ResourceName: "p0",
+		},
+	}
+	g := NewGomegaWithT(t)
+	err := validateRootDevicePatterns(policy, nil)
+	g.Expect(err).To(MatchError(ContainSubstring("invalid range")))
+
+	// a legacy root device is accepted on the updates which don't change the root devices
+	policy.Spec.NicSelector.ExcludeRootDevices = nil
+	policy.Spec.NicSelector.RootDevices = []string{"3b:00.0"}
+	g.Expect(validateRootDevicePatterns(policy, nil)).To(MatchError(ContainSubstring("expected format")))
+	oldPolicy := policy.DeepCopy()
+	policy.Spec.NumVfs = 32
+	g.Expect(validateRootDevicePatterns(policy, oldPolicy)).To(Succeed())
+
+	policy.Spec.NicSelector.RootDevices = []string{"3b:00.0", "3b:00.1"}
+	g.Expect(validateRootDevicePatterns(policy, oldPolicy)).To(MatchError(ContainSubstring("expected format")))
+}
+
+func TestStaticValidateSriovNetworkNodePolicyWithPfSettings(t *testing.T) {
+	policy := &SriovNetworkNodePolicy{
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				Vendor: "8086",
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:       63,
+			Priority:     99,
+			ResourceName: "p0",
+			PfSettings: &PfSettings{
+				Features: map[string]bool{"rx-vlan-filter": false, "lro": true},
+			},
+		},
+	}
+	g := NewGomegaWithT(t)
+	ok, err := staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(Equal(true))
+
+	policy.Spec.PfSettings.Features["gro"] = true
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("feature gro is not supported")))
+	g.Expect(ok).To(Equal(false))
+
+	policy.Spec.PfSettings.Features = map[string]bool{"hw-tc-offload": false}
+	policy.Spec.EswitchMode = "switchdev"
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("can't be disabled in switchdev mode")))
+	g.Expect(ok).To(Equal(false))
+
+	policy.Spec.PfSettings.Features = nil
+	policy.Spec.EswitchMode = ""
+	policy.Spec.ExternallyManaged = true
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("pfSettings can't be used")))
+	g.Expect(ok).To(Equal(false))
+}
+
+func TestStaticValidateSriovNetworkNodePolicyWithVfDefaults(t *testing.T) {
+	minTxRate, maxTxRate := 1000, 100
+	policy := &SriovNetworkNodePolicy{
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "vfio-pci",
+			NicSelector: SriovNetworkNicSelector{
+				Vendor: "8086",
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:       63,
+			Priority:     99,
+			ResourceName: "p0",
+			VfDefaults: &VfDefaults{
+				Trust:     "on",
+				MinTxRate: &minTxRate,
+				MaxTxRate: &maxTxRate,
+			},
+		},
+	}
+	g := NewGomegaWithT(t)
+	ok, err := staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("can't be larger than maxTxRate")))
+	g.Expect(ok).To(Equal(false))
+
+	maxTxRate = 10000
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(Equal(true))
+}
+
+func TestStaticValidateSriovNetworkNodePolicyWithVfMacAllocation(t *testing.T) {
+	policy := &SriovNetworkNodePolicy{
+		Spec: SriovNetworkNodePolicySpec{
+			DeviceType: "netdevice",
+			NicSelector: SriovNetworkNicSelector{
+				Vendor: "8086",
+			},
+			NodeSelector: map[string]string{
+				"feature.node.kubernetes.io/network-sriov.capable": "true",
+			},
+			NumVfs:          63,
+			Priority:        99,
+			ResourceName:    "p0",
+			VfMacAllocation: &VfMacAllocation{Prefix: "02:5a"},
+		},
+	}
+	g := NewGomegaWithT(t)
+	ok, err := staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(Equal(true))
+
+	policy.Spec.VfMacAllocation.Pool = []string{"02:00:00:00:10:00-02:00:00:00:1f:ff"}
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("exactly one of prefix and pool")))
+	g.Expect(ok).To(Equal(false))
+
+	policy.Spec.VfMacAllocation.Prefix = ""
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ok).To(Equal(true))
+
+	policy.Spec.VfMacAllocation.Pool = []string{"03:00:00:00:10:00-03:00:00:00:1f:ff"}
+	ok, err = staticValidateSriovNetworkNodePolicy(policy)
+	g.Expect(err).To(MatchError(ContainSubstring("multicast")))
+	g.Expect(ok).To(Equal(false))
+}
+
 func TestStaticValidateSriovNetworkNodePolicyWithInvalidVendor(t *testing.T) {
 	policy := &SriovNetworkNodePolicy{
 		Spec: SriovNetworkNodePolicySpec{
@@ -1334,3 +1715,21 @@ func TestValidatePolicyForNodePolicyAllowSwitchdevWithExternallyManage(t *testin
 	err := validatePolicyForNodePolicy(policy, appliedPolicy)
 	g.Expect(err).NotTo(HaveOccurred())
 }
+
+func TestValidatePolicyDryRun(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	policy := newNodePolicy()
+	policy.Annotations = map[string]string{constants.PolicyDryRunAnnotation: "true"}
+	ns := newNodeState()
+	ns.Name = "worker-1"
+	nsList := &SriovNetworkNodeStateList{Items: []SriovNetworkNodeState{*ns}}
+	g.Expect(validatePolicyDryRun(policy, nsList)).To(Succeed())
+
+	// the policy is already applied on the node
+	nsList.Items[0].Spec.Interfaces[0].VfGroups[0].PolicyName = policy.Name
+	g.Expect(validatePolicyDryRun(policy, nsList)).To(MatchError(ContainSubstring("is applied on node worker-1")))
+
+	policy.Annotations = nil
+	g.Expect(validatePolicyDryRun(policy, nsList)).To(Succeed())
+}
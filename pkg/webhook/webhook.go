@@ -63,7 +63,17 @@ func ValidateCustomResource(ar v1.AdmissionReview) *v1.AdmissionResponse {
 			return toV1AdmissionResponse(err)
 		}
 
-		if reviewResponse.Allowed, reviewResponse.Warnings, err = validateSriovNetworkNodePolicy(&policy, ar.Request.Operation); err != nil {
+		// the root devices of the policy are only checked when they change
+		var oldPolicy *sriovnetworkv1.SriovNetworkNodePolicy
+		if ar.Request.Operation == v1.Update {
+			oldPolicy = &sriovnetworkv1.SriovNetworkNodePolicy{}
+			if err = json.Unmarshal(ar.Request.OldObject.Raw, oldPolicy); err != nil {
+				log.Log.Error(err, "failed to unmarshal old object")
+				return toV1AdmissionResponse(err)
+			}
+		}
+
+		if reviewResponse.Allowed, reviewResponse.Warnings, err = validateSriovNetworkNodePolicy(&policy, oldPolicy, ar.Request.Operation); err != nil {
 			reviewResponse.Result = &metav1.Status{
 				Reason: metav1.StatusReason(err.Error()),
 			}
@@ -97,6 +107,81 @@ func ValidateCustomResource(ar v1.AdmissionReview) *v1.AdmissionResponse {
 				Reason: metav1.StatusReason(err.Error()),
 			}
 		}
+
+	case "SriovNetwork":
+		network := sriovnetworkv1.SriovNetwork{}
+
+		err = json.Unmarshal(raw, &network)
+		if err != nil {
+			log.Log.Error(err, "failed to unmarshal object")
+			return toV1AdmissionResponse(err)
+		}
+
+		// the resource of the network is only checked when it changes
+		var oldNetwork *sriovnetworkv1.SriovNetwork
+		if ar.Request.Operation == v1.Update {
+			oldNetwork = &sriovnetworkv1.SriovNetwork{}
+			if err = json.Unmarshal(ar.Request.OldObject.Raw, oldNetwork); err != nil {
+				log.Log.Error(err, "failed to unmarshal old object")
+				return toV1AdmissionResponse(err)
+			}
+		}
+
+		if reviewResponse.Allowed, reviewResponse.Warnings, err = validateSriovNetwork(&network, oldNetwork, ar.Request.Operation); err != nil {
+			reviewResponse.Result = &metav1.Status{
+				Reason: metav1.StatusReason(err.Error()),
+			}
+		}
+
+	case "SriovIBNetwork":
+		network := sriovnetworkv1.SriovIBNetwork{}
+
+		err = json.Unmarshal(raw, &network)
+		if err != nil {
+			log.Log.Error(err, "failed to unmarshal object")
+			return toV1AdmissionResponse(err)
+		}
+
+		// the resource of the network is only checked when it changes
+		var oldNetwork *sriovnetworkv1.SriovIBNetwork
+		if ar.Request.Operation == v1.Update {
+			oldNetwork = &sriovnetworkv1.SriovIBNetwork{}
+			if err = json.Unmarshal(ar.Request.OldObject.Raw, oldNetwork); err != nil {
+				log.Log.Error(err, "failed to unmarshal old object")
+				return toV1AdmissionResponse(err)
+			}
+		}
+
+		if reviewResponse.Allowed, reviewResponse.Warnings, err = validateSriovIBNetwork(&network, oldNetwork, ar.Request.Operation); err != nil {
+			reviewResponse.Result = &metav1.Status{
+				Reason: metav1.StatusReason(err.Error()),
+			}
+		}
+
+	case "OVSNetwork":
+		network := sriovnetworkv1.OVSNetwork{}
+
+		err = json.Unmarshal(raw, &network)
+		if err != nil {
+			log.Log.Error(err, "failed to unmarshal object")
+			return toV1AdmissionResponse(err)
+		}
+
+		// the resource of the network is only checked when it changes
+		var oldNetwork *sriovnetworkv1.OVSNetwork
+		if ar.Request.Operation == v1.Update {
+			oldNetwork = &sriovnetworkv1.OVSNetwork{}
+			if err = json.Unmarshal(ar.Request.OldObject.Raw, oldNetwork); err != nil {
+				log.Log.Error(err, "failed to unmarshal old object")
+				return toV1AdmissionResponse(err)
+			}
+		}
+
+		if reviewResponse.Allowed, reviewResponse.Warnings, err = validateOVSNetwork(&network, oldNetwork, ar.Request.Operation); err != nil {
+			reviewResponse.Result = &metav1.Status{
+				Reason: metav1.StatusReason(err.Error()),
+			}
+		}
 	}
 
 	return &reviewResponse